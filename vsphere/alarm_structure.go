@@ -0,0 +1,786 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	alarmExpressionOperatorOr  = "or"
+	alarmExpressionOperatorAnd = "and"
+)
+
+// alarmExpressionMaxDepth is the number of levels of expression_group blocks
+// that can be nested in an alarm. The schema for each level is generated, as
+// schemas cannot refer to themselves.
+const alarmExpressionMaxDepth = 4
+
+var alarmExpressionOperatorAllowedValues = []string{
+	alarmExpressionOperatorOr,
+	alarmExpressionOperatorAnd,
+}
+
+var alarmMetricOperatorAllowedValues = []string{
+	string(types.MetricAlarmOperatorIsAbove),
+	string(types.MetricAlarmOperatorIsBelow),
+}
+
+var alarmStateOperatorAllowedValues = []string{
+	string(types.StateAlarmOperatorIsEqual),
+	string(types.StateAlarmOperatorIsUnequal),
+}
+
+var alarmEventComparisonOperatorAllowedValues = []string{
+	string(types.EventAlarmExpressionComparisonOperatorEquals),
+	string(types.EventAlarmExpressionComparisonOperatorNotEqualTo),
+	string(types.EventAlarmExpressionComparisonOperatorStartsWith),
+	string(types.EventAlarmExpressionComparisonOperatorDoesNotStartWith),
+	string(types.EventAlarmExpressionComparisonOperatorEndsWith),
+	string(types.EventAlarmExpressionComparisonOperatorDoesNotEndWith),
+}
+
+var alarmStatusAllowedValues = []string{
+	string(types.ManagedEntityStatusGreen),
+	string(types.ManagedEntityStatusYellow),
+	string(types.ManagedEntityStatusRed),
+}
+
+// schemaAlarmSpec returns schema items for resources that need to work with
+// an AlarmSpec.
+func schemaAlarmSpec() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the alarm.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The description of the alarm.",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether or not the alarm is enabled.",
+		},
+
+		// Expressions
+		"expression_operator": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      alarmExpressionOperatorOr,
+			Description:  "How the alarm expressions are combined. Can be one of or or and.",
+			ValidateFunc: validation.StringInSlice(alarmExpressionOperatorAllowedValues, false),
+		},
+		"metric_expression": schemaAlarmExpressionList("metric_expression", alarmExpressionMaxDepth),
+		"state_expression":  schemaAlarmExpressionList("state_expression", alarmExpressionMaxDepth),
+		"event_expression":  schemaAlarmExpressionList("event_expression", alarmExpressionMaxDepth),
+		"expression_group":  schemaAlarmExpressionList("expression_group", alarmExpressionMaxDepth),
+
+		// AlarmSetting
+		"tolerance_range": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The tolerance range for metric expressions, in hundredths of a percent.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"reporting_frequency": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The minimum time, in seconds, between alarm state changes.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+
+		// Actions
+		"action_frequency": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The time, in seconds, between repeated actions for transitions that repeat.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"email_action": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An action that sends an email when the alarm changes state.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"to": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "A comma-separated list of recipients.",
+				},
+				"cc": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "A comma-separated list of CC recipients.",
+				},
+				"subject": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The subject of the email.",
+				},
+				"body": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The body of the email.",
+				},
+				"transition": schemaAlarmTransition(),
+			}},
+		},
+		"snmp_action": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An action that sends an SNMP trap when the alarm changes state.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"transition": schemaAlarmTransition(),
+			}},
+		},
+		"script_action": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An action that runs a script on vCenter when the alarm changes state.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"script": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The full path to the script, with any arguments.",
+				},
+				"transition": schemaAlarmTransition(),
+			}},
+		},
+	}
+}
+
+// schemaAlarmExpressionList returns the schema for one of the expression
+// lists in an alarm or an expression_group. depth is the number of levels of
+// expression_group blocks that can still be nested at this level.
+func schemaAlarmExpressionList(key string, depth int) *schema.Schema {
+	switch key {
+	case "metric_expression":
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An expression that triggers the alarm when a performance metric crosses a threshold.",
+			Elem:        &schema.Resource{Schema: schemaAlarmMetricExpression()},
+		}
+	case "state_expression":
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An expression that triggers the alarm when a property of the entity matches a value.",
+			Elem:        &schema.Resource{Schema: schemaAlarmStateExpression()},
+		}
+	case "event_expression":
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An expression that triggers the alarm when an event is logged against the entity.",
+			Elem:        &schema.Resource{Schema: schemaAlarmEventExpression()},
+		}
+	case "expression_group":
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "A group of expressions that are combined with their own operator, and evaluated as a single expression.",
+			Elem:        &schema.Resource{Schema: schemaAlarmExpressionGroup(depth - 1)},
+		}
+	}
+	panic(fmt.Sprintf("unknown alarm expression list %q", key))
+}
+
+// schemaAlarmExpressionGroup returns schema items for an expression_group
+// block. Further expression_group blocks can be nested in it until depth
+// reaches zero.
+func schemaAlarmExpressionGroup(depth int) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"operator": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      alarmExpressionOperatorOr,
+			Description:  "How the expressions in the group are combined. Can be one of or or and.",
+			ValidateFunc: validation.StringInSlice(alarmExpressionOperatorAllowedValues, false),
+		},
+		"metric_expression": schemaAlarmExpressionList("metric_expression", depth),
+		"state_expression":  schemaAlarmExpressionList("state_expression", depth),
+		"event_expression":  schemaAlarmExpressionList("event_expression", depth),
+	}
+	if depth > 0 {
+		s["expression_group"] = schemaAlarmExpressionList("expression_group", depth)
+	}
+	return s
+}
+
+// schemaAlarmMetricExpression returns schema items for a
+// MetricAlarmExpression.
+func schemaAlarmMetricExpression() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"object_type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The type of object the metric is collected from, such as VirtualMachine or HostSystem.",
+		},
+		"metric": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the performance counter, in the form group.name.rollup. Example: cpu.usage.average.",
+		},
+		"instance": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The instance of the metric, such as a device name. Leave empty for the aggregate.",
+		},
+		"operator": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(types.MetricAlarmOperatorIsAbove),
+			Description:  "The comparison operator. Can be one of isAbove or isBelow.",
+			ValidateFunc: validation.StringInSlice(alarmMetricOperatorAllowedValues, false),
+		},
+		"yellow": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The threshold for the yellow state. Percentages are expressed in hundredths of a percent.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"yellow_interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The time, in seconds, that the yellow threshold must be crossed before the alarm triggers.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"red": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The threshold for the red state. Percentages are expressed in hundredths of a percent.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"red_interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The time, in seconds, that the red threshold must be crossed before the alarm triggers.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+	}
+}
+
+// schemaAlarmStateExpression returns schema items for a StateAlarmExpression.
+func schemaAlarmStateExpression() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"object_type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The type of object the state is read from, such as VirtualMachine or HostSystem.",
+		},
+		"state_path": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The path to the property to test. Example: runtime.connectionState.",
+		},
+		"operator": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(types.StateAlarmOperatorIsEqual),
+			Description:  "The comparison operator. Can be one of isEqual or isUnequal.",
+			ValidateFunc: validation.StringInSlice(alarmStateOperatorAllowedValues, false),
+		},
+		"yellow": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The value that triggers the yellow state.",
+		},
+		"red": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The value that triggers the red state.",
+		},
+	}
+}
+
+// schemaAlarmEventExpression returns schema items for an
+// EventAlarmExpression.
+func schemaAlarmEventExpression() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"event_type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The type of event to match, such as HostConnectionLostEvent, or EventEx for extended events.",
+		},
+		"event_type_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The ID of an extended event to match. Only used when event_type is EventEx or ExtendedEvent.",
+		},
+		"object_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The type of object the event is logged against, such as HostSystem.",
+		},
+		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The alarm status to set when the event is matched. Can be one of green, yellow, or red.",
+			ValidateFunc: validation.StringInSlice(alarmStatusAllowedValues, false),
+		},
+		"comparison": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Conditions on the attributes of the event that must also match.",
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"attribute_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the event attribute to compare.",
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      string(types.EventAlarmExpressionComparisonOperatorEquals),
+					Description:  "The comparison operator.",
+					ValidateFunc: validation.StringInSlice(alarmEventComparisonOperatorAllowedValues, false),
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The value to compare against.",
+				},
+			}},
+		},
+	}
+}
+
+// schemaAlarmTransition returns the schema for the state transitions that an
+// alarm action fires on.
+func schemaAlarmTransition() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "The alarm state transitions that trigger the action.",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"start_state": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The state the alarm is transitioning from.",
+				ValidateFunc: validation.StringInSlice(alarmStatusAllowedValues, false),
+			},
+			"final_state": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The state the alarm is transitioning to.",
+				ValidateFunc: validation.StringInSlice(alarmStatusAllowedValues, false),
+			},
+			"repeat": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether or not the action repeats while the alarm remains in the final state.",
+			},
+		}},
+	}
+}
+
+// expandMetricAlarmExpression reads a metric_expression block into a
+// MetricAlarmExpression. counters is a map of counter names to IDs.
+func expandMetricAlarmExpression(d map[string]interface{}, counters map[string]int32) (*types.MetricAlarmExpression, error) {
+	name := d["metric"].(string)
+	id, ok := counters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown performance counter %q", name)
+	}
+	obj := &types.MetricAlarmExpression{
+		Operator: types.MetricAlarmOperator(d["operator"].(string)),
+		Type:     d["object_type"].(string),
+		Metric: types.PerfMetricId{
+			CounterId: id,
+			Instance:  d["instance"].(string),
+		},
+		Yellow:         int32(d["yellow"].(int)),
+		YellowInterval: int32(d["yellow_interval"].(int)),
+		Red:            int32(d["red"].(int)),
+		RedInterval:    int32(d["red_interval"].(int)),
+	}
+	return obj, nil
+}
+
+// flattenMetricAlarmExpression reads a MetricAlarmExpression into a
+// metric_expression block. counters is a map of counter IDs to names.
+func flattenMetricAlarmExpression(obj *types.MetricAlarmExpression, counters map[int32]string) (map[string]interface{}, error) {
+	name, ok := counters[obj.Metric.CounterId]
+	if !ok {
+		return nil, fmt.Errorf("unknown performance counter ID %d", obj.Metric.CounterId)
+	}
+	return map[string]interface{}{
+		"object_type":     obj.Type,
+		"metric":          name,
+		"instance":        obj.Metric.Instance,
+		"operator":        string(obj.Operator),
+		"yellow":          int(obj.Yellow),
+		"yellow_interval": int(obj.YellowInterval),
+		"red":             int(obj.Red),
+		"red_interval":    int(obj.RedInterval),
+	}, nil
+}
+
+// expandStateAlarmExpression reads a state_expression block into a
+// StateAlarmExpression.
+func expandStateAlarmExpression(d map[string]interface{}) *types.StateAlarmExpression {
+	return &types.StateAlarmExpression{
+		Operator:  types.StateAlarmOperator(d["operator"].(string)),
+		Type:      d["object_type"].(string),
+		StatePath: d["state_path"].(string),
+		Yellow:    d["yellow"].(string),
+		Red:       d["red"].(string),
+	}
+}
+
+// flattenStateAlarmExpression reads a StateAlarmExpression into a
+// state_expression block.
+func flattenStateAlarmExpression(obj *types.StateAlarmExpression) map[string]interface{} {
+	return map[string]interface{}{
+		"object_type": obj.Type,
+		"state_path":  obj.StatePath,
+		"operator":    string(obj.Operator),
+		"yellow":      obj.Yellow,
+		"red":         obj.Red,
+	}
+}
+
+// expandEventAlarmExpression reads an event_expression block into an
+// EventAlarmExpression.
+func expandEventAlarmExpression(d map[string]interface{}) *types.EventAlarmExpression {
+	obj := &types.EventAlarmExpression{
+		EventType:   d["event_type"].(string),
+		EventTypeId: d["event_type_id"].(string),
+		ObjectType:  d["object_type"].(string),
+		Status:      types.ManagedEntityStatus(d["status"].(string)),
+	}
+	for _, v := range d["comparison"].([]interface{}) {
+		c := v.(map[string]interface{})
+		obj.Comparisons = append(obj.Comparisons, types.EventAlarmExpressionComparison{
+			AttributeName: c["attribute_name"].(string),
+			Operator:      c["operator"].(string),
+			Value:         c["value"].(string),
+		})
+	}
+	return obj
+}
+
+// flattenEventAlarmExpression reads an EventAlarmExpression into an
+// event_expression block.
+func flattenEventAlarmExpression(obj *types.EventAlarmExpression) map[string]interface{} {
+	var comparisons []interface{}
+	for _, c := range obj.Comparisons {
+		comparisons = append(comparisons, map[string]interface{}{
+			"attribute_name": c.AttributeName,
+			"operator":       c.Operator,
+			"value":          c.Value,
+		})
+	}
+	return map[string]interface{}{
+		"event_type":    obj.EventType,
+		"event_type_id": obj.EventTypeId,
+		"object_type":   obj.ObjectType,
+		"status":        string(obj.Status),
+		"comparison":    comparisons,
+	}
+}
+
+// expandAlarmExpression reads the expression blocks from ResourceData and
+// combines them into a single OrAlarmExpression or AndAlarmExpression.
+func expandAlarmExpression(d *schema.ResourceData, counters map[string]int32) (types.BaseAlarmExpression, error) {
+	m := map[string]interface{}{
+		"operator":          d.Get("expression_operator"),
+		"metric_expression": d.Get("metric_expression"),
+		"state_expression":  d.Get("state_expression"),
+		"event_expression":  d.Get("event_expression"),
+		"expression_group":  d.Get("expression_group"),
+	}
+	return expandAlarmExpressionGroup(m, counters)
+}
+
+// expandAlarmExpressionGroup reads the expressions in an expression_group
+// block, or the top level of an alarm, and combines them into a single
+// OrAlarmExpression or AndAlarmExpression. Nested groups are expanded
+// recursively.
+func expandAlarmExpressionGroup(d map[string]interface{}, counters map[string]int32) (types.BaseAlarmExpression, error) {
+	var exprs []types.BaseAlarmExpression
+	for _, v := range d["metric_expression"].([]interface{}) {
+		expr, err := expandMetricAlarmExpression(v.(map[string]interface{}), counters)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	for _, v := range d["state_expression"].([]interface{}) {
+		exprs = append(exprs, expandStateAlarmExpression(v.(map[string]interface{})))
+	}
+	for _, v := range d["event_expression"].([]interface{}) {
+		exprs = append(exprs, expandEventAlarmExpression(v.(map[string]interface{})))
+	}
+	if groups, ok := d["expression_group"].([]interface{}); ok {
+		for _, v := range groups {
+			expr, err := expandAlarmExpressionGroup(v.(map[string]interface{}), counters)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, expr)
+		}
+	}
+	if len(exprs) < 1 {
+		return nil, fmt.Errorf("at least one metric_expression, state_expression, event_expression, or expression_group must be defined")
+	}
+
+	if d["operator"].(string) == alarmExpressionOperatorAnd {
+		return &types.AndAlarmExpression{Expression: exprs}, nil
+	}
+	return &types.OrAlarmExpression{Expression: exprs}, nil
+}
+
+// flattenAlarmExpression saves an alarm expression tree into ResourceData.
+func flattenAlarmExpression(d *schema.ResourceData, obj types.BaseAlarmExpression, counters map[int32]string) error {
+	m, err := flattenAlarmExpressionGroup(obj, counters, alarmExpressionMaxDepth)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("expression_operator", m["operator"]); err != nil {
+		return err
+	}
+	for _, k := range []string{"metric_expression", "state_expression", "event_expression", "expression_group"} {
+		if err := d.Set(k, m[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenAlarmExpressionGroup reads an alarm expression into an
+// expression_group block. OR and AND expressions nested in obj are flattened
+// recursively into further expression_group blocks, up to depth levels. An
+// expression that is not an OR or AND is treated as an OR with a single
+// expression.
+func flattenAlarmExpressionGroup(obj types.BaseAlarmExpression, counters map[int32]string, depth int) (map[string]interface{}, error) {
+	op := alarmExpressionOperatorOr
+	var exprs []types.BaseAlarmExpression
+	switch e := obj.(type) {
+	case *types.OrAlarmExpression:
+		exprs = e.Expression
+	case *types.AndAlarmExpression:
+		op = alarmExpressionOperatorAnd
+		exprs = e.Expression
+	default:
+		exprs = []types.BaseAlarmExpression{obj}
+	}
+
+	var metrics, states, events, groups []interface{}
+	for _, expr := range exprs {
+		switch e := expr.(type) {
+		case *types.MetricAlarmExpression:
+			m, err := flattenMetricAlarmExpression(e, counters)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, m)
+		case *types.StateAlarmExpression:
+			states = append(states, flattenStateAlarmExpression(e))
+		case *types.EventAlarmExpression:
+			events = append(events, flattenEventAlarmExpression(e))
+		case *types.OrAlarmExpression, *types.AndAlarmExpression:
+			if depth < 1 {
+				return nil, fmt.Errorf("alarm expressions are nested more than %d levels deep", alarmExpressionMaxDepth)
+			}
+			g, err := flattenAlarmExpressionGroup(expr, counters, depth-1)
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, g)
+		default:
+			return nil, fmt.Errorf("unsupported alarm expression type %T", expr)
+		}
+	}
+
+	m := map[string]interface{}{
+		"operator":          op,
+		"metric_expression": metrics,
+		"state_expression":  states,
+		"event_expression":  events,
+	}
+	if depth > 0 {
+		m["expression_group"] = groups
+	}
+	return m, nil
+}
+
+// expandAlarmTriggeringAction reads an action block into an
+// AlarmTriggeringAction wrapping the supplied action.
+func expandAlarmTriggeringAction(d map[string]interface{}, action types.BaseAction) *types.AlarmTriggeringAction {
+	obj := &types.AlarmTriggeringAction{
+		Action: action,
+	}
+	for _, v := range d["transition"].(*schema.Set).List() {
+		t := v.(map[string]interface{})
+		obj.TransitionSpecs = append(obj.TransitionSpecs, types.AlarmTriggeringActionTransitionSpec{
+			StartState: types.ManagedEntityStatus(t["start_state"].(string)),
+			FinalState: types.ManagedEntityStatus(t["final_state"].(string)),
+			Repeats:    t["repeat"].(bool),
+		})
+	}
+	return obj
+}
+
+// flattenAlarmTriggeringActionTransitions reads the state transitions of an
+// AlarmTriggeringAction. Transitions set through the legacy boolean fields are
+// included as non-repeating transitions.
+func flattenAlarmTriggeringActionTransitions(obj *types.AlarmTriggeringAction) []interface{} {
+	var transitions []interface{}
+	seen := make(map[string]bool)
+	for _, t := range obj.TransitionSpecs {
+		transitions = append(transitions, map[string]interface{}{
+			"start_state": string(t.StartState),
+			"final_state": string(t.FinalState),
+			"repeat":      t.Repeats,
+		})
+		seen[string(t.StartState)+string(t.FinalState)] = true
+	}
+	legacy := []struct {
+		set        bool
+		start, end types.ManagedEntityStatus
+	}{
+		{obj.Green2yellow, types.ManagedEntityStatusGreen, types.ManagedEntityStatusYellow},
+		{obj.Yellow2red, types.ManagedEntityStatusYellow, types.ManagedEntityStatusRed},
+		{obj.Red2yellow, types.ManagedEntityStatusRed, types.ManagedEntityStatusYellow},
+		{obj.Yellow2green, types.ManagedEntityStatusYellow, types.ManagedEntityStatusGreen},
+	}
+	for _, l := range legacy {
+		if l.set && !seen[string(l.start)+string(l.end)] {
+			transitions = append(transitions, map[string]interface{}{
+				"start_state": string(l.start),
+				"final_state": string(l.end),
+				"repeat":      false,
+			})
+		}
+	}
+	return transitions
+}
+
+// expandAlarmAction reads the action blocks from ResourceData into a
+// GroupAlarmAction. nil is returned if no actions are defined.
+func expandAlarmAction(d *schema.ResourceData) types.BaseAlarmAction {
+	var actions []types.BaseAlarmAction
+	for _, v := range d.Get("email_action").([]interface{}) {
+		m := v.(map[string]interface{})
+		actions = append(actions, expandAlarmTriggeringAction(m, &types.SendEmailAction{
+			ToList:  m["to"].(string),
+			CcList:  m["cc"].(string),
+			Subject: m["subject"].(string),
+			Body:    m["body"].(string),
+		}))
+	}
+	for _, v := range d.Get("snmp_action").([]interface{}) {
+		actions = append(actions, expandAlarmTriggeringAction(v.(map[string]interface{}), &types.SendSNMPAction{}))
+	}
+	for _, v := range d.Get("script_action").([]interface{}) {
+		m := v.(map[string]interface{})
+		actions = append(actions, expandAlarmTriggeringAction(m, &types.RunScriptAction{
+			Script: m["script"].(string),
+		}))
+	}
+	if len(actions) < 1 {
+		return nil
+	}
+	return &types.GroupAlarmAction{Action: actions}
+}
+
+// flattenAlarmAction saves the actions of an alarm into ResourceData. Action
+// types that are not supported by the resource are skipped.
+func flattenAlarmAction(d *schema.ResourceData, obj types.BaseAlarmAction) error {
+	var actions []types.BaseAlarmAction
+	switch a := obj.(type) {
+	case nil:
+	case *types.GroupAlarmAction:
+		actions = a.Action
+	default:
+		actions = []types.BaseAlarmAction{obj}
+	}
+
+	var emails, snmps, scripts []interface{}
+	for _, action := range actions {
+		ta, ok := action.(*types.AlarmTriggeringAction)
+		if !ok {
+			log.Printf("[DEBUG] Skipping unsupported alarm action type %T", action)
+			continue
+		}
+		transitions := flattenAlarmTriggeringActionTransitions(ta)
+		switch a := ta.Action.(type) {
+		case *types.SendEmailAction:
+			emails = append(emails, map[string]interface{}{
+				"to":         a.ToList,
+				"cc":         a.CcList,
+				"subject":    a.Subject,
+				"body":       a.Body,
+				"transition": transitions,
+			})
+		case *types.SendSNMPAction:
+			snmps = append(snmps, map[string]interface{}{
+				"transition": transitions,
+			})
+		case *types.RunScriptAction:
+			scripts = append(scripts, map[string]interface{}{
+				"script":     a.Script,
+				"transition": transitions,
+			})
+		default:
+			log.Printf("[DEBUG] Skipping unsupported alarm action type %T", ta.Action)
+		}
+	}
+
+	if err := d.Set("email_action", emails); err != nil {
+		return err
+	}
+	if err := d.Set("snmp_action", snmps); err != nil {
+		return err
+	}
+	return d.Set("script_action", scripts)
+}
+
+// expandAlarmSpec reads certain ResourceData keys and returns an AlarmSpec.
+// counters is a map of performance counter names to IDs.
+func expandAlarmSpec(d *schema.ResourceData, counters map[string]int32) (*types.AlarmSpec, error) {
+	expr, err := expandAlarmExpression(d, counters)
+	if err != nil {
+		return nil, err
+	}
+	obj := &types.AlarmSpec{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		Enabled:         d.Get("enabled").(bool),
+		Expression:      expr,
+		Action:          expandAlarmAction(d),
+		ActionFrequency: int32(d.Get("action_frequency").(int)),
+		Setting: &types.AlarmSetting{
+			ToleranceRange:     int32(d.Get("tolerance_range").(int)),
+			ReportingFrequency: int32(d.Get("reporting_frequency").(int)),
+		},
+	}
+	return obj, nil
+}
+
+// flattenAlarmInfo reads various fields from an AlarmInfo into the passed in
+// ResourceData. counters is a map of performance counter IDs to names.
+func flattenAlarmInfo(d *schema.ResourceData, obj *types.AlarmInfo, counters map[int32]string) error {
+	d.Set("name", obj.Name)
+	d.Set("description", obj.Description)
+	d.Set("enabled", obj.Enabled)
+	d.Set("action_frequency", obj.ActionFrequency)
+	if obj.Setting != nil {
+		d.Set("tolerance_range", obj.Setting.ToleranceRange)
+		d.Set("reporting_frequency", obj.Setting.ReportingFrequency)
+	}
+	if err := flattenAlarmExpression(d, obj.Expression, counters); err != nil {
+		return err
+	}
+	return flattenAlarmAction(d, obj.Action)
+}
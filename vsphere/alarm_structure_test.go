@@ -0,0 +1,64 @@
+package vsphere
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAlarmExpressionNested(t *testing.T) {
+	state := &types.StateAlarmExpression{
+		Operator:  types.StateAlarmOperatorIsEqual,
+		Type:      "HostSystem",
+		StatePath: "runtime.connectionState",
+		Red:       "notResponding",
+	}
+	event := &types.EventAlarmExpression{
+		EventType:  "HostConnectionLostEvent",
+		ObjectType: "HostSystem",
+		Status:     types.ManagedEntityStatusRed,
+	}
+	expected := &types.OrAlarmExpression{
+		Expression: []types.BaseAlarmExpression{
+			state,
+			&types.AndAlarmExpression{
+				Expression: []types.BaseAlarmExpression{
+					event,
+					&types.OrAlarmExpression{
+						Expression: []types.BaseAlarmExpression{state, event},
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, schemaAlarmSpec(), map[string]interface{}{})
+	if err := flattenAlarmExpression(d, expected, nil); err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	actual, err := expandAlarmExpression(d, nil)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestFlattenAlarmExpressionTooDeep(t *testing.T) {
+	var expr types.BaseAlarmExpression = &types.StateAlarmExpression{
+		Operator:  types.StateAlarmOperatorIsEqual,
+		Type:      "HostSystem",
+		StatePath: "runtime.connectionState",
+	}
+	for i := 0; i <= alarmExpressionMaxDepth+1; i++ {
+		expr = &types.OrAlarmExpression{Expression: []types.BaseAlarmExpression{expr}}
+	}
+
+	d := schema.TestResourceDataRaw(t, schemaAlarmSpec(), map[string]interface{}{})
+	if err := flattenAlarmExpression(d, expr, nil); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
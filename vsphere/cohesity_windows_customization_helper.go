@@ -0,0 +1,353 @@
+package vsphere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualdisk"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/vmworkflow"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	// cohesityCustomizationCabinetKey is the guestinfo key that the datastore
+	// path of the staged cabinet file is attached to the virtual machine with.
+	cohesityCustomizationCabinetKey = "guestinfo.cohesity.customization.cabinet"
+
+	// cohesityCustomizationResultDir and cohesityCustomizationResultFile are
+	// the directory and name of the file in the guest that the guest agent
+	// reports the result of the customization in. Values that the guest sets in
+	// guestinfo are not visible through the API, so the result file is read
+	// through guest operations instead.
+	cohesityCustomizationResultDir  = `C:\ProgramData\Cohesity\Customization`
+	cohesityCustomizationResultFile = "result.json"
+
+	// cohesityCustomizationGuestUser is the local account that guest
+	// operations are authenticated as, along with admin_password.
+	cohesityCustomizationGuestUser = "Administrator"
+
+	// The values of the status in the result file that indicate completion.
+	cohesityCustomizationStatusSucceeded = "succeeded"
+	cohesityCustomizationStatusFailed    = "failed"
+
+	// cohesityCustomizationDefaultFileName is the name of a generated cabinet
+	// file staged in the folder of the virtual machine when destination_file
+	// is not set.
+	cohesityCustomizationDefaultFileName = "cohesity-customization.cab"
+
+	// cohesityCustomizationPollInterval is the interval at which the guest
+	// result is polled for.
+	cohesityCustomizationPollInterval = time.Second * 5
+)
+
+// cohesityWindowsCustomizationCabinet represents a cabinet file that has been
+// generated, staged in a datastore, and attached to a virtual machine.
+type cohesityWindowsCustomizationCabinet struct {
+	client *govmomi.Client
+	dc     *object.Datacenter
+	ds     *object.Datastore
+	path   string
+
+	// The administrator password that guest operations are authenticated with,
+	// and the server time that the cabinet was staged at. Result files that are
+	// older than this are left over from an earlier customization.
+	password string
+	since    time.Time
+}
+
+// cohesityWindowsCustomizationResult is the result of a customization, as
+// reported by the guest agent in the result file.
+type cohesityWindowsCustomizationResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// String implements Stringer for cohesityWindowsCustomizationCabinet.
+func (c *cohesityWindowsCustomizationCabinet) String() string {
+	return c.ds.Path(c.path)
+}
+
+// stageCohesityWindowsCustomization generates the cohesity windows
+// customization cabinet from the customize block in the supplied
+// ResourceData, uploads it to the datastore, and attaches it to the virtual
+// machine. The virtual machine should be powered off.
+func stageCohesityWindowsCustomization(d *schema.ResourceData, client *govmomi.Client, vm *object.VirtualMachine) (*cohesityWindowsCustomizationCabinet, error) {
+	custom, err := vmworkflow.ExpandCohesityWindowsCustomization(d, "")
+	if err != nil {
+		return nil, err
+	}
+	b, err := custom.Cabinet()
+	if err != nil {
+		return nil, fmt.Errorf("error generating cabinet file: %s", err)
+	}
+
+	dc, err := getDatacenter(client, d.Get("customize.0.cohesity_windows_customization_options.0.datacenter").(string))
+	if err != nil {
+		return nil, fmt.Errorf("error locating datacenter: %s", err)
+	}
+	props, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching virtual machine properties: %s", err)
+	}
+	vmxPath, ok := virtualdisk.DatastorePathFromString(props.Config.Files.VmPathName)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse virtual machine configuration path %q", props.Config.Files.VmPathName)
+	}
+	dsName := vmxPath.Datastore
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.datastore"); ok {
+		dsName = v.(string)
+	}
+	ds, err := datastore.FromPath(client, dsName, dc)
+	if err != nil {
+		return nil, fmt.Errorf("error locating datastore %q: %s", dsName, err)
+	}
+	p := path.Join(path.Dir(vmxPath.Path), cohesityCustomizationDefaultFileName)
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.destination_file"); ok {
+		p = v.(string)
+	}
+	c := &cohesityWindowsCustomizationCabinet{
+		client:   client,
+		dc:       dc,
+		ds:       ds,
+		path:     p,
+		password: custom.AdminPassword,
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	now, err := methods.GetCurrentTime(tctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching server time: %s", err)
+	}
+	c.since = *now
+
+	log.Printf("[DEBUG] Uploading cabinet file to %q", c)
+	if d.Get("customize.0.cohesity_windows_customization_options.0.create_directories").(bool) {
+		fm := object.NewFileManager(client.Client)
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		if err := fm.MakeDirectory(ctx, ds.Path(path.Dir(p)), dc, true); err != nil {
+			return nil, fmt.Errorf("error creating directory for cabinet file: %s", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	param := soap.DefaultUpload
+	param.ContentLength = int64(len(b))
+	if err := ds.Upload(ctx, bytes.NewReader(b), p, &param); err != nil {
+		return nil, fmt.Errorf("error uploading cabinet file to %q: %s", c, err)
+	}
+
+	// Attach the cabinet. The guest agent reads its path from guestinfo.
+	spec := types.VirtualMachineConfigSpec{
+		ExtraConfig: []types.BaseOptionValue{
+			&types.OptionValue{Key: cohesityCustomizationCabinetKey, Value: c.String()},
+		},
+	}
+	if err := virtualmachine.Reconfigure(vm, spec); err != nil {
+		if derr := c.Remove(vm); derr != nil {
+			log.Printf("[DEBUG] Error cleaning up cabinet file %q: %s", c, derr)
+		}
+		return nil, fmt.Errorf("error attaching cabinet file: %s", err)
+	}
+	log.Printf("[DEBUG] Cabinet file %q attached to virtual machine %q", c, vm.InventoryPath)
+	return c, nil
+}
+
+// CanWait returns true if the result of the customization can be waited on.
+// This requires the administrator password to authenticate guest operations
+// with.
+func (c *cohesityWindowsCustomizationCabinet) CanWait() bool {
+	return c.password != ""
+}
+
+// Wait waits for the guest agent to report the result of the customization,
+// returning an error if it failed. The timeout is in minutes - a value of less
+// than 1 skips the wait.
+//
+// The result is read from the result file in the guest through guest
+// operations, once VMware Tools is running. Errors reading the file are not
+// fatal, as the guest may be rebooting, or the new administrator password may
+// not be in effect yet.
+func (c *cohesityWindowsCustomizationCabinet) Wait(vm *object.VirtualMachine, timeout int) error {
+	if timeout < 1 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Minute)
+	defer cancel()
+	for {
+		result, err := c.result(vm)
+		if err != nil {
+			log.Printf("[DEBUG] Could not read cohesity windows customization result from %q: %s", vm.InventoryPath, err)
+		}
+		if result != nil {
+			switch result.Status {
+			case cohesityCustomizationStatusSucceeded:
+				return nil
+			case cohesityCustomizationStatusFailed:
+				if result.Message == "" {
+					return errors.New("no message reported by guest")
+				}
+				return errors.New(result.Message)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New("timeout waiting for customization to complete")
+		case <-time.After(cohesityCustomizationPollInterval):
+		}
+	}
+}
+
+// result reads the result file from the guest. A nil result is returned if
+// VMware Tools is not running yet, or the guest agent has not written a result
+// for this customization.
+func (c *cohesityWindowsCustomizationCabinet) result(vm *object.VirtualMachine) (*cohesityWindowsCustomizationResult, error) {
+	props, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return nil, err
+	}
+	if props.Guest == nil || props.Guest.ToolsRunningStatus != string(types.VirtualMachineToolsRunningStatusGuestToolsRunning) {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var gom mo.GuestOperationsManager
+	if err := c.client.RetrieveOne(ctx, *c.client.ServiceContent.GuestOperationsManager, []string{"fileManager"}, &gom); err != nil {
+		return nil, err
+	}
+	if gom.FileManager == nil {
+		return nil, errors.New("guest file manager not available")
+	}
+	auth := &types.NamePasswordAuthentication{
+		Username: cohesityCustomizationGuestUser,
+		Password: c.password,
+	}
+
+	list, err := methods.ListFilesInGuest(ctx, c.client, &types.ListFilesInGuest{
+		This:         *gom.FileManager,
+		Vm:           vm.Reference(),
+		Auth:         auth,
+		FilePath:     cohesityCustomizationResultDir,
+		MatchPattern: regexp.QuoteMeta(cohesityCustomizationResultFile),
+	})
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, f := range list.Returnval.Files {
+		if f.Path != cohesityCustomizationResultFile || f.Attributes == nil {
+			continue
+		}
+		mtime := f.Attributes.GetGuestFileAttributes().ModificationTime
+		found = mtime != nil && !mtime.Before(c.since)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	transfer, err := methods.InitiateFileTransferFromGuest(ctx, c.client, &types.InitiateFileTransferFromGuest{
+		This:          *gom.FileManager,
+		Vm:            vm.Reference(),
+		Auth:          auth,
+		GuestFilePath: cohesityCustomizationResultDir + `\` + cohesityCustomizationResultFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u, err := c.client.ParseURL(transfer.Returnval.Url)
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := c.client.Download(ctx, u, &soap.DefaultDownload)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var result cohesityWindowsCustomizationResult
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error parsing result file: %s", err)
+	}
+	return &result, nil
+}
+
+// Remove detaches the cabinet from the virtual machine and deletes the staged
+// file from the datastore.
+func (c *cohesityWindowsCustomizationCabinet) Remove(vm *object.VirtualMachine) error {
+	log.Printf("[DEBUG] Removing cabinet file %q", c)
+	spec := types.VirtualMachineConfigSpec{
+		ExtraConfig: []types.BaseOptionValue{
+			&types.OptionValue{Key: cohesityCustomizationCabinetKey, Value: ""},
+		},
+	}
+	if err := virtualmachine.Reconfigure(vm, spec); err != nil {
+		return fmt.Errorf("error detaching cabinet file: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := c.ds.NewFileManager(c.dc, false).DeleteFile(ctx, c.path); err != nil {
+		return fmt.Errorf("error deleting cabinet file: %s", err)
+	}
+	return nil
+}
+
+// copyCohesityWindowsCustomization copies or uploads an existing cabinet file
+// referenced by source_file in cohesity_windows_customization_options. The
+// cabinet must be attached to the virtual machine through extra_config.
+func copyCohesityWindowsCustomization(d *schema.ResourceData, client *govmomi.Client) error {
+	if _, ok := d.GetOk("extra_config"); !ok {
+		return fmt.Errorf("extra_config not set")
+	}
+
+	f := file{}
+
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.source_datacenter"); ok {
+		f.sourceDatacenter = v.(string)
+		f.copyFile = true
+	}
+
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.datacenter"); ok {
+		f.datacenter = v.(string)
+	}
+
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.source_datastore"); ok {
+		f.sourceDatastore = v.(string)
+		f.copyFile = true
+	}
+
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.datastore"); ok {
+		f.datastore = v.(string)
+	} else {
+		return fmt.Errorf("datastore argument is required")
+	}
+
+	f.sourceFile = d.Get("customize.0.cohesity_windows_customization_options.0.source_file").(string)
+
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.destination_file"); ok {
+		f.destinationFile = v.(string)
+	} else {
+		return fmt.Errorf("destination_file argument is required")
+	}
+
+	if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.create_directories"); ok {
+		f.createDirectories = v.(bool)
+	}
+
+	return createFile(client, &f)
+}
@@ -0,0 +1,213 @@
+package vsphere
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	datastoreFileTypeFile     = "file"
+	datastoreFileTypeFolder   = "folder"
+	datastoreFileTypeVMDisk   = "vm_disk"
+	datastoreFileTypeVMConfig = "vm_config"
+	datastoreFileTypeISO      = "iso"
+	datastoreFileTypeFloppy   = "floppy"
+	datastoreFileTypeLog      = "log"
+)
+
+var datastoreFileTypeAllowedValues = []string{
+	datastoreFileTypeFolder,
+	datastoreFileTypeVMDisk,
+	datastoreFileTypeVMConfig,
+	datastoreFileTypeISO,
+	datastoreFileTypeFloppy,
+	datastoreFileTypeLog,
+}
+
+func dataSourceVSphereDatastoreFiles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereDatastoreFilesRead,
+
+		Schema: map[string]*schema.Schema{
+			"datastore_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The managed object ID of the datastore to search.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The directory in the datastore to search. The default is the root of the datastore.",
+			},
+			"match_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Glob patterns that file names must match, such as *.iso. The default matches all files.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"recursive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Search the subdirectories of path as well.",
+			},
+			"file_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return files of these types. Can be any of folder, vm_disk, vm_config, iso, floppy, or log. The default returns all files.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(datastoreFileTypeAllowedValues, false),
+				},
+			},
+			"files": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The files that matched the search, sorted by path.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the file, relative to the root of the datastore.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the file, in bytes.",
+						},
+						"modification_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the file was last modified, in RFC3339 format.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the file. One of file, folder, vm_disk, vm_config, iso, floppy, or log.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereDatastoreFilesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	ds, err := datastore.FromID(client, d.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore: %s", err)
+	}
+
+	spec := &types.HostDatastoreBrowserSearchSpec{
+		MatchPattern: structure.SliceInterfacesToStrings(d.Get("match_patterns").([]interface{})),
+		Details: &types.FileQueryFlags{
+			FileType:     true,
+			FileSize:     true,
+			Modification: true,
+		},
+	}
+	for _, t := range structure.SliceInterfacesToStrings(d.Get("file_types").([]interface{})) {
+		spec.Query = append(spec.Query, datastoreFileQuery(t))
+	}
+
+	dir := d.Get("path").(string)
+	var results []types.HostDatastoreBrowserSearchResults
+	if d.Get("recursive").(bool) {
+		results, err = datastore.SearchDatastoreSubFolders(ds, dir, spec)
+	} else {
+		var r *types.HostDatastoreBrowserSearchResults
+		if r, err = datastore.SearchDatastoreFolder(ds, dir, spec); err == nil {
+			results = append(results, *r)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error searching datastore %q: %s", ds.Name(), err)
+	}
+
+	files := flattenDatastoreFiles(results)
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("files", files); err != nil {
+		return fmt.Errorf("error setting files: %s", err)
+	}
+	return nil
+}
+
+// datastoreFileQuery returns the FileQuery for a file type in file_types.
+func datastoreFileQuery(t string) types.BaseFileQuery {
+	switch t {
+	case datastoreFileTypeFolder:
+		return &types.FolderFileQuery{}
+	case datastoreFileTypeVMDisk:
+		return &types.VmDiskFileQuery{}
+	case datastoreFileTypeVMConfig:
+		return &types.VmConfigFileQuery{}
+	case datastoreFileTypeISO:
+		return &types.IsoImageFileQuery{}
+	case datastoreFileTypeFloppy:
+		return &types.FloppyImageFileQuery{}
+	case datastoreFileTypeLog:
+		return &types.VmLogFileQuery{}
+	}
+	return &types.FileQuery{}
+}
+
+// datastoreFileType returns the file type of a FileInfo returned by a
+// datastore search.
+func datastoreFileType(bfi types.BaseFileInfo) string {
+	switch bfi.(type) {
+	case *types.FolderFileInfo:
+		return datastoreFileTypeFolder
+	case *types.VmDiskFileInfo:
+		return datastoreFileTypeVMDisk
+	case *types.VmConfigFileInfo, *types.TemplateConfigFileInfo:
+		return datastoreFileTypeVMConfig
+	case *types.IsoImageFileInfo:
+		return datastoreFileTypeISO
+	case *types.FloppyImageFileInfo:
+		return datastoreFileTypeFloppy
+	case *types.VmLogFileInfo:
+		return datastoreFileTypeLog
+	}
+	return datastoreFileTypeFile
+}
+
+// flattenDatastoreFiles converts the results of a datastore search into the
+// files attribute, sorted by path.
+func flattenDatastoreFiles(results []types.HostDatastoreBrowserSearchResults) []interface{} {
+	var files []map[string]interface{}
+	for _, result := range results {
+		var folder object.DatastorePath
+		folder.FromString(result.FolderPath)
+		for _, bfi := range result.File {
+			fi := bfi.GetFileInfo()
+			var modified string
+			if fi.Modification != nil {
+				modified = fi.Modification.Format(time.RFC3339)
+			}
+			files = append(files, map[string]interface{}{
+				"path":              path.Join(folder.Path, fi.Path),
+				"size":              int(fi.FileSize),
+				"modification_time": modified,
+				"type":              datastoreFileType(bfi),
+			})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i]["path"].(string) < files[j]["path"].(string) })
+
+	var out []interface{}
+	for _, f := range files {
+		out = append(out, f)
+	}
+	return out
+}
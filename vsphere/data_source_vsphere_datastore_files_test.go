@@ -0,0 +1,114 @@
+package vsphere
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereDatastoreFiles_basic(t *testing.T) {
+	dir := testAccDataSourceVSphereDatastoreFilesSourceDir(t)
+	defer os.RemoveAll(dir)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDatastoreFilesConfig(dir, `"*.iso"`, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_datastore_files.files", "files.#", "1"),
+					resource.TestCheckResourceAttr("data.vsphere_datastore_files.files", "files.0.path", "terraform-test-files/test.iso"),
+					resource.TestCheckResourceAttr("data.vsphere_datastore_files.files", "files.0.size", "4"),
+					resource.TestCheckResourceAttrSet("data.vsphere_datastore_files.files", "files.0.modification_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereDatastoreFiles_recursiveFileTypes(t *testing.T) {
+	dir := testAccDataSourceVSphereDatastoreFilesSourceDir(t)
+	defer os.RemoveAll(dir)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDatastoreFilesConfig(dir, "", `
+  recursive  = true
+  file_types = ["log"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_datastore_files.files", "files.#", "1"),
+					resource.TestCheckResourceAttr("data.vsphere_datastore_files.files", "files.0.path", "terraform-test-files/logs/vmware.log"),
+					resource.TestCheckResourceAttr("data.vsphere_datastore_files.files", "files.0.type", "log"),
+				),
+			},
+		},
+	})
+}
+
+// testAccDataSourceVSphereDatastoreFilesSourceDir creates a local directory
+// with the files that are synced to the datastore for the tests.
+func testAccDataSourceVSphereDatastoreFilesSourceDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "tf_test_dir")
+	if err != nil {
+		t.Fatalf("error %s", err)
+	}
+	testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "test.iso", "test")
+	testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "logs/vmware.log", "test")
+	return dir
+}
+
+func testAccDataSourceVSphereDatastoreFilesConfig(dir, patterns, extra string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_datastore_directory" "dir" {
+  datacenter = "${var.datacenter}"
+  datastore  = "${var.datastore}"
+  path       = "terraform-test-files"
+  source_dir = "%s"
+}
+
+data "vsphere_datastore_files" "files" {
+  datastore_id   = "${data.vsphere_datastore.datastore.id}"
+  path           = "${vsphere_datastore_directory.dir.path}"
+  match_patterns = [%s]
+%s
+  depends_on = ["vsphere_datastore_directory.dir"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		dir,
+		patterns,
+		extra,
+	)
+}
@@ -0,0 +1,234 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/storagepod"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereDatastoreStats() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereDatastoreStatsRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the datacenter to report datastores for.",
+				Required:    true,
+			},
+			"datastore_cluster_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of a datastore cluster to limit the report to.",
+				Optional:    true,
+			},
+			"best_fit": {
+				Type:        schema.TypeList,
+				Description: "Select the datastore with the most free space that meets the supplied criteria, and return it in datastore_id.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_free_space_gb": {
+							Type:         schema.TypeInt,
+							Description:  "The minimum amount of free space, in GB, that the selected datastore must have.",
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"exclude_tag_ids": {
+							Type:        schema.TypeSet,
+							Description: "A list of tag IDs. Datastores that have any of these tags attached are not selected.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"datastore_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the datastore selected by best_fit.",
+				Computed:    true,
+			},
+			"datastores": {
+				Type:        schema.TypeList,
+				Description: "The datastores in the datacenter or datastore cluster, sorted by name.",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: schemaDatastoreStats()},
+			},
+		},
+	}
+}
+
+// schemaDatastoreStats returns the schema for a single datastore in the
+// datastores attribute of the vsphere_datastore_stats data source.
+func schemaDatastoreStats() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the datastore.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the datastore.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of the datastore.",
+		},
+		"capacity": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Maximum capacity of the datastore, in MB.",
+		},
+		"free_space": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Available space of the datastore, in MB.",
+		},
+		"uncommitted_space": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Total additional storage space, in MB, potentially used by all virtual machines on this datastore.",
+		},
+		"provisioned_space": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The storage space, in MB, that would be used if all thin-provisioned disks on this datastore were fully grown.",
+		},
+		"accessible": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not the datastore is accessible.",
+		},
+		"maintenance_mode": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The maintenance mode state of the datastore.",
+		},
+		"host_system_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The managed object IDs of the hosts that have the datastore mounted.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func dataSourceVSphereDatastoreStatsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	var container types.ManagedObjectReference
+	if id, ok := d.GetOk("datastore_cluster_id"); ok {
+		pod, err := storagepod.FromID(client, id.(string))
+		if err != nil {
+			return fmt.Errorf("error loading datastore cluster: %s", err)
+		}
+		container = pod.Reference()
+	} else {
+		dc, err := datacenterFromID(client, d.Get("datacenter_id").(string))
+		if err != nil {
+			return fmt.Errorf("cannot locate datacenter: %s", err)
+		}
+		container = dc.Reference()
+	}
+
+	var dss []mo.Datastore
+	if err := inventoryListRetrieve(client, container, "Datastore", []string{"name", "summary", "host"}, &dss); err != nil {
+		return err
+	}
+	sort.Slice(dss, func(i, j int) bool { return dss[i].Name < dss[j].Name })
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("datastores", flattenDatastoreStats(dss)); err != nil {
+		return fmt.Errorf("error setting datastores: %s", err)
+	}
+
+	if _, ok := d.GetOk("best_fit"); !ok {
+		return nil
+	}
+	ds, err := datastoreStatsBestFit(d, meta, dss)
+	if err != nil {
+		return err
+	}
+	return d.Set("datastore_id", ds.Reference().Value)
+}
+
+// flattenDatastoreStats converts a list of datastores into the format used by
+// the datastores attribute of the vsphere_datastore_stats data source.
+func flattenDatastoreStats(dss []mo.Datastore) []interface{} {
+	var result []interface{}
+	for _, ds := range dss {
+		var hosts []string
+		for _, mount := range ds.Host {
+			if mount.MountInfo.Mounted != nil && !*mount.MountInfo.Mounted {
+				continue
+			}
+			hosts = append(hosts, mount.Key.Value)
+		}
+		sort.Strings(hosts)
+		result = append(result, map[string]interface{}{
+			"id":                ds.Reference().Value,
+			"name":              ds.Name,
+			"type":              ds.Summary.Type,
+			"capacity":          structure.ByteToMB(ds.Summary.Capacity),
+			"free_space":        structure.ByteToMB(ds.Summary.FreeSpace),
+			"uncommitted_space": structure.ByteToMB(ds.Summary.Uncommitted),
+			"provisioned_space": structure.ByteToMB(ds.Summary.Capacity - ds.Summary.FreeSpace + ds.Summary.Uncommitted),
+			"accessible":        ds.Summary.Accessible,
+			"maintenance_mode":  ds.Summary.MaintenanceMode,
+			"host_system_ids":   hosts,
+		})
+	}
+	return result
+}
+
+// datastoreStatsBestFit returns the datastore with the most free space that
+// meets the criteria in the best_fit attribute. Datastores that are
+// inaccessible or in maintenance mode are never selected.
+func datastoreStatsBestFit(d *schema.ResourceData, meta interface{}, dss []mo.Datastore) (*mo.Datastore, error) {
+	minFree := structure.GBToByte(d.Get("best_fit.0.min_free_space_gb").(int))
+	var excluded map[string]bool
+	if tagIDs := d.Get("best_fit.0.exclude_tag_ids").(*schema.Set).List(); len(tagIDs) > 0 {
+		var err error
+		excluded, err = inventoryListTaggedObjects(meta, structure.SliceInterfacesToStrings(tagIDs), false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var best *mo.Datastore
+	for i := range dss {
+		ds := &dss[i]
+		switch {
+		case !ds.Summary.Accessible:
+			log.Printf("[DEBUG] Skipping datastore %q: not accessible", ds.Name)
+			continue
+		case ds.Summary.MaintenanceMode != "" && ds.Summary.MaintenanceMode != string(types.DatastoreSummaryMaintenanceModeStateNormal):
+			log.Printf("[DEBUG] Skipping datastore %q: maintenance mode state is %q", ds.Name, ds.Summary.MaintenanceMode)
+			continue
+		case ds.Summary.FreeSpace < minFree:
+			log.Printf("[DEBUG] Skipping datastore %q: free space below minimum", ds.Name)
+			continue
+		case excluded[inventoryListFilterKey(ds.Reference())]:
+			log.Printf("[DEBUG] Skipping datastore %q: has an excluded tag", ds.Name)
+			continue
+		}
+		if best == nil || ds.Summary.FreeSpace > best.Summary.FreeSpace {
+			best = ds
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no datastore meets the best_fit criteria")
+	}
+	log.Printf("[DEBUG] Best fit datastore is %q (%s)", best.Name, best.Reference().Value)
+	return best, nil
+}
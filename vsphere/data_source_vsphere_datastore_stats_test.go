@@ -0,0 +1,96 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereDatastoreStats_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereDatastoreStatsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDatastoreStatsConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_datastore_stats.stats", "datastores.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttrSet("data.vsphere_datastore_stats.stats", "datastores.0.capacity"),
+					resource.TestCheckResourceAttr("data.vsphere_datastore_stats.stats", "datastore_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereDatastoreStats_bestFit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereDatastoreStatsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDatastoreStatsConfig(testAccDataSourceVSphereDatastoreStatsConfigBestFit(1)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vsphere_datastore_stats.stats", "datastore_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereDatastoreStats_bestFitNoMatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereDatastoreStatsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceVSphereDatastoreStatsConfig(testAccDataSourceVSphereDatastoreStatsConfigBestFit(1000000000)),
+				ExpectError: regexp.MustCompile("no datastore meets the best_fit criteria"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereDatastoreStatsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_datastore_stats acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereDatastoreStatsConfigBestFit(minFree int) string {
+	return fmt.Sprintf(`
+  best_fit {
+    min_free_space_gb = %d
+  }
+`,
+		minFree,
+	)
+}
+
+func testAccDataSourceVSphereDatastoreStatsConfig(extra string) string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_datastore_stats" "stats" {
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+%s
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		extra,
+	)
+}
@@ -0,0 +1,113 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+func dataSourceVSphereDatastores() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"datastores": {
+			Type:        schema.TypeList,
+			Description: "The datastores that matched the search, sorted by name.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The managed object ID of the datastore.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the datastore.",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The type of the datastore, such as VMFS or NFS.",
+					},
+					"accessible": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether or not the datastore is accessible.",
+					},
+					"capacity": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Maximum capacity of the datastore, in MB.",
+					},
+					"free_space": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Available space of the datastore, in MB.",
+					},
+				},
+			},
+		},
+	}
+	structure.MergeSchema(s, schemaInventoryListFilter())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereDatastoresRead,
+		Schema: s,
+	}
+}
+
+func dataSourceVSphereDatastoresRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	container, err := inventoryListContainer(d, client, false)
+	if err != nil {
+		return err
+	}
+	members, err := inventoryListClusterMembers(d, client, "datastore")
+	if err != nil {
+		return err
+	}
+	filter, err := newInventoryListFilter(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var dss []mo.Datastore
+	ps := []string{"name", "customValue", "summary"}
+	if err := inventoryListRetrieve(client, container, "Datastore", ps, &dss); err != nil {
+		return err
+	}
+	sort.Slice(dss, func(i, j int) bool { return dss[i].Name < dss[j].Name })
+
+	var ids []string
+	var datastores []interface{}
+	for _, ds := range dss {
+		if members != nil && !members[ds.Reference().Value] {
+			continue
+		}
+		if !filter.Match(ds.ManagedEntity) {
+			continue
+		}
+		ids = append(ids, ds.Reference().Value)
+		datastores = append(datastores, map[string]interface{}{
+			"id":         ds.Reference().Value,
+			"name":       ds.Name,
+			"type":       ds.Summary.Type,
+			"accessible": ds.Summary.Accessible,
+			"capacity":   structure.ByteToMB(ds.Summary.Capacity),
+			"free_space": structure.ByteToMB(ds.Summary.FreeSpace),
+		})
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+	if err := d.Set("datastores", datastores); err != nil {
+		return fmt.Errorf("error setting datastores: %s", err)
+	}
+	return nil
+}
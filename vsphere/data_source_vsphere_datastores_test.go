@@ -0,0 +1,106 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereDatastores_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereDatastoresPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDatastoresConfigNameRegex(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_datastores.datastores", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_datastores.datastores", "ids.0",
+						"data.vsphere_datastore.datastore", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_datastores.datastores", "datastores.0.accessible", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereDatastores_cluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereDatastoresPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereDatastoresConfigCluster(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_datastores.datastores", "ids.#", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereDatastoresPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_datastores acceptance tests")
+	}
+	if os.Getenv("VSPHERE_CLUSTER") == "" {
+		t.Skip("set VSPHERE_CLUSTER to run vsphere_datastores acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DATASTORE") == "" {
+		t.Skip("set VSPHERE_DATASTORE to run vsphere_datastores acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereDatastoresConfigNameRegex() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_datastores" "datastores" {
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+  name_regex    = %q
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		"^"+regexp.QuoteMeta(os.Getenv("VSPHERE_DATASTORE"))+"$",
+	)
+}
+
+func testAccDataSourceVSphereDatastoresConfigCluster() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_datastores" "datastores" {
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+  cluster_id    = "${data.vsphere_compute_cluster.cluster.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_CLUSTER"),
+	)
+}
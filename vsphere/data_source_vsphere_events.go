@@ -0,0 +1,272 @@
+package vsphere
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var eventFilterSpecRecursionOptionAllowedValues = []string{
+	string(types.EventFilterSpecRecursionOptionSelf),
+	string(types.EventFilterSpecRecursionOptionChildren),
+	string(types.EventFilterSpecRecursionOptionAll),
+}
+
+func dataSourceVSphereEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"entity_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the entity to query events for.",
+				Optional:    true,
+			},
+			"entity_type": {
+				Type:         schema.TypeString,
+				Description:  "The managed object type of the entity to query events for. Required when entity_id is set.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(managedEntityTypeAllowedValues, false),
+			},
+			"recursion": {
+				Type:         schema.TypeString,
+				Description:  "Whether to return events for the entity itself (self), its immediate children (children), or the entity and all of its descendants (all).",
+				Optional:     true,
+				Default:      string(types.EventFilterSpecRecursionOptionAll),
+				ValidateFunc: validation.StringInSlice(eventFilterSpecRecursionOptionAllowedValues, false),
+			},
+			"event_type_ids": {
+				Type:        schema.TypeSet,
+				Description: "The event type IDs to filter on, such as VmPoweredOffEvent or com.vmware.vc.ha.VmRestartedByHAEvent.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"user_names": {
+				Type:        schema.TypeSet,
+				Description: "The names of the users that triggered the events to filter on.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"begin_time": {
+				Type:         schema.TypeString,
+				Description:  "Only return events created at or after this time, in RFC3339 format.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Description:  "Only return events created at or before this time, in RFC3339 format.",
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"max_events": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of events to return. The most recent events are returned first. 0 returns all matching events.",
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Description: "The events that matched the filter, newest first.",
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: schemaEvent()},
+			},
+		},
+	}
+}
+
+// schemaEvent returns the schema for a single event in the events attribute
+// of the vsphere_events data source.
+func schemaEvent() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"key": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The unique key of the event.",
+		},
+		"chain_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The key of the parent or group event that this event is a part of.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The event type ID of the event.",
+		},
+		"created_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time the event was created, in RFC3339 format.",
+		},
+		"user_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the user that triggered the event.",
+		},
+		"message": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The formatted message of the event.",
+		},
+		"datacenter_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the datacenter related to the event.",
+		},
+		"compute_resource_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the cluster or standalone host related to the event.",
+		},
+		"host_system_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the host related to the event.",
+		},
+		"virtual_machine_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the virtual machine related to the event.",
+		},
+		"datastore_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the datastore related to the event.",
+		},
+		"network_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the network related to the event.",
+		},
+		"distributed_virtual_switch_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The managed object ID of the distributed virtual switch related to the event.",
+		},
+	}
+}
+
+func dataSourceVSphereEventsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	filter, err := expandEventFilterSpec(d, client)
+	if err != nil {
+		return err
+	}
+	events, err := queryEvents(client, filter, d.Get("max_events").(int))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("events", flattenEvents(events)); err != nil {
+		return fmt.Errorf("error setting events: %s", err)
+	}
+	return nil
+}
+
+// expandEventFilterSpec reads the filter attributes of the vsphere_events data
+// source and returns an EventFilterSpec.
+func expandEventFilterSpec(d *schema.ResourceData, client *govmomi.Client) (types.EventFilterSpec, error) {
+	filter := types.EventFilterSpec{
+		EventTypeId: structure.SliceInterfacesToStrings(d.Get("event_type_ids").(*schema.Set).List()),
+	}
+
+	if id, ok := d.GetOk("entity_id"); ok {
+		t, ok := d.GetOk("entity_type")
+		if !ok {
+			return filter, fmt.Errorf("entity_type is required when entity_id is set")
+		}
+		ref, err := managedEntityReferenceFromID(client, t.(string), id.(string))
+		if err != nil {
+			return filter, err
+		}
+		filter.Entity = &types.EventFilterSpecByEntity{
+			Entity:    ref,
+			Recursion: types.EventFilterSpecRecursionOption(d.Get("recursion").(string)),
+		}
+	}
+
+	if users := d.Get("user_names").(*schema.Set).List(); len(users) > 0 {
+		filter.UserName = &types.EventFilterSpecByUsername{
+			UserList: structure.SliceInterfacesToStrings(users),
+		}
+	}
+
+	begin, err := expandEventFilterTime(d, "begin_time")
+	if err != nil {
+		return filter, err
+	}
+	end, err := expandEventFilterTime(d, "end_time")
+	if err != nil {
+		return filter, err
+	}
+	if begin != nil || end != nil {
+		filter.Time = &types.EventFilterSpecByTime{
+			BeginTime: begin,
+			EndTime:   end,
+		}
+	}
+
+	return filter, nil
+}
+
+// expandEventFilterTime parses the RFC3339 time in the supplied key. nil is
+// returned if the key is not set.
+func expandEventFilterTime(d *schema.ResourceData, key string) (*time.Time, error) {
+	v, ok := d.GetOk(key)
+	if !ok {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v.(string))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", key, err)
+	}
+	return &t, nil
+}
+
+// flattenEvents converts a list of events into the format used by the events
+// attribute of the vsphere_events data source.
+func flattenEvents(events []types.BaseEvent) []interface{} {
+	var result []interface{}
+	for _, be := range events {
+		e := be.GetEvent()
+		m := map[string]interface{}{
+			"key":          int(e.Key),
+			"chain_id":     int(e.ChainId),
+			"type":         eventTypeID(be),
+			"created_time": e.CreatedTime.Format(time.RFC3339),
+			"user_name":    e.UserName,
+			"message":      e.FullFormattedMessage,
+		}
+		if e.Datacenter != nil {
+			m["datacenter_id"] = e.Datacenter.Datacenter.Value
+		}
+		if e.ComputeResource != nil {
+			m["compute_resource_id"] = e.ComputeResource.ComputeResource.Value
+		}
+		if e.Host != nil {
+			m["host_system_id"] = e.Host.Host.Value
+		}
+		if e.Vm != nil {
+			m["virtual_machine_id"] = e.Vm.Vm.Value
+		}
+		if e.Ds != nil {
+			m["datastore_id"] = e.Ds.Datastore.Value
+		}
+		if e.Net != nil {
+			m["network_id"] = e.Net.Network.Value
+		}
+		if e.Dvs != nil {
+			m["distributed_virtual_switch_id"] = e.Dvs.Dvs.Value
+		}
+		result = append(result, m)
+	}
+	return result
+}
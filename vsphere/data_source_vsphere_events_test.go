@@ -0,0 +1,83 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereEvents_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereEventsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereEventsConfigDatacenter(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_events.events", "events.#", "5"),
+					resource.TestCheckResourceAttrSet("data.vsphere_events.events", "events.0.type"),
+					resource.TestCheckResourceAttrSet("data.vsphere_events.events", "events.0.created_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereEvents_eventTypeIDs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereEventsConfigEventTypeIDs,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_events.events", "events.0.type", "CustomFieldDefAddedEvent"),
+					resource.TestMatchResourceAttr("data.vsphere_events.events", "events.0.message", regexp.MustCompile("terraform-test-attribute")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereEventsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_events acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereEventsConfigDatacenter() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_events" "events" {
+  entity_id   = "${data.vsphere_datacenter.dc.id}"
+  entity_type = "Datacenter"
+  max_events  = 5
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
+
+const testAccDataSourceVSphereEventsConfigEventTypeIDs = `
+resource "vsphere_custom_attribute" "attribute" {
+  name = "terraform-test-attribute"
+}
+
+data "vsphere_events" "events" {
+  event_type_ids = ["CustomFieldDefAddedEvent"]
+  max_events     = 1
+
+  depends_on = ["vsphere_custom_attribute.attribute"]
+}
+`
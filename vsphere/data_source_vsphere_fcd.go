@@ -0,0 +1,90 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/firstclassdisk"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereFCD() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereFCDRead,
+
+		Schema: map[string]*schema.Schema{
+			"datastore_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the datastore the first class disk is in.",
+				Required:    true,
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Description:   "The name of the first class disk. Conflicts with fcd_id.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"fcd_id"},
+			},
+			"fcd_id": {
+				Type:          schema.TypeString,
+				Description:   "The ID of the first class disk. Conflicts with name.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"capacity": {
+				Type:        schema.TypeInt,
+				Description: "The capacity of the first class disk, in GB.",
+				Computed:    true,
+			},
+			"provisioning_type": {
+				Type:        schema.TypeString,
+				Description: "The provisioning type of the first class disk.",
+				Computed:    true,
+			},
+			"keep_after_delete_vm": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not the first class disk is kept when a virtual machine it is attached to is deleted.",
+				Computed:    true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Description: "The datastore path of the virtual disk file backing the first class disk.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceVSphereFCDRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := firstclassdisk.VerifySupport(client); err != nil {
+		return err
+	}
+	ds, err := datastore.FromID(client, d.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore: %s", err)
+	}
+
+	var obj *types.VStorageObject
+	switch {
+	case d.Get("fcd_id").(string) != "":
+		id := d.Get("fcd_id").(string)
+		if obj, err = firstclassdisk.FromID(client, id, ds); err != nil {
+			return fmt.Errorf("error fetching first class disk %q: %s", id, err)
+		}
+	case d.Get("name").(string) != "":
+		name := d.Get("name").(string)
+		if obj, err = firstclassdisk.FromName(client, name, ds); err != nil {
+			return fmt.Errorf("error fetching first class disk %q: %s", name, err)
+		}
+	default:
+		return errors.New("one of name or fcd_id must be set")
+	}
+
+	d.SetId(obj.Config.Id.Id)
+	d.Set("fcd_id", obj.Config.Id.Id)
+	return flattenVStorageObject(d, obj)
+}
@@ -0,0 +1,79 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereFCD_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereFCDPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereFCDConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_fcd.by_name", "id",
+						"vsphere_fcd.disk", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_fcd.by_id", "name",
+						"vsphere_fcd.disk", "name",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_fcd.by_name", "capacity", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_fcd.by_name", "path",
+						"vsphere_fcd.disk", "path",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereFCDConfig() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_fcd" "disk" {
+  name         = "terraform-test-fcd"
+  datastore_id = "${data.vsphere_datastore.datastore.id}"
+  capacity     = 1
+}
+
+data "vsphere_fcd" "by_name" {
+  name         = "${vsphere_fcd.disk.name}"
+  datastore_id = "${vsphere_fcd.disk.datastore_id}"
+}
+
+data "vsphere_fcd" "by_id" {
+  fcd_id       = "${vsphere_fcd.disk.id}"
+  datastore_id = "${vsphere_fcd.disk.datastore_id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+	)
+}
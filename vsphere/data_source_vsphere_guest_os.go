@@ -0,0 +1,170 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/computeresource"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereGuestOS() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"hardware_version": {
+			Type:        schema.TypeString,
+			Description: "The virtual machine hardware version to list guest operating systems for, such as vmx-14. If not set, the default hardware version of the cluster or host is used.",
+			Optional:    true,
+		},
+		"family": {
+			Type:        schema.TypeString,
+			Description: "Only include guest operating systems in this family, such as linuxGuest or windowsGuest.",
+			Optional:    true,
+		},
+		"guest_ids": {
+			Type:        schema.TypeList,
+			Description: "The IDs of the supported guest operating systems, sorted by ID.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"guest_os": {
+			Type:        schema.TypeList,
+			Description: "The supported guest operating systems, sorted by ID.",
+			Computed:    true,
+			Elem:        &schema.Resource{Schema: schemaGuestOsDescriptor()},
+		},
+	}
+	structure.MergeSchema(s, schemaVMConfigOptionTarget())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereGuestOSRead,
+		Schema: s,
+	}
+}
+
+// schemaGuestOsDescriptor returns the schema for a single guest operating
+// system in the guest_os attribute of the vsphere_guest_os data source.
+func schemaGuestOsDescriptor() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The guest ID, for use in the guest_id attribute of vsphere_virtual_machine.",
+		},
+		"family": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The family of the guest operating system.",
+		},
+		"full_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The full name of the guest operating system.",
+		},
+		"supported_max_cpus": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum number of virtual processors supported by the guest operating system.",
+		},
+		"supported_min_memory": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The minimum memory supported by the guest operating system, in MB.",
+		},
+		"supported_max_memory": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum memory supported by the guest operating system, in MB.",
+		},
+		"supported_max_disks": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum number of disks supported by the guest operating system.",
+		},
+		"recommended_memory": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The recommended memory size, in MB.",
+		},
+		"recommended_disk_size": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The recommended disk size, in MB.",
+		},
+		"recommended_disk_controller": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The recommended disk controller type.",
+		},
+		"recommended_scsi_controller": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The recommended SCSI controller type.",
+		},
+		"recommended_ethernet_card": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The recommended network interface type.",
+		},
+		"recommended_firmware": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The recommended firmware type.",
+		},
+	}
+}
+
+func dataSourceVSphereGuestOSRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	ref, host, err := vmConfigOptionTarget(d, client)
+	if err != nil {
+		return err
+	}
+	co, err := computeresource.ConfigOptionFromReference(client, ref, d.Get("hardware_version").(string), host)
+	if err != nil {
+		return fmt.Errorf("error querying config options: %s", err)
+	}
+
+	descs := co.GuestOSDescriptor
+	sort.Slice(descs, func(i, j int) bool { return descs[i].Id < descs[j].Id })
+	family := d.Get("family").(string)
+	var ids []string
+	var guests []interface{}
+	for _, desc := range descs {
+		if family != "" && desc.Family != family {
+			continue
+		}
+		ids = append(ids, desc.Id)
+		guests = append(guests, flattenGuestOsDescriptor(desc))
+	}
+
+	d.SetId(vmConfigOptionID(ref, host, co.Version))
+	if err := d.Set("guest_ids", ids); err != nil {
+		return fmt.Errorf("error setting guest_ids: %s", err)
+	}
+	if err := d.Set("guest_os", guests); err != nil {
+		return fmt.Errorf("error setting guest_os: %s", err)
+	}
+	return nil
+}
+
+// flattenGuestOsDescriptor converts a GuestOsDescriptor into the format used
+// by the guest_os attribute of the vsphere_guest_os data source.
+func flattenGuestOsDescriptor(desc types.GuestOsDescriptor) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                          desc.Id,
+		"family":                      desc.Family,
+		"full_name":                   desc.FullName,
+		"supported_max_cpus":          int(desc.SupportedMaxCPUs),
+		"supported_min_memory":        int(desc.SupportedMinMemMB),
+		"supported_max_memory":        int(desc.SupportedMaxMemMB),
+		"supported_max_disks":         int(desc.SupportedNumDisks),
+		"recommended_memory":          int(desc.RecommendedMemMB),
+		"recommended_disk_size":       int(desc.RecommendedDiskSizeMB),
+		"recommended_disk_controller": desc.RecommendedDiskController,
+		"recommended_scsi_controller": desc.RecommendedSCSIController,
+		"recommended_ethernet_card":   desc.RecommendedEthernetCard,
+		"recommended_firmware":        desc.RecommendedFirmware,
+	}
+}
@@ -0,0 +1,58 @@
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/customizationspec"
+)
+
+func dataSourceVSphereGuestOSCustomization() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereGuestOSCustomizationRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the customization specification.",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the customization specification.",
+				Computed:    true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The type of the customization specification, either Linux or Windows.",
+				Computed:    true,
+			},
+			"change_version": {
+				Type:        schema.TypeString,
+				Description: "The current version of the customization specification, which changes each time it is modified.",
+				Computed:    true,
+			},
+			"last_update_time": {
+				Type:        schema.TypeString,
+				Description: "The time the customization specification was last modified, in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceVSphereGuestOSCustomizationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := customizationspec.VerifySupport(client); err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+	item, err := customizationspec.FromName(client, name)
+	if err != nil {
+		return fmt.Errorf("error fetching customization specification %q: %s", name, err)
+	}
+
+	d.SetId(item.Info.Name)
+	flattenGuestOSCustomizationSpecInfo(d, item.Info)
+	return nil
+}
@@ -0,0 +1,53 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereGuestOSCustomization_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereGuestOSCustomizationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_guest_os_customization.spec", "id",
+						"vsphere_guest_os_customization.spec", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_guest_os_customization.spec", "type", "Linux"),
+					resource.TestCheckResourceAttr("data.vsphere_guest_os_customization.spec", "description", "terraform-test"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_guest_os_customization.spec", "change_version",
+						"vsphere_guest_os_customization.spec", "change_version",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceVSphereGuestOSCustomizationConfig = `
+resource "vsphere_guest_os_customization" "spec" {
+  name        = "terraform-test-spec"
+  description = "terraform-test"
+
+  customize {
+    linux_options {
+      host_name = "terraform-test"
+      domain    = "example.com"
+    }
+
+    network_interface {}
+  }
+}
+
+data "vsphere_guest_os_customization" "spec" {
+  name = "${vsphere_guest_os_customization.spec.name}"
+}
+`
@@ -0,0 +1,106 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereGuestOS_cluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereGuestOSPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereGuestOSConfigCluster(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_guest_os.guest_os", "guest_ids.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttr("data.vsphere_guest_os.guest_os", "guest_os.0.family", "linuxGuest"),
+					testCheckOutputBool("has_other3x", true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereGuestOS_host(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereGuestOSPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereGuestOSConfigHost(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_guest_os.guest_os", "guest_ids.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttrSet("data.vsphere_guest_os.guest_os", "guest_os.0.full_name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereGuestOSPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_guest_os acceptance tests")
+	}
+	if os.Getenv("VSPHERE_CLUSTER") == "" {
+		t.Skip("set VSPHERE_CLUSTER to run vsphere_guest_os acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_guest_os acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereGuestOSConfigCluster() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_guest_os" "guest_os" {
+  cluster_id = "${data.vsphere_compute_cluster.cluster.id}"
+  family     = "linuxGuest"
+}
+
+output "has_other3x" {
+  value = "${contains(data.vsphere_guest_os.guest_os.guest_ids, "other3xLinux64Guest")}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_CLUSTER"),
+	)
+}
+
+func testAccDataSourceVSphereGuestOSConfigHost() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_host" "host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_guest_os" "guest_os" {
+  host_system_id = "${data.vsphere_host.host.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+	)
+}
@@ -0,0 +1,117 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var hostSystemConnectionStateAllowedValues = []string{
+	string(types.HostSystemConnectionStateConnected),
+	string(types.HostSystemConnectionStateNotResponding),
+	string(types.HostSystemConnectionStateDisconnected),
+}
+
+func dataSourceVSphereHosts() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"connection_state": {
+			Type:         schema.TypeString,
+			Description:  "Only include hosts in this connection state. Can be one of connected, notResponding, or disconnected.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(hostSystemConnectionStateAllowedValues, false),
+		},
+		"hosts": {
+			Type:        schema.TypeList,
+			Description: "The hosts that matched the search, sorted by name.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The managed object ID of the host.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the host.",
+					},
+					"connection_state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The connection state of the host.",
+					},
+					"power_state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The power state of the host.",
+					},
+					"maintenance_mode": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether or not the host is in maintenance mode.",
+					},
+				},
+			},
+		},
+	}
+	structure.MergeSchema(s, schemaInventoryListFilter())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereHostsRead,
+		Schema: s,
+	}
+}
+
+func dataSourceVSphereHostsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	container, err := inventoryListContainer(d, client, true)
+	if err != nil {
+		return err
+	}
+	filter, err := newInventoryListFilter(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var hss []mo.HostSystem
+	ps := []string{"name", "customValue", "runtime.connectionState", "runtime.powerState", "runtime.inMaintenanceMode"}
+	if err := inventoryListRetrieve(client, container, "HostSystem", ps, &hss); err != nil {
+		return err
+	}
+	sort.Slice(hss, func(i, j int) bool { return hss[i].Name < hss[j].Name })
+
+	var ids []string
+	var hosts []interface{}
+	for _, hs := range hss {
+		if !filter.Match(hs.ManagedEntity) {
+			continue
+		}
+		if v, ok := d.GetOk("connection_state"); ok && string(hs.Runtime.ConnectionState) != v.(string) {
+			continue
+		}
+		ids = append(ids, hs.Reference().Value)
+		hosts = append(hosts, map[string]interface{}{
+			"id":               hs.Reference().Value,
+			"name":             hs.Name,
+			"connection_state": string(hs.Runtime.ConnectionState),
+			"power_state":      string(hs.Runtime.PowerState),
+			"maintenance_mode": hs.Runtime.InMaintenanceMode,
+		})
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+	if err := d.Set("hosts", hosts); err != nil {
+		return fmt.Errorf("error setting hosts: %s", err)
+	}
+	return nil
+}
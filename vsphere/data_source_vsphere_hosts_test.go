@@ -0,0 +1,108 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereHosts_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereHostsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostsConfigNameRegex(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_hosts.hosts", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_hosts.hosts", "ids.0",
+						"data.vsphere_host.host", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_hosts.hosts", "hosts.0.name", os.Getenv("VSPHERE_ESXI_HOST")),
+					resource.TestCheckResourceAttr("data.vsphere_hosts.hosts", "hosts.0.connection_state", "connected"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereHosts_cluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereHostsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereHostsConfigCluster(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_hosts.hosts", "ids.#", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereHostsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_hosts acceptance tests")
+	}
+	if os.Getenv("VSPHERE_CLUSTER") == "" {
+		t.Skip("set VSPHERE_CLUSTER to run vsphere_hosts acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_hosts acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereHostsConfigNameRegex() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_host" "host" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_hosts" "hosts" {
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+  name_regex    = %q
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		"^"+regexp.QuoteMeta(os.Getenv("VSPHERE_ESXI_HOST"))+"$",
+	)
+}
+
+func testAccDataSourceVSphereHostsConfigCluster() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_hosts" "hosts" {
+  datacenter_id    = "${data.vsphere_datacenter.dc.id}"
+  cluster_id       = "${data.vsphere_compute_cluster.cluster.id}"
+  connection_state = "connected"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_CLUSTER"),
+	)
+}
@@ -0,0 +1,95 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+func dataSourceVSphereNetworks() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"networks": {
+			Type:        schema.TypeList,
+			Description: "The networks that matched the search, sorted by name.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The managed object ID of the network.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the network.",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The managed object type of the network. One of Network, DistributedVirtualPortgroup, or OpaqueNetwork.",
+					},
+				},
+			},
+		},
+	}
+	structure.MergeSchema(s, schemaInventoryListFilter())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereNetworksRead,
+		Schema: s,
+	}
+}
+
+func dataSourceVSphereNetworksRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	container, err := inventoryListContainer(d, client, false)
+	if err != nil {
+		return err
+	}
+	members, err := inventoryListClusterMembers(d, client, "network")
+	if err != nil {
+		return err
+	}
+	filter, err := newInventoryListFilter(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var nets []mo.Network
+	if err := inventoryListRetrieve(client, container, "Network", []string{"name", "customValue"}, &nets); err != nil {
+		return err
+	}
+	sort.Slice(nets, func(i, j int) bool { return nets[i].Name < nets[j].Name })
+
+	var ids []string
+	var networks []interface{}
+	for _, net := range nets {
+		ref := net.Reference()
+		if members != nil && !members[ref.Value] {
+			continue
+		}
+		if !filter.Match(net.ManagedEntity) {
+			continue
+		}
+		ids = append(ids, ref.Value)
+		networks = append(networks, map[string]interface{}{
+			"id":   ref.Value,
+			"name": net.Name,
+			"type": ref.Type,
+		})
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+	if err := d.Set("networks", networks); err != nil {
+		return fmt.Errorf("error setting networks: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereNetworks_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereNetworksPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereNetworksConfigNameRegex(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_networks.networks", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_networks.networks", "ids.0",
+						"data.vsphere_network.network", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereNetworksPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_networks acceptance tests")
+	}
+	if os.Getenv("VSPHERE_NETWORK_LABEL_PXE") == "" {
+		t.Skip("set VSPHERE_NETWORK_LABEL_PXE to run vsphere_networks acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereNetworksConfigNameRegex() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_network" "network" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_networks" "networks" {
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+  name_regex    = %q
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_NETWORK_LABEL_PXE"),
+		"^"+regexp.QuoteMeta(os.Getenv("VSPHERE_NETWORK_LABEL_PXE"))+"$",
+	)
+}
@@ -0,0 +1,280 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/firstclassdisk"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereOrphanedFiles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereOrphanedFilesRead,
+
+		Schema: map[string]*schema.Schema{
+			"datastore_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The managed object IDs of the datastores to search.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"files": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The virtual disk and virtual machine configuration files that are not used by any registered virtual machine or template, or by a first class disk, sorted by datastore and path.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datastore_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The managed object ID of the datastore the file is in.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the file, relative to the root of the datastore.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the file, in bytes.",
+						},
+						"modification_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the file was last modified, in RFC3339 format.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the file, either vm_disk or vm_config.",
+						},
+					},
+				},
+			},
+			"total_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The combined size of the files, in bytes.",
+			},
+		},
+	}
+}
+
+func dataSourceVSphereOrphanedFilesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+
+	// Files can be referenced by virtual machines anywhere in the inventory,
+	// so all of them need to be checked.
+	var vms []mo.VirtualMachine
+	ps := []string{"summary.config.vmPathName", "config.hardware.device", "layoutEx.file"}
+	if err := inventoryListRetrieve(client, client.ServiceContent.RootFolder, "VirtualMachine", ps, &vms); err != nil {
+		return err
+	}
+	used := orphanedFilesReferenced(vms)
+	// First class disks are only available on vCenter 6.5 and higher. On other
+	// endpoints there are no first class disks to exclude.
+	fcdErr := firstclassdisk.VerifySupport(client)
+	if fcdErr != nil {
+		log.Printf("[DEBUG] Not checking for first class disks: %s", fcdErr)
+	}
+
+	spec := &types.HostDatastoreBrowserSearchSpec{
+		Query: []types.BaseFileQuery{
+			&types.VmDiskFileQuery{},
+			&types.VmConfigFileQuery{},
+		},
+		Details: &types.FileQueryFlags{
+			FileType:     true,
+			FileSize:     true,
+			Modification: true,
+		},
+	}
+
+	var files []interface{}
+	var total int64
+	for _, id := range structure.SliceInterfacesToStrings(d.Get("datastore_ids").([]interface{})) {
+		ds, err := datastore.FromID(client, id)
+		if err != nil {
+			return fmt.Errorf("cannot locate datastore %q: %s", id, err)
+		}
+		if fcdErr == nil {
+			if err := orphanedFilesAddFirstClassDisks(client, ds, used); err != nil {
+				return err
+			}
+		}
+		results, err := datastore.SearchDatastoreSubFolders(ds, "", spec)
+		if err != nil {
+			return fmt.Errorf("error searching datastore %q: %s", ds.Name(), err)
+		}
+
+		var found []map[string]interface{}
+		for _, result := range results {
+			var folder object.DatastorePath
+			folder.FromString(result.FolderPath)
+			for _, bfi := range result.File {
+				fi := bfi.GetFileInfo()
+				p := path.Join(folder.Path, fi.Path)
+				if used[orphanedFilesNormalizePath(ds.Path(p))] {
+					continue
+				}
+				var modified string
+				if fi.Modification != nil {
+					modified = fi.Modification.Format(time.RFC3339)
+				}
+				total += fi.FileSize
+				found = append(found, map[string]interface{}{
+					"datastore_id":      id,
+					"path":              p,
+					"size":              int(fi.FileSize),
+					"modification_time": modified,
+					"type":              datastoreFileType(bfi),
+				})
+			}
+		}
+		sort.Slice(found, func(i, j int) bool { return found[i]["path"].(string) < found[j]["path"].(string) })
+		for _, f := range found {
+			files = append(files, f)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("files", files); err != nil {
+		return fmt.Errorf("error setting files: %s", err)
+	}
+	d.Set("total_size", int(total))
+	return nil
+}
+
+// orphanedFilesReferenced returns the normalized datastore paths of all files
+// used by the supplied virtual machines. This includes the configuration
+// file, every file in the file layout, and the backing of every virtual disk
+// along with its parents, such as the base disks of linked clones.
+func orphanedFilesReferenced(vms []mo.VirtualMachine) map[string]bool {
+	used := make(map[string]bool)
+	add := func(name string) {
+		if name != "" {
+			used[orphanedFilesNormalizePath(name)] = true
+		}
+	}
+	for _, vm := range vms {
+		add(vm.Summary.Config.VmPathName)
+		if vm.LayoutEx != nil {
+			for _, f := range vm.LayoutEx.File {
+				add(f.Name)
+			}
+		}
+		if vm.Config == nil {
+			continue
+		}
+		for _, dev := range vm.Config.Hardware.Device {
+			disk, ok := dev.(*types.VirtualDisk)
+			if !ok {
+				continue
+			}
+			for _, name := range virtualDiskBackingChain(disk.Backing) {
+				add(name)
+			}
+		}
+	}
+	return used
+}
+
+// orphanedFilesAddFirstClassDisks adds the backing files of all first class
+// disks in the supplied datastore to used. First class disks exist
+// independently of virtual machines, so their backings would otherwise be
+// reported as orphaned when they are not attached to a virtual machine.
+func orphanedFilesAddFirstClassDisks(client *govmomi.Client, ds *object.Datastore, used map[string]bool) error {
+	ids, err := firstclassdisk.List(client, ds)
+	if err != nil {
+		return fmt.Errorf("error listing first class disks on datastore %q: %s", ds.Name(), err)
+	}
+	for _, id := range ids {
+		obj, err := firstclassdisk.FromID(client, id.Id, ds)
+		if err != nil {
+			return fmt.Errorf("error fetching first class disk %q on datastore %q: %s", id.Id, ds.Name(), err)
+		}
+		for _, name := range firstClassDiskBackingChain(obj.Config.Backing) {
+			used[orphanedFilesNormalizePath(name)] = true
+		}
+	}
+	return nil
+}
+
+// firstClassDiskBackingChain returns the file paths of a first class disk
+// backing and all of its parents.
+func firstClassDiskBackingChain(backing types.BaseBaseConfigInfoBackingInfo) []string {
+	var names []string
+	fb, _ := backing.(types.BaseBaseConfigInfoFileBackingInfo)
+	for fb != nil {
+		b := fb.GetBaseConfigInfoFileBackingInfo()
+		if b == nil {
+			break
+		}
+		if b.FilePath != "" {
+			names = append(names, b.FilePath)
+		}
+		fb = b.Parent
+	}
+	return names
+}
+
+// virtualDiskBackingChain returns the file names of a virtual disk backing
+// and all of its parents.
+func virtualDiskBackingChain(backing types.BaseVirtualDeviceBackingInfo) []string {
+	var names []string
+	for backing != nil {
+		fb, ok := backing.(types.BaseVirtualDeviceFileBackingInfo)
+		if !ok {
+			break
+		}
+		names = append(names, fb.GetVirtualDeviceFileBackingInfo().FileName)
+		switch b := backing.(type) {
+		case *types.VirtualDiskFlatVer2BackingInfo:
+			if b.Parent == nil {
+				return names
+			}
+			backing = b.Parent
+		case *types.VirtualDiskSeSparseBackingInfo:
+			if b.Parent == nil {
+				return names
+			}
+			backing = b.Parent
+		case *types.VirtualDiskSparseVer2BackingInfo:
+			if b.Parent == nil {
+				return names
+			}
+			backing = b.Parent
+		case *types.VirtualDiskRawDiskMappingVer1BackingInfo:
+			if b.Parent == nil {
+				return names
+			}
+			backing = b.Parent
+		default:
+			return names
+		}
+	}
+	return names
+}
+
+// orphanedFilesNormalizePath cleans up a datastore path so that paths from
+// virtual machines and from datastore searches can be compared.
+func orphanedFilesNormalizePath(name string) string {
+	var p object.DatastorePath
+	if !p.FromString(name) {
+		return name
+	}
+	p.Path = path.Clean(p.Path)
+	return p.String()
+}
@@ -0,0 +1,111 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccDataSourceVSphereOrphanedFiles_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereOrphanedFilesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceVSphereOrphanedFilesHasPath("terraform-test-orphaned.vmdk", "vm_disk"),
+				),
+			},
+		},
+	})
+}
+
+// testAccDataSourceVSphereOrphanedFilesHasPath checks that a file with the
+// supplied path and type is in the files of the data source.
+func testAccDataSourceVSphereOrphanedFilesHasPath(expected, fileType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["data.vsphere_orphaned_files.orphaned"]
+		if !ok {
+			return fmt.Errorf("data.vsphere_orphaned_files.orphaned not found in state")
+		}
+		attrs := rs.Primary.Attributes
+		for i := 0; attrs[fmt.Sprintf("files.%d.path", i)] != ""; i++ {
+			if attrs[fmt.Sprintf("files.%d.path", i)] != expected {
+				continue
+			}
+			if actual := attrs[fmt.Sprintf("files.%d.type", i)]; actual != fileType {
+				return fmt.Errorf("expected %q to be of type %q, got %q", expected, fileType, actual)
+			}
+			return nil
+		}
+		return fmt.Errorf("expected %q to be in orphaned files", expected)
+	}
+}
+
+func testAccDataSourceVSphereOrphanedFilesConfig() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_virtual_disk" "disk" {
+  size         = 1
+  vmdk_path    = "terraform-test-orphaned.vmdk"
+  adapter_type = "lsiLogic"
+  type         = "thin"
+  datacenter   = "${var.datacenter}"
+  datastore    = "${var.datastore}"
+}
+
+data "vsphere_orphaned_files" "orphaned" {
+  datastore_ids = ["${data.vsphere_datastore.datastore.id}"]
+
+  depends_on = ["vsphere_virtual_disk.disk"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+	)
+}
+
+func TestFirstClassDiskBackingChain(t *testing.T) {
+	backing := &types.BaseConfigInfoDiskFileBackingInfo{
+		BaseConfigInfoFileBackingInfo: types.BaseConfigInfoFileBackingInfo{
+			FilePath: "[datastore1] fcd/child.vmdk",
+			Parent: &types.BaseConfigInfoDiskFileBackingInfo{
+				BaseConfigInfoFileBackingInfo: types.BaseConfigInfoFileBackingInfo{
+					FilePath: "[datastore1] fcd/base.vmdk",
+				},
+			},
+		},
+	}
+	expected := []string{"[datastore1] fcd/child.vmdk", "[datastore1] fcd/base.vmdk"}
+	if actual := firstClassDiskBackingChain(backing); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+	if actual := firstClassDiskBackingChain(nil); len(actual) != 0 {
+		t.Fatalf("expected no paths for nil backing, got %#v", actual)
+	}
+}
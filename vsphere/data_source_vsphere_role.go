@@ -0,0 +1,55 @@
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/authorization"
+)
+
+func dataSourceVSphereRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVSphereRoleRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the role.",
+				Required:    true,
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display label of the role.",
+			},
+			"system": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not the role is a built-in system role.",
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The IDs of the privileges granted by the role, less the System.Anonymous, System.Read, and System.View privileges that are granted to every role.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceVSphereRoleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	role, err := authorization.RoleFromName(client, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprint(role.RoleId))
+	if role.Info != nil {
+		d.Set("label", role.Info.GetDescription().Label)
+	}
+	d.Set("system", role.System)
+	if err := d.Set("privileges", authorization.UserPrivileges(role)); err != nil {
+		return fmt.Errorf("error setting privileges: %s", err)
+	}
+	return nil
+}
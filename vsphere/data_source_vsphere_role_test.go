@@ -0,0 +1,68 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereRole_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereRoleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_role.role", "id",
+						"vsphere_role.role", "id",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "system", "false"),
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "privileges.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereRole_system(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereRoleConfigSystem,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "id", "-1"),
+					resource.TestCheckResourceAttr("data.vsphere_role.role", "system", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceVSphereRoleConfig = `
+resource "vsphere_role" "role" {
+  name = "terraform-test-role"
+
+  privileges = [
+    "VirtualMachine.Interact.PowerOff",
+    "VirtualMachine.Interact.PowerOn",
+  ]
+}
+
+data "vsphere_role" "role" {
+  name = "${vsphere_role.role.name}"
+}
+`
+
+const testAccDataSourceVSphereRoleConfigSystem = `
+data "vsphere_role" "role" {
+  name = "Admin"
+}
+`
@@ -0,0 +1,149 @@
+package vsphere
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var virtualMachinePowerStateAllowedValues = []string{
+	string(types.VirtualMachinePowerStatePoweredOn),
+	string(types.VirtualMachinePowerStatePoweredOff),
+	string(types.VirtualMachinePowerStateSuspended),
+}
+
+func dataSourceVSphereVirtualMachines() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"power_state": {
+			Type:         schema.TypeString,
+			Description:  "Only include virtual machines in this power state. Can be one of poweredOn, poweredOff, or suspended.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(virtualMachinePowerStateAllowedValues, false),
+		},
+		"virtual_machines": {
+			Type:        schema.TypeList,
+			Description: "The virtual machines that matched the search, sorted by name.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The managed object ID of the virtual machine.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the virtual machine.",
+					},
+					"uuid": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The UUID of the virtual machine.",
+					},
+					"power_state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The power state of the virtual machine.",
+					},
+					"template": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether or not the virtual machine is a template.",
+					},
+					"guest_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The guest ID of the virtual machine.",
+					},
+					"num_cpus": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of virtual processors of the virtual machine.",
+					},
+					"memory": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The size of the virtual machine's memory, in MB.",
+					},
+				},
+			},
+		},
+	}
+	structure.MergeSchema(s, schemaInventoryListFilter())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereVirtualMachinesRead,
+		Schema: s,
+	}
+}
+
+func dataSourceVSphereVirtualMachinesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	container, err := inventoryListContainer(d, client, true)
+	if err != nil {
+		return err
+	}
+	filter, err := newInventoryListFilter(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var vms []mo.VirtualMachine
+	ps := []string{
+		"name",
+		"customValue",
+		"runtime.powerState",
+		"config.uuid",
+		"config.template",
+		"config.guestId",
+		"config.hardware.numCPU",
+		"config.hardware.memoryMB",
+	}
+	if err := inventoryListRetrieve(client, container, "VirtualMachine", ps, &vms); err != nil {
+		return err
+	}
+	sort.Slice(vms, func(i, j int) bool { return vms[i].Name < vms[j].Name })
+
+	var ids []string
+	var result []interface{}
+	for _, vm := range vms {
+		if !filter.Match(vm.ManagedEntity) {
+			continue
+		}
+		if v, ok := d.GetOk("power_state"); ok && string(vm.Runtime.PowerState) != v.(string) {
+			continue
+		}
+		m := map[string]interface{}{
+			"id":          vm.Reference().Value,
+			"name":        vm.Name,
+			"power_state": string(vm.Runtime.PowerState),
+		}
+		// The config property is not available for inaccessible virtual
+		// machines.
+		if vm.Config != nil {
+			m["uuid"] = vm.Config.Uuid
+			m["template"] = vm.Config.Template
+			m["guest_id"] = vm.Config.GuestId
+			m["num_cpus"] = int(vm.Config.Hardware.NumCPU)
+			m["memory"] = int(vm.Config.Hardware.MemoryMB)
+		}
+		ids = append(ids, vm.Reference().Value)
+		result = append(result, m)
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %s", err)
+	}
+	if err := d.Set("virtual_machines", result); err != nil {
+		return fmt.Errorf("error setting virtual_machines: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereVirtualMachines_tags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereVirtualMachinesPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereVirtualMachinesConfigTags(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_virtual_machines.vms", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_virtual_machines.vms", "ids.0",
+						"vsphere_virtual_machine.vm", "moid",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_virtual_machines.vms", "virtual_machines.0.uuid",
+						"vsphere_virtual_machine.vm", "uuid",
+					),
+					resource.TestCheckResourceAttr("data.vsphere_virtual_machines.vms", "virtual_machines.0.power_state", "poweredOn"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVSphereVirtualMachines_customAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereVirtualMachinesPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereVirtualMachinesConfigCustomAttributes(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vsphere_virtual_machines.vms", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.vsphere_virtual_machines.vms", "ids.0",
+						"vsphere_virtual_machine.vm", "moid",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereVirtualMachinesPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_virtual_machines acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DATASTORE") == "" {
+		t.Skip("set VSPHERE_DATASTORE to run vsphere_virtual_machines acceptance tests")
+	}
+	if os.Getenv("VSPHERE_CLUSTER") == "" {
+		t.Skip("set VSPHERE_CLUSTER to run vsphere_virtual_machines acceptance tests")
+	}
+	if os.Getenv("VSPHERE_NETWORK_LABEL_PXE") == "" {
+		t.Skip("set VSPHERE_NETWORK_LABEL_PXE to run vsphere_virtual_machines acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereVirtualMachinesConfigBase() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+variable "cluster" {
+  default = "%s"
+}
+
+variable "network_label" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "${var.cluster}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_network" "network" {
+  name          = "${var.network_label}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		os.Getenv("VSPHERE_CLUSTER"),
+		os.Getenv("VSPHERE_NETWORK_LABEL_PXE"),
+	)
+}
+
+func testAccDataSourceVSphereVirtualMachinesConfigTags() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_tag_category" "terraform-test-category" {
+  name        = "terraform-test-tag-category"
+  cardinality = "MULTIPLE"
+
+  associable_types = [
+    "VirtualMachine",
+  ]
+}
+
+resource "vsphere_tag" "terraform-test-tag" {
+  name        = "terraform-test-tag"
+  category_id = "${vsphere_tag_category.terraform-test-category.id}"
+}
+
+resource "vsphere_virtual_machine" "vm" {
+  name             = "terraform-test"
+  resource_pool_id = "${data.vsphere_compute_cluster.cluster.resource_pool_id}"
+  datastore_id     = "${data.vsphere_datastore.datastore.id}"
+
+  num_cpus = 2
+  memory   = 2048
+  guest_id = "other3xLinux64Guest"
+
+  wait_for_guest_net_timeout = -1
+
+  network_interface {
+    network_id = "${data.vsphere_network.network.id}"
+  }
+
+  disk {
+    label = "disk0"
+    size  = 20
+  }
+
+  tags = ["${vsphere_tag.terraform-test-tag.id}"]
+}
+
+data "vsphere_virtual_machines" "vms" {
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+  cluster_id    = "${data.vsphere_compute_cluster.cluster.id}"
+  tag_ids       = ["${vsphere_tag.terraform-test-tag.id}"]
+  power_state   = "poweredOn"
+
+  depends_on = ["vsphere_virtual_machine.vm"]
+}
+`,
+		testAccDataSourceVSphereVirtualMachinesConfigBase(),
+	)
+}
+
+func testAccDataSourceVSphereVirtualMachinesConfigCustomAttributes() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_custom_attribute" "terraform-test-attribute" {
+  name                = "terraform-test-attribute"
+  managed_object_type = "VirtualMachine"
+}
+
+resource "vsphere_virtual_machine" "vm" {
+  name             = "terraform-test"
+  resource_pool_id = "${data.vsphere_compute_cluster.cluster.resource_pool_id}"
+  datastore_id     = "${data.vsphere_datastore.datastore.id}"
+
+  num_cpus = 2
+  memory   = 2048
+  guest_id = "other3xLinux64Guest"
+
+  wait_for_guest_net_timeout = -1
+
+  network_interface {
+    network_id = "${data.vsphere_network.network.id}"
+  }
+
+  disk {
+    label = "disk0"
+    size  = 20
+  }
+
+  custom_attributes = "${map(vsphere_custom_attribute.terraform-test-attribute.id, "terraform-test-value")}"
+}
+
+data "vsphere_virtual_machines" "vms" {
+  datacenter_id     = "${data.vsphere_datacenter.dc.id}"
+  custom_attributes = "${map(vsphere_custom_attribute.terraform-test-attribute.id, "terraform-test-value")}"
+
+  depends_on = ["vsphere_virtual_machine.vm"]
+}
+`,
+		testAccDataSourceVSphereVirtualMachinesConfigBase(),
+	)
+}
@@ -0,0 +1,194 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/computeresource"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func dataSourceVSphereVMHardwareOptions() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"default_version": {
+			Type:        schema.TypeString,
+			Description: "The default hardware version for new virtual machines.",
+			Computed:    true,
+		},
+		"versions": {
+			Type:        schema.TypeList,
+			Description: "The keys of the hardware versions that new virtual machines can be created with, such as vmx-14.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"hardware_versions": {
+			Type:        schema.TypeList,
+			Description: "The hardware versions known to the cluster or host.",
+			Computed:    true,
+			Elem:        &schema.Resource{Schema: schemaVirtualHardwareOption()},
+		},
+	}
+	structure.MergeSchema(s, schemaVMConfigOptionTarget())
+
+	return &schema.Resource{
+		Read:   dataSourceVSphereVMHardwareOptionsRead,
+		Schema: s,
+	}
+}
+
+// schemaVirtualHardwareOption returns the schema for a single hardware version
+// in the hardware_versions attribute of the vsphere_vm_hardware_options data
+// source.
+func schemaVirtualHardwareOption() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The key of the hardware version, for use in the compatibility_version attribute of vsphere_virtual_machine.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The description of the hardware version.",
+		},
+		"default": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not this is the default hardware version for new virtual machines.",
+		},
+		"create_supported": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not new virtual machines can be created with this hardware version.",
+		},
+		"run_supported": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not virtual machines with this hardware version can be powered on.",
+		},
+		"upgrade_supported": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not virtual machines can be upgraded to this hardware version.",
+		},
+		"max_cpus": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum number of virtual processors.",
+		},
+		"max_cores_per_socket": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum number of cores per virtual socket.",
+		},
+		"max_memory": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum memory size, in MB.",
+		},
+		"max_disks_per_scsi_controller": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum number of disks on a single SCSI controller.",
+		},
+	}
+}
+
+func dataSourceVSphereVMHardwareOptionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	ref, host, err := vmConfigOptionTarget(d, client)
+	if err != nil {
+		return err
+	}
+	descs, err := computeresource.ConfigOptionDescriptorsFromReference(client, ref)
+	if err != nil {
+		return fmt.Errorf("error querying config option descriptors: %s", err)
+	}
+
+	var defaultVersion string
+	var versions []string
+	var hwVersions []interface{}
+	for _, desc := range descs {
+		if host != nil && !vmConfigOptionDescriptorHasHost(desc, host.Reference()) {
+			log.Printf("[DEBUG] Skipping hardware version %q: not supported on host %q", desc.Key, host.Reference().Value)
+			continue
+		}
+		co, err := computeresource.ConfigOptionFromReference(client, ref, desc.Key, host)
+		if err != nil {
+			return fmt.Errorf("error querying config options for hardware version %q: %s", desc.Key, err)
+		}
+		m := flattenVirtualMachineConfigOptionDescriptor(desc, co)
+		if m["default"].(bool) {
+			defaultVersion = desc.Key
+		}
+		if m["create_supported"].(bool) {
+			versions = append(versions, desc.Key)
+		}
+		hwVersions = append(hwVersions, m)
+	}
+
+	d.SetId(vmConfigOptionID(ref, host, "hardware_options"))
+	d.Set("default_version", defaultVersion)
+	if err := d.Set("versions", versions); err != nil {
+		return fmt.Errorf("error setting versions: %s", err)
+	}
+	if err := d.Set("hardware_versions", hwVersions); err != nil {
+		return fmt.Errorf("error setting hardware_versions: %s", err)
+	}
+	return nil
+}
+
+// vmConfigOptionDescriptorHasHost returns true if the supplied host is in the
+// host list of the descriptor. An empty host list means the descriptor applies
+// to all hosts.
+func vmConfigOptionDescriptorHasHost(desc types.VirtualMachineConfigOptionDescriptor, host types.ManagedObjectReference) bool {
+	if len(desc.Host) < 1 {
+		return true
+	}
+	for _, ref := range desc.Host {
+		if ref.Value == host.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenVirtualMachineConfigOptionDescriptor converts a config option
+// descriptor and its config option into the format used by the
+// hardware_versions attribute of the vsphere_vm_hardware_options data source.
+func flattenVirtualMachineConfigOptionDescriptor(desc types.VirtualMachineConfigOptionDescriptor, co *types.VirtualMachineConfigOption) map[string]interface{} {
+	hw := co.HardwareOptions
+	var maxCPUs int32
+	for _, n := range hw.NumCPU {
+		if n > maxCPUs {
+			maxCPUs = n
+		}
+	}
+	var maxCoresPerSocket int32
+	if hw.NumCoresPerSocket != nil {
+		maxCoresPerSocket = hw.NumCoresPerSocket.Max
+	}
+	var maxSCSIDisks int32
+	for _, bdo := range hw.VirtualDeviceOption {
+		if o, ok := bdo.(types.BaseVirtualSCSIControllerOption); ok {
+			if n := o.GetVirtualSCSIControllerOption().NumSCSIDisks.Max; n > maxSCSIDisks {
+				maxSCSIDisks = n
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"version":                       desc.Key,
+		"description":                   desc.Description,
+		"default":                       desc.DefaultConfigOption != nil && *desc.DefaultConfigOption,
+		"create_supported":              desc.CreateSupported != nil && *desc.CreateSupported,
+		"run_supported":                 desc.RunSupported != nil && *desc.RunSupported,
+		"upgrade_supported":             desc.UpgradeSupported != nil && *desc.UpgradeSupported,
+		"max_cpus":                      int(maxCPUs),
+		"max_cores_per_socket":          int(maxCoresPerSocket),
+		"max_memory":                    int(hw.MemoryMB.Max),
+		"max_disks_per_scsi_controller": int(maxSCSIDisks),
+	}
+}
@@ -0,0 +1,59 @@
+package vsphere
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceVSphereVMHardwareOptions_cluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccDataSourceVSphereVMHardwareOptionsPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVSphereVMHardwareOptionsConfigCluster(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.vsphere_vm_hardware_options.options", "default_version", regexp.MustCompile("^vmx-[0-9]+$")),
+					resource.TestMatchResourceAttr("data.vsphere_vm_hardware_options.options", "versions.#", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttrSet("data.vsphere_vm_hardware_options.options", "hardware_versions.0.max_cpus"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVSphereVMHardwareOptionsPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_vm_hardware_options acceptance tests")
+	}
+	if os.Getenv("VSPHERE_CLUSTER") == "" {
+		t.Skip("set VSPHERE_CLUSTER to run vsphere_vm_hardware_options acceptance tests")
+	}
+}
+
+func testAccDataSourceVSphereVMHardwareOptionsConfigCluster() string {
+	return fmt.Sprintf(`
+data "vsphere_datacenter" "dc" {
+  name = "%s"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "%s"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_vm_hardware_options" "options" {
+  cluster_id = "${data.vsphere_compute_cluster.cluster.id}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_CLUSTER"),
+	)
+}
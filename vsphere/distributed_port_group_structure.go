@@ -1,6 +1,12 @@
 package vsphere
 
 import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
@@ -12,6 +18,28 @@ var distributedVirtualPortgroupPortgroupTypeAllowedValues = []string{
 	string(types.DistributedVirtualPortgroupPortgroupTypeEphemeral),
 }
 
+// dvsTrafficFilterAgentName is the name of the filter agent that processes
+// traffic filtering and marking rules on a port group.
+const dvsTrafficFilterAgentName = "dvfilter-generic-vmware"
+
+const (
+	dvsTrafficRuleActionAccept = "accept"
+	dvsTrafficRuleActionDrop   = "drop"
+	dvsTrafficRuleActionTag    = "tag"
+)
+
+var dvsTrafficRuleActionAllowedValues = []string{
+	dvsTrafficRuleActionAccept,
+	dvsTrafficRuleActionDrop,
+	dvsTrafficRuleActionTag,
+}
+
+var dvsNetworkRuleDirectionTypeAllowedValues = []string{
+	string(types.DvsNetworkRuleDirectionTypeIncomingPackets),
+	string(types.DvsNetworkRuleDirectionTypeOutgoingPackets),
+	string(types.DvsNetworkRuleDirectionTypeBoth),
+}
+
 // schemaDVPortgroupConfigSpec returns schema items for resources that
 // need to work with a DVPortgroupConfigSpec.
 func schemaDVPortgroupConfigSpec() map[string]*schema.Schema {
@@ -115,11 +143,355 @@ func schemaDVPortgroupConfigSpec() map[string]*schema.Schema {
 			Default:     "-1",
 			Description: "The key of a network resource pool to associate with this portgroup.",
 		},
+
+		// DvsFilterPolicy
+		"traffic_rule": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "An ordered list of traffic filtering and marking rules for this portgroup. Rules are evaluated in the order they are defined.",
+			Elem:        &schema.Resource{Schema: schemaDvsTrafficRule()},
+		},
 	}
 	structure.MergeSchema(s, schemaVMwareDVSPortSetting())
 	return s
 }
 
+// schemaDvsTrafficRule returns the schema for a single traffic filtering and
+// marking rule. Only IP qualifiers are currently supported, as the MAC and
+// system traffic qualifier types do not satisfy BaseDvsNetworkRuleQualifier
+// in the version of govmomi currently in use.
+func schemaDvsTrafficRule() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "A description of the rule.",
+		},
+		"action": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The action to take on matching traffic. Can be one of accept, drop, or tag.",
+			ValidateFunc: validation.StringInSlice(dvsTrafficRuleActionAllowedValues, false),
+		},
+		"direction": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(types.DvsNetworkRuleDirectionTypeBoth),
+			Description:  "The direction of the traffic that the rule applies to. Can be one of incomingPackets, outgoingPackets, or both.",
+			ValidateFunc: validation.StringInSlice(dvsNetworkRuleDirectionTypeAllowedValues, false),
+		},
+		"cos_tag": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      -1,
+			Description:  "The CoS (802.1p) priority tag, from 0 to 7, to set on matching traffic when action is tag. -1 leaves the tag unchanged.",
+			ValidateFunc: validation.IntBetween(-1, 7),
+		},
+		"dscp_tag": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      -1,
+			Description:  "The DSCP value, from 0 to 63, to set on matching traffic when action is tag. -1 leaves the tag unchanged.",
+			ValidateFunc: validation.IntBetween(-1, 63),
+		},
+		// DvsIpNetworkRuleQualifier
+		"protocol": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      -1,
+			Description:  "The IP protocol number to match. -1 matches any protocol.",
+			ValidateFunc: validation.IntBetween(-1, 255),
+		},
+		"source_address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The source IP address or CIDR network to match.",
+			ValidateFunc: validateDvsTrafficRuleIPAddress,
+		},
+		"destination_address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The destination IP address or CIDR network to match.",
+			ValidateFunc: validateDvsTrafficRuleIPAddress,
+		},
+		"source_port": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The source TCP/UDP port, or range of ports in the form start-end, to match.",
+			ValidateFunc: validateDvsTrafficRulePort,
+		},
+		"destination_port": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The destination TCP/UDP port, or range of ports in the form start-end, to match.",
+			ValidateFunc: validateDvsTrafficRulePort,
+		},
+	}
+}
+
+// validateDvsTrafficRuleIPAddress validates an IP address or CIDR network in
+// a traffic rule.
+func validateDvsTrafficRuleIPAddress(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+	if value == "" {
+		return nil, nil
+	}
+	if strings.Contains(value, "/") {
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return nil, []error{fmt.Errorf("%s: invalid CIDR network %q", k, value)}
+		}
+		return nil, nil
+	}
+	if net.ParseIP(value) == nil {
+		return nil, []error{fmt.Errorf("%s: invalid IP address %q", k, value)}
+	}
+	return nil, nil
+}
+
+// validateDvsTrafficRulePort validates a port or port range in a traffic
+// rule.
+func validateDvsTrafficRulePort(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+	if value == "" {
+		return nil, nil
+	}
+	if _, err := expandDvsIPPort(value); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// expandDvsIPPort parses a port or port range string into a BaseDvsIpPort.
+func expandDvsIPPort(s string) (types.BaseDvsIpPort, error) {
+	parts := strings.SplitN(s, "-", 2)
+	start, err := strconv.Atoi(parts[0])
+	if err != nil || start < 0 || start > 65535 {
+		return nil, fmt.Errorf("invalid port %q", parts[0])
+	}
+	if len(parts) == 1 {
+		return &types.DvsSingleIpPort{PortNumber: int32(start)}, nil
+	}
+	end, err := strconv.Atoi(parts[1])
+	if err != nil || end < start || end > 65535 {
+		return nil, fmt.Errorf("invalid port range %q", s)
+	}
+	return &types.DvsIpPortRange{StartPortNumber: int32(start), EndPortNumber: int32(end)}, nil
+}
+
+// flattenDvsIPPort returns the string form of a BaseDvsIpPort.
+func flattenDvsIPPort(obj types.BaseDvsIpPort) string {
+	switch p := obj.(type) {
+	case *types.DvsSingleIpPort:
+		return strconv.Itoa(int(p.PortNumber))
+	case *types.DvsIpPortRange:
+		return fmt.Sprintf("%d-%d", p.StartPortNumber, p.EndPortNumber)
+	}
+	return ""
+}
+
+// expandIPAddress parses an IP address or CIDR network string into a
+// BaseIpAddress.
+func expandIPAddress(s string) types.BaseIpAddress {
+	if ip, ipnet, err := net.ParseCIDR(s); err == nil {
+		l, _ := ipnet.Mask.Size()
+		return &types.IpRange{AddressPrefix: ip.String(), PrefixLength: int32(l)}
+	}
+	return &types.SingleIp{Address: s}
+}
+
+// flattenIPAddress returns the string form of a BaseIpAddress.
+func flattenIPAddress(obj types.BaseIpAddress) string {
+	switch a := obj.(type) {
+	case *types.SingleIp:
+		return a.Address
+	case *types.IpRange:
+		return fmt.Sprintf("%s/%d", a.AddressPrefix, a.PrefixLength)
+	}
+	return ""
+}
+
+// expandDvsTrafficRule reads a single traffic rule from a list object map and
+// returns a DvsTrafficRule with the supplied sequence number.
+func expandDvsTrafficRule(d map[string]interface{}, sequence int32) types.DvsTrafficRule {
+	obj := types.DvsTrafficRule{
+		Description: d["description"].(string),
+		Sequence:    sequence,
+		Direction:   d["direction"].(string),
+	}
+
+	switch d["action"].(string) {
+	case dvsTrafficRuleActionAccept:
+		obj.Action = &types.DvsAcceptNetworkRuleAction{}
+	case dvsTrafficRuleActionDrop:
+		obj.Action = &types.DvsDropNetworkRuleAction{}
+	case dvsTrafficRuleActionTag:
+		// Only the tags that are set are sent. -1 leaves the tag unchanged.
+		action := &types.DvsUpdateTagNetworkRuleAction{}
+		if v := d["cos_tag"].(int); v >= 0 {
+			action.QosTag = int32(v)
+		}
+		if v := d["dscp_tag"].(int); v >= 0 {
+			action.DscpTag = int32(v)
+		}
+		obj.Action = action
+	}
+
+	ipq := &types.DvsIpNetworkRuleQualifier{}
+	if v := d["protocol"].(int); v >= 0 {
+		ipq.Protocol = &types.IntExpression{Value: int32(v)}
+	}
+	if v := d["source_address"].(string); v != "" {
+		ipq.SourceAddress = expandIPAddress(v)
+	}
+	if v := d["destination_address"].(string); v != "" {
+		ipq.DestinationAddress = expandIPAddress(v)
+	}
+	if v := d["source_port"].(string); v != "" {
+		ipq.SourceIpPort, _ = expandDvsIPPort(v)
+	}
+	if v := d["destination_port"].(string); v != "" {
+		ipq.DestinationIpPort, _ = expandDvsIPPort(v)
+	}
+	if !structure.AllFieldsEmpty(ipq) {
+		obj.Qualifier = append(obj.Qualifier, ipq)
+	}
+
+	return obj
+}
+
+// flattenDvsTrafficRule reads various fields from a DvsTrafficRule and
+// returns a list object map.
+//
+// This is the flatten counterpart to expandDvsTrafficRule.
+func flattenDvsTrafficRule(obj types.DvsTrafficRule) map[string]interface{} {
+	d := map[string]interface{}{
+		"description": obj.Description,
+		"direction":   obj.Direction,
+		"protocol":    -1,
+		"cos_tag":     -1,
+		"dscp_tag":    -1,
+	}
+
+	switch a := obj.Action.(type) {
+	case *types.DvsAcceptNetworkRuleAction:
+		d["action"] = dvsTrafficRuleActionAccept
+	case *types.DvsDropNetworkRuleAction:
+		d["action"] = dvsTrafficRuleActionDrop
+	case *types.DvsUpdateTagNetworkRuleAction:
+		d["action"] = dvsTrafficRuleActionTag
+		if a.QosTag > 0 {
+			d["cos_tag"] = int(a.QosTag)
+		}
+		if a.DscpTag > 0 {
+			d["dscp_tag"] = int(a.DscpTag)
+		}
+	}
+
+	for _, q := range obj.Qualifier {
+		ipq, ok := q.(*types.DvsIpNetworkRuleQualifier)
+		if !ok {
+			continue
+		}
+		if ipq.Protocol != nil {
+			d["protocol"] = int(ipq.Protocol.Value)
+		}
+		d["source_address"] = flattenIPAddress(ipq.SourceAddress)
+		d["destination_address"] = flattenIPAddress(ipq.DestinationAddress)
+		d["source_port"] = flattenDvsIPPort(ipq.SourceIpPort)
+		d["destination_port"] = flattenDvsIPPort(ipq.DestinationIpPort)
+	}
+
+	return d
+}
+
+// expandDvsFilterPolicy reads the traffic_rule list and returns a
+// DvsFilterPolicy containing a traffic filter config spec, or nil if the rules
+// have not changed. Rules are sequenced in the order they appear in
+// configuration.
+//
+// The key of the existing traffic filter, if any, is supplied through
+// currentKey, and is needed to edit or remove the rules.
+func expandDvsFilterPolicy(d *schema.ResourceData, currentKey string) *types.DvsFilterPolicy {
+	if !d.HasChange("traffic_rule") {
+		return nil
+	}
+
+	spec := &types.DvsTrafficFilterConfigSpec{
+		DvsTrafficFilterConfig: types.DvsTrafficFilterConfig{
+			DvsFilterConfig: types.DvsFilterConfig{
+				Key:       currentKey,
+				AgentName: dvsTrafficFilterAgentName,
+			},
+		},
+	}
+
+	rules := d.Get("traffic_rule").([]interface{})
+	switch {
+	case len(rules) < 1 && currentKey == "":
+		return nil
+	case len(rules) < 1:
+		spec.Operation = string(types.ConfigSpecOperationRemove)
+	case currentKey == "":
+		spec.Operation = string(types.ConfigSpecOperationAdd)
+	default:
+		spec.Operation = string(types.ConfigSpecOperationEdit)
+	}
+
+	if len(rules) > 0 {
+		ruleset := &types.DvsTrafficRuleset{
+			Enabled: structure.BoolPtr(true),
+		}
+		for i, r := range rules {
+			ruleset.Rules = append(ruleset.Rules, expandDvsTrafficRule(r.(map[string]interface{}), int32(i+1)))
+		}
+		spec.TrafficRuleset = ruleset
+	}
+
+	obj := &types.DvsFilterPolicy{
+		InheritablePolicy: types.InheritablePolicy{
+			Inherited: false,
+		},
+		FilterConfig: []types.BaseDvsFilterConfig{spec},
+	}
+	return obj
+}
+
+// dvsTrafficFilterConfig returns the traffic filter config in the supplied
+// filter policy, or nil if there is none.
+func dvsTrafficFilterConfig(obj *types.DvsFilterPolicy) *types.DvsTrafficFilterConfig {
+	if obj == nil {
+		return nil
+	}
+	for _, fc := range obj.FilterConfig {
+		if tfc, ok := fc.(*types.DvsTrafficFilterConfig); ok {
+			return tfc
+		}
+	}
+	return nil
+}
+
+// flattenDvsFilterPolicy reads the traffic rules from a DvsFilterPolicy into
+// the traffic_rule list, sorted by sequence number. Rules inherited from the
+// DVS are not included.
+//
+// This is the flatten counterpart to expandDvsFilterPolicy.
+func flattenDvsFilterPolicy(d *schema.ResourceData, obj *types.DvsFilterPolicy) error {
+	var rules []types.DvsTrafficRule
+	if obj != nil && obj.Inherited {
+		obj = nil
+	}
+	if tfc := dvsTrafficFilterConfig(obj); tfc != nil && tfc.TrafficRuleset != nil {
+		rules = append(rules, tfc.TrafficRuleset.Rules...)
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Sequence < rules[j].Sequence })
+
+	var s []map[string]interface{}
+	for _, rule := range rules {
+		s = append(s, flattenDvsTrafficRule(rule))
+	}
+	return d.Set("traffic_rule", s)
+}
+
 // expandVMwareDVSPortgroupPolicy reads certain ResourceData keys and
 // returns a VMwareDVSPortgroupPolicy.
 func expandVMwareDVSPortgroupPolicy(d *schema.ResourceData) *types.VMwareDVSPortgroupPolicy {
@@ -158,8 +530,9 @@ func flattenVMwareDVSPortgroupPolicy(d *schema.ResourceData, obj *types.VMwareDV
 }
 
 // expandDVPortgroupConfigSpec reads certain ResourceData keys and
-// returns a DVPortgroupConfigSpec.
-func expandDVPortgroupConfigSpec(d *schema.ResourceData) types.DVPortgroupConfigSpec {
+// returns a DVPortgroupConfigSpec. The current configuration of the portgroup
+// is used to update existing traffic rules, and should be nil on creation.
+func expandDVPortgroupConfigSpec(d *schema.ResourceData, current *types.DVPortgroupConfigInfo) types.DVPortgroupConfigSpec {
 	obj := types.DVPortgroupConfigSpec{
 		ConfigVersion:                d.Get("config_version").(string),
 		Name:                         d.Get("name").(string),
@@ -172,6 +545,22 @@ func expandDVPortgroupConfigSpec(d *schema.ResourceData) types.DVPortgroupConfig
 		AutoExpand:                   structure.GetBoolPtr(d, "auto_expand"),
 		VmVnicNetworkResourcePoolKey: d.Get("network_resource_pool_key").(string),
 	}
+	var filterKey string
+	if current != nil && current.DefaultPortConfig != nil {
+		if tfc := dvsTrafficFilterConfig(current.DefaultPortConfig.GetDVPortSetting().FilterPolicy); tfc != nil {
+			filterKey = tfc.Key
+		}
+	}
+	if policy := expandDvsFilterPolicy(d, filterKey); policy != nil {
+		// The port setting is nil when none of its attributes are set, so it
+		// needs to be created to carry the filter policy.
+		ps, _ := obj.DefaultPortConfig.(*types.VMwareDVSPortSetting)
+		if ps == nil {
+			ps = &types.VMwareDVSPortSetting{}
+			obj.DefaultPortConfig = ps
+		}
+		ps.FilterPolicy = policy
+	}
 	return obj
 }
 
@@ -195,5 +584,5 @@ func flattenDVPortgroupConfigInfo(d *schema.ResourceData, obj types.DVPortgroupC
 	if err := flattenVMwareDVSPortgroupPolicy(d, obj.Policy.(*types.VMwareDVSPortgroupPolicy)); err != nil {
 		return err
 	}
-	return nil
+	return flattenDvsFilterPolicy(d, obj.DefaultPortConfig.GetDVPortSetting().FilterPolicy)
 }
@@ -0,0 +1,106 @@
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestExpandDVPortgroupConfigSpec(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected func(t *testing.T, spec types.DVPortgroupConfigSpec)
+	}{
+		{
+			name: "minimal",
+			raw: map[string]interface{}{
+				"name":                            "terraform-test-pg",
+				"distributed_virtual_switch_uuid": "50 00 00 00 00 00 00 00-00 00 00 00 00 00 00 00",
+			},
+			expected: func(t *testing.T, spec types.DVPortgroupConfigSpec) {
+				if ps, _ := spec.DefaultPortConfig.(*types.VMwareDVSPortSetting); ps != nil && ps.FilterPolicy != nil {
+					t.Fatalf("expected no filter policy, got %#v", ps.FilterPolicy)
+				}
+			},
+		},
+		{
+			name: "traffic rule only",
+			raw: map[string]interface{}{
+				"name":                            "terraform-test-pg",
+				"distributed_virtual_switch_uuid": "50 00 00 00 00 00 00 00-00 00 00 00 00 00 00 00",
+				"traffic_rule": []interface{}{
+					map[string]interface{}{
+						"action":   "tag",
+						"dscp_tag": 46,
+					},
+				},
+			},
+			expected: func(t *testing.T, spec types.DVPortgroupConfigSpec) {
+				ps, _ := spec.DefaultPortConfig.(*types.VMwareDVSPortSetting)
+				if ps == nil || ps.FilterPolicy == nil {
+					t.Fatal("expected filter policy to be set")
+				}
+				tfc := ps.FilterPolicy.FilterConfig[0].(*types.DvsTrafficFilterConfigSpec)
+				if tfc.Operation != string(types.ConfigSpecOperationAdd) {
+					t.Fatalf("expected operation %q, got %q", types.ConfigSpecOperationAdd, tfc.Operation)
+				}
+				action := tfc.TrafficRuleset.Rules[0].Action.(*types.DvsUpdateTagNetworkRuleAction)
+				if action.DscpTag != 46 {
+					t.Fatalf("expected DSCP tag 46, got %d", action.DscpTag)
+				}
+				if action.QosTag != 0 {
+					t.Fatalf("expected CoS tag to be unset, got %d", action.QosTag)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceVSphereDistributedPortGroup().Schema, tc.raw)
+			tc.expected(t, expandDVPortgroupConfigSpec(d, nil))
+		})
+	}
+}
+
+func TestFlattenDvsTrafficRule(t *testing.T) {
+	cases := []struct {
+		name    string
+		action  types.BaseDvsNetworkRuleAction
+		cosTag  int
+		dscpTag int
+	}{
+		{
+			name:    "accept",
+			action:  &types.DvsAcceptNetworkRuleAction{},
+			cosTag:  -1,
+			dscpTag: -1,
+		},
+		{
+			name:    "drop",
+			action:  &types.DvsDropNetworkRuleAction{},
+			cosTag:  -1,
+			dscpTag: -1,
+		},
+		{
+			name:    "tag",
+			action:  &types.DvsUpdateTagNetworkRuleAction{DscpTag: 46},
+			cosTag:  -1,
+			dscpTag: 46,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := flattenDvsTrafficRule(types.DvsTrafficRule{Action: tc.action})
+			if d["cos_tag"] != tc.cosTag {
+				t.Fatalf("expected cos_tag %d, got %v", tc.cosTag, d["cos_tag"])
+			}
+			if d["dscp_tag"] != tc.dscpTag {
+				t.Fatalf("expected dscp_tag %d, got %v", tc.dscpTag, d["dscp_tag"])
+			}
+		})
+	}
+}
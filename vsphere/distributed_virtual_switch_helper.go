@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/network"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
@@ -125,6 +126,63 @@ func updateDVSConfiguration(client *govmomi.Client, dvs *object.VmwareDistribute
 	return nil
 }
 
+// updateDVSLacpGroupConfig exposes the UpdateDVSLacpGroupConfig_Task method of
+// the VmwareDistributedVirtualSwitch MO, which is used to add, edit, or remove
+// link aggregation groups on a DVS. This local implementation may go away if
+// this is exposed in the higher-level object upstream.
+func updateDVSLacpGroupConfig(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, specs []types.VMwareDvsLacpGroupSpec) error {
+	if len(specs) < 1 {
+		return nil
+	}
+	req := &types.UpdateDVSLacpGroupConfig_Task{
+		This:          dvs.Reference(),
+		LacpGroupSpec: specs,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.UpdateDVSLacpGroupConfig_Task(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	if err := task.Wait(tctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconfigureDVSVmVnicNetworkResourcePool exposes the
+// DvsReconfigureVmVnicNetworkResourcePool_Task method of the
+// DistributedVirtualSwitch MO, which is used to manage user-defined virtual
+// machine network resource pools under network I/O control version 3. This
+// local implementation may go away if this is exposed in the higher-level
+// object upstream.
+func reconfigureDVSVmVnicNetworkResourcePool(client *govmomi.Client, dvs *object.VmwareDistributedVirtualSwitch, specs []types.DvsVmVnicResourcePoolConfigSpec) error {
+	req := &types.DvsReconfigureVmVnicNetworkResourcePool_Task{
+		This:       dvs.Reference(),
+		ConfigSpec: specs,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.DvsReconfigureVmVnicNetworkResourcePool_Task(ctx, client, req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	tctx, tcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer tcancel()
+	if err := task.Wait(tctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // enableDVSNetworkResourceManagement exposes the
 // EnableNetworkResourceManagement method of the DistributedVirtualSwitch MO.
 // This local implementation may go away if this is exposed in the higher-level
@@ -144,3 +202,27 @@ func enableDVSNetworkResourceManagement(client *govmomi.Client, dvs *object.Vmwa
 
 	return nil
 }
+
+// dvsPortKeysForPortgroups returns the keys of all ports on the supplied DVS
+// that belong to the port groups identified by the supplied port group keys.
+func dvsPortKeysForPortgroups(dvs *object.VmwareDistributedVirtualSwitch, pgKeys []string) ([]string, error) {
+	if len(pgKeys) < 1 {
+		return nil, nil
+	}
+	criteria := &types.DistributedVirtualSwitchPortCriteria{
+		PortgroupKey: pgKeys,
+		Inside:       structure.BoolPtr(true),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	ports, err := dvs.FetchDVPorts(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, port := range ports {
+		keys = append(keys, port.Key)
+	}
+	return keys, nil
+}
@@ -15,6 +15,34 @@ var lacpAPIVersionAllowedValues = []string{
 	string(types.VMwareDvsLacpApiVersionMultipleLag),
 }
 
+var lacpGroupModeAllowedValues = []string{
+	string(types.VMwareUplinkLacpModeActive),
+	string(types.VMwareUplinkLacpModePassive),
+}
+
+var lacpGroupLoadBalanceAlgorithmAllowedValues = []string{
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcMac),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestMac),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestMac),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIpVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIpVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIpTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIpTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpTcpUdpPort),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIpTcpUdpPortVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIpTcpUdpPortVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpTcpUdpPortVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmDestIp),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcIp),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIp),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmVlan),
+	string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcPortId),
+}
+
 var multicastFilteringModeAllowedValues = []string{
 	string(types.VMwareDvsMulticastFilteringModeLegacyFiltering),
 	string(types.VMwareDvsMulticastFilteringModeSnooping),
@@ -151,6 +179,72 @@ func schemaVMwareDVSConfigSpec() map[string]*schema.Schema {
 			ValidateFunc: validation.StringInSlice(linkDiscoveryProtocolConfigProtocolAllowedValues, false),
 		},
 
+		// VMwareDvsLacpGroupConfig
+		"lacp_group": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "A link aggregation group (LAG) to create on this DVS. Requires lacp_api_version to be multipleLag.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The name of the link aggregation group. Port groups can reference this name as an uplink in their teaming policy.",
+						ValidateFunc: validation.NoZeroValues,
+					},
+					"uplink_count": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "The number of uplink ports in the link aggregation group.",
+						ValidateFunc: validation.IntBetween(1, 32),
+					},
+					"mode": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      string(types.VMwareUplinkLacpModeActive),
+						Description:  "The LACP mode for the link aggregation group. Can be one of active or passive.",
+						ValidateFunc: validation.StringInSlice(lacpGroupModeAllowedValues, false),
+					},
+					"load_balancing_mode": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      string(types.VMwareDvsLacpLoadBalanceAlgorithmSrcDestIpTcpUdpPortVlan),
+						Description:  "The load balancing algorithm for the link aggregation group.",
+						ValidateFunc: validation.StringInSlice(lacpGroupLoadBalanceAlgorithmAllowedValues, false),
+					},
+				},
+			},
+		},
+
+		// VMwareDVSPvlanMapEntry
+		"pvlan_mapping": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "A private VLAN (PVLAN) mapping.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"primary_vlan_id": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "The primary VLAN ID. The VLAN IDs of 0 and 4095 are reserved and cannot be used in this property.",
+						ValidateFunc: validation.IntBetween(1, 4094),
+					},
+					"secondary_vlan_id": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "The secondary VLAN ID. The VLAN IDs of 0 and 4095 are reserved and cannot be used in this property.",
+						ValidateFunc: validation.IntBetween(1, 4094),
+					},
+					"pvlan_type": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "The private VLAN type. Valid values are promiscuous, community and isolated.",
+						ValidateFunc: validation.StringInSlice(privateVLANTypeAllowedValues, false),
+					},
+				},
+			},
+		},
+
 		// DVSNameArrayUplinkPortPolicy
 		"uplinks": {
 			Type:        schema.TypeList,
@@ -509,6 +603,203 @@ func flattenSliceOfDvsHostInfrastructureTrafficResource(d *schema.ResourceData,
 	return nil
 }
 
+// expandVMwareDvsLacpGroupConfig reads certain keys from a Set object map and
+// returns a VMwareDvsLacpGroupConfig.
+func expandVMwareDvsLacpGroupConfig(d map[string]interface{}) types.VMwareDvsLacpGroupConfig {
+	obj := types.VMwareDvsLacpGroupConfig{
+		Name:                 d["name"].(string),
+		UplinkNum:            int32(d["uplink_count"].(int)),
+		Mode:                 d["mode"].(string),
+		LoadbalanceAlgorithm: d["load_balancing_mode"].(string),
+	}
+	return obj
+}
+
+// flattenVMwareDvsLacpGroupConfig reads various fields from a
+// VMwareDvsLacpGroupConfig and returns a Set object map.
+//
+// This is the flatten counterpart to expandVMwareDvsLacpGroupConfig.
+func flattenVMwareDvsLacpGroupConfig(obj types.VMwareDvsLacpGroupConfig) map[string]interface{} {
+	d := make(map[string]interface{})
+	d["name"] = obj.Name
+	d["uplink_count"] = int(obj.UplinkNum)
+	d["mode"] = obj.Mode
+	d["load_balancing_mode"] = obj.LoadbalanceAlgorithm
+	return d
+}
+
+// expandSliceOfVMwareDvsLacpGroupSpec expands all link aggregation group
+// entries for a VMware DVS, detecting if a group needs to be added, removed,
+// or edited. Groups are matched by name, and the supplied current group
+// configuration is used to look up the keys of groups that are being removed
+// or edited.
+//
+// Removals are returned separately from additions and edits, so that groups
+// can be removed before the rest of the DVS configuration is updated and added
+// after.
+func expandSliceOfVMwareDvsLacpGroupSpec(d *schema.ResourceData, current []types.VMwareDvsLacpGroupConfig) ([]types.VMwareDvsLacpGroupSpec, []types.VMwareDvsLacpGroupSpec) {
+	var removeSpecs, updateSpecs []types.VMwareDvsLacpGroupSpec
+	o, n := d.GetChange("lacp_group")
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+
+	// Make an intersection set. These groups have not changed so we don't
+	// bother with them.
+	is := os.Intersection(ns)
+	os = os.Difference(is)
+	ns = ns.Difference(is)
+
+	keys := make(map[string]string)
+	for _, c := range current {
+		keys[c.Name] = c.Key
+	}
+
+	for _, oe := range os.List() {
+		om := oe.(map[string]interface{})
+		var found bool
+		for _, ne := range ns.List() {
+			nm := ne.(map[string]interface{})
+			if nm["name"] == om["name"] {
+				found = true
+			}
+		}
+		if !found {
+			key, ok := keys[om["name"].(string)]
+			if !ok {
+				// Already gone
+				continue
+			}
+			spec := types.VMwareDvsLacpGroupSpec{
+				LacpGroupConfig: expandVMwareDvsLacpGroupConfig(om),
+				Operation:       string(types.ConfigSpecOperationRemove),
+			}
+			spec.LacpGroupConfig.Key = key
+			removeSpecs = append(removeSpecs, spec)
+		}
+	}
+
+	for _, ne := range ns.List() {
+		nm := ne.(map[string]interface{})
+		spec := types.VMwareDvsLacpGroupSpec{
+			LacpGroupConfig: expandVMwareDvsLacpGroupConfig(nm),
+		}
+		if key, ok := keys[nm["name"].(string)]; ok {
+			spec.LacpGroupConfig.Key = key
+			spec.Operation = string(types.ConfigSpecOperationEdit)
+		} else {
+			spec.Operation = string(types.ConfigSpecOperationAdd)
+		}
+		updateSpecs = append(updateSpecs, spec)
+	}
+
+	return removeSpecs, updateSpecs
+}
+
+// flattenSliceOfVMwareDvsLacpGroupConfig creates a set of all link
+// aggregation group entries for a supplied slice of VMwareDvsLacpGroupConfig.
+//
+// This is the flatten counterpart to expandSliceOfVMwareDvsLacpGroupSpec.
+func flattenSliceOfVMwareDvsLacpGroupConfig(d *schema.ResourceData, groups []types.VMwareDvsLacpGroupConfig) error {
+	var s []map[string]interface{}
+	for _, g := range groups {
+		s = append(s, flattenVMwareDvsLacpGroupConfig(g))
+	}
+	if err := d.Set("lacp_group", s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// expandVMwareDVSPvlanMapEntry reads certain keys from a Set object map and
+// returns a VMwareDVSPvlanMapEntry.
+func expandVMwareDVSPvlanMapEntry(d map[string]interface{}) types.VMwareDVSPvlanMapEntry {
+	obj := types.VMwareDVSPvlanMapEntry{
+		PrimaryVlanId:   int32(d["primary_vlan_id"].(int)),
+		SecondaryVlanId: int32(d["secondary_vlan_id"].(int)),
+		PvlanType:       d["pvlan_type"].(string),
+	}
+	return obj
+}
+
+// flattenVMwareDVSPvlanMapEntry reads various fields from a
+// VMwareDVSPvlanMapEntry and returns a Set object map.
+//
+// This is the flatten counterpart to expandVMwareDVSPvlanMapEntry.
+func flattenVMwareDVSPvlanMapEntry(obj types.VMwareDVSPvlanMapEntry) map[string]interface{} {
+	d := make(map[string]interface{})
+	d["primary_vlan_id"] = int(obj.PrimaryVlanId)
+	d["secondary_vlan_id"] = int(obj.SecondaryVlanId)
+	d["pvlan_type"] = obj.PvlanType
+	return d
+}
+
+// expandSliceOfVMwareDVSPvlanConfigSpec expands all private VLAN mapping
+// entries for a VMware DVS, detecting if an entry needs to be added or
+// removed. Entries cannot be edited, so a changed entry is removed and
+// re-added.
+//
+// Promiscuous entries define the primary VLAN, so they are ordered so that
+// they are added before and removed after any of their secondary entries.
+func expandSliceOfVMwareDVSPvlanConfigSpec(d *schema.ResourceData) []types.VMwareDVSPvlanConfigSpec {
+	o, n := d.GetChange("pvlan_mapping")
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+
+	// Make an intersection set. These entries have not changed so we don't
+	// bother with them.
+	is := os.Intersection(ns)
+	os = os.Difference(is)
+	ns = ns.Difference(is)
+
+	var removeSpecs, removePromiscuousSpecs []types.VMwareDVSPvlanConfigSpec
+	for _, oe := range os.List() {
+		spec := types.VMwareDVSPvlanConfigSpec{
+			PvlanEntry: expandVMwareDVSPvlanMapEntry(oe.(map[string]interface{})),
+			Operation:  string(types.ConfigSpecOperationRemove),
+		}
+		if spec.PvlanEntry.PvlanType == string(types.VmwareDistributedVirtualSwitchPvlanPortTypePromiscuous) {
+			removePromiscuousSpecs = append(removePromiscuousSpecs, spec)
+		} else {
+			removeSpecs = append(removeSpecs, spec)
+		}
+	}
+
+	var addSpecs, addPromiscuousSpecs []types.VMwareDVSPvlanConfigSpec
+	for _, ne := range ns.List() {
+		spec := types.VMwareDVSPvlanConfigSpec{
+			PvlanEntry: expandVMwareDVSPvlanMapEntry(ne.(map[string]interface{})),
+			Operation:  string(types.ConfigSpecOperationAdd),
+		}
+		if spec.PvlanEntry.PvlanType == string(types.VmwareDistributedVirtualSwitchPvlanPortTypePromiscuous) {
+			addPromiscuousSpecs = append(addPromiscuousSpecs, spec)
+		} else {
+			addSpecs = append(addSpecs, spec)
+		}
+	}
+
+	var specs []types.VMwareDVSPvlanConfigSpec
+	specs = append(specs, removeSpecs...)
+	specs = append(specs, removePromiscuousSpecs...)
+	specs = append(specs, addPromiscuousSpecs...)
+	specs = append(specs, addSpecs...)
+	return specs
+}
+
+// flattenSliceOfVMwareDVSPvlanMapEntry creates a set of all private VLAN
+// mapping entries for a supplied slice of VMwareDVSPvlanMapEntry.
+//
+// This is the flatten counterpart to expandSliceOfVMwareDVSPvlanConfigSpec.
+func flattenSliceOfVMwareDVSPvlanMapEntry(d *schema.ResourceData, entries []types.VMwareDVSPvlanMapEntry) error {
+	var s []map[string]interface{}
+	for _, e := range entries {
+		s = append(s, flattenVMwareDVSPvlanMapEntry(e))
+	}
+	if err := d.Set("pvlan_mapping", s); err != nil {
+		return err
+	}
+	return nil
+}
+
 // expandDVSNameArrayUplinkPortPolicy reads certain ResourceData keys and
 // returns a DVSNameArrayUplinkPortPolicy.
 func expandDVSNameArrayUplinkPortPolicy(d *schema.ResourceData) *types.DVSNameArrayUplinkPortPolicy {
@@ -551,6 +842,7 @@ func expandVMwareDVSConfigSpec(d *schema.ResourceData) *types.VMwareDVSConfigSpe
 		IpfixConfig:                 expandVMwareIpfixConfig(d),
 		LacpApiVersion:              d.Get("lacp_api_version").(string),
 		MulticastFilteringMode:      d.Get("multicast_filtering_mode").(string),
+		PvlanConfigSpec:             expandSliceOfVMwareDVSPvlanConfigSpec(d),
 	}
 	return obj
 }
@@ -590,6 +882,12 @@ func flattenVMwareDVSConfigInfo(d *schema.ResourceData, obj *types.VMwareDVSConf
 	if err := flattenSliceOfDvsHostInfrastructureTrafficResource(d, obj.InfrastructureTrafficResourceConfig); err != nil {
 		return err
 	}
+	if err := flattenSliceOfVMwareDvsLacpGroupConfig(d, obj.LacpGroupConfig); err != nil {
+		return err
+	}
+	if err := flattenSliceOfVMwareDVSPvlanMapEntry(d, obj.PvlanConfig); err != nil {
+		return err
+	}
 	if err := flattenDVSContactInfo(d, obj.Contact); err != nil {
 		return err
 	}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"reflect"
 	"time"
 
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/event"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -57,8 +60,21 @@ func newVirtualMachineCustomizationWaiter(client *govmomi.Client, vm *object.Vir
 	w := &virtualMachineCustomizationWaiter{
 		done: make(chan struct{}),
 	}
+	// Record the current time on the server, so that completion events from
+	// an earlier customization of the same virtual machine are ignored.
+	var since time.Time
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer cancel()
+		now, err := methods.GetCurrentTime(ctx, client)
+		if err != nil {
+			log.Printf("[DEBUG] Could not fetch server time for customization waiter on %q: %s", vm.InventoryPath, err)
+		} else {
+			since = *now
+		}
+	}
 	go func() {
-		w.err = w.wait(client, vm, timeout)
+		w.err = w.wait(client, vm, timeout, since)
 		close(w.done)
 	}()
 	return w
@@ -68,8 +84,8 @@ func newVirtualMachineCustomizationWaiter(client *govmomi.Client, vm *object.Vir
 // either due to success or error. It does this by watching specifically for
 // CustomizationSucceeded and CustomizationFailed events. If the customization
 // failed due to some sort of error, the full formatted message is returned as
-// an error.
-func (w *virtualMachineCustomizationWaiter) wait(client *govmomi.Client, vm *object.VirtualMachine, timeout int) error {
+// an error. Events created before since are ignored.
+func (w *virtualMachineCustomizationWaiter) wait(client *govmomi.Client, vm *object.VirtualMachine, timeout int, since time.Time) error {
 	// A timeout of less than 1 minute (zero or negative value) skips the waiter,
 	// so we return immediately.
 	if timeout < 1 {
@@ -80,6 +96,9 @@ func (w *virtualMachineCustomizationWaiter) wait(client *govmomi.Client, vm *obj
 	cbErr := make(chan error, 1)
 	cb := func(obj types.ManagedObjectReference, page []types.BaseEvent) error {
 		for _, be := range page {
+			if be.GetEvent().CreatedTime.Before(since) {
+				continue
+			}
 			switch e := be.(type) {
 			case types.BaseCustomizationFailed:
 				cbErr <- errors.New(e.GetCustomizationFailed().GetEvent().FullFormattedMessage)
@@ -138,3 +157,81 @@ func selectEventsForReference(client *govmomi.Client, ref types.ManagedObjectRef
 	mgr := event.NewManager(client.Client)
 	return mgr.QueryEvents(ctx, filter)
 }
+
+// eventHistoryPageSize is the number of events that queryEvents reads from an
+// event history collector at once.
+const eventHistoryPageSize = 100
+
+// queryEvents returns the events that match the supplied filter, newest first.
+//
+// Unlike selectEventsForReference, the events are read through an event
+// history collector, so the result is not truncated by the server-side limit
+// on QueryEvents. The collector is read backwards starting from its latest
+// page, so a maxEvents value of greater than zero stops reading once the most
+// recent maxEvents events have been collected, rather than reading the whole
+// event history.
+func queryEvents(client *govmomi.Client, filter types.EventFilterSpec, maxEvents int) ([]types.BaseEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	mgr := event.NewManager(client.Client)
+	collector, err := mgr.CreateCollectorForEvents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error creating event collector: %s", err)
+	}
+	defer func() {
+		dctx, dcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer dcancel()
+		if err := collector.Destroy(dctx); err != nil {
+			log.Printf("[DEBUG] Error destroying event collector %q: %s", collector.Reference().Value, err)
+		}
+	}()
+
+	pageSize := int32(eventHistoryPageSize)
+	if maxEvents > 0 && maxEvents < eventHistoryPageSize {
+		pageSize = int32(maxEvents)
+	}
+	if err := collector.SetPageSize(ctx, pageSize); err != nil {
+		return nil, fmt.Errorf("error setting event collector page size: %s", err)
+	}
+	events, err := collector.LatestPage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading latest events: %s", err)
+	}
+	// Reset moves the scrollable view to just before the latest page, so that
+	// ReadPreviousEvents continues with the next oldest events.
+	if err := collector.Reset(ctx); err != nil {
+		return nil, fmt.Errorf("error resetting event collector: %s", err)
+	}
+	for maxEvents < 1 || len(events) < maxEvents {
+		page, err := collector.ReadPreviousEvents(ctx, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("error reading events: %s", err)
+		}
+		if len(page) < 1 {
+			break
+		}
+		events = append(events, page...)
+	}
+
+	event.Sort(events)
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	if maxEvents > 0 && len(events) > maxEvents {
+		events = events[:maxEvents]
+	}
+	return events, nil
+}
+
+// eventTypeID returns the event type ID of the supplied event. This is the
+// eventTypeId field for EventEx and ExtendedEvent events, and the name of the
+// event's data object type for all others.
+func eventTypeID(be types.BaseEvent) string {
+	switch e := be.(type) {
+	case *types.EventEx:
+		return e.EventTypeId
+	case *types.ExtendedEvent:
+		return e.EventTypeId
+	}
+	return reflect.TypeOf(be).Elem().Name()
+}
@@ -14,12 +14,17 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/alarm"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/authorization"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/clustercomputeresource"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/customizationspec"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/dvportgroup"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/firstclassdisk"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/resourcepool"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/scheduledtask"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/storagepod"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/vappcontainer"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
@@ -458,6 +463,24 @@ func testDeleteVM(s *terraform.State, resourceName string) error {
 	return task.Wait(tctx)
 }
 
+// testUnregisterVM powers off a virtual machine and removes it from inventory,
+// leaving its files in place.
+func testUnregisterVM(s *terraform.State, resourceName string) error {
+	if err := testPowerOffVM(s, resourceName); err != nil {
+		return err
+	}
+	vm, err := testGetVirtualMachine(s, resourceName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := vm.Unregister(ctx); err != nil {
+		return fmt.Errorf("error unregistering virtual machine: %s", err)
+	}
+	return nil
+}
+
 // testGetTagCategory gets a tag category by name.
 func testGetTagCategory(s *terraform.State, resourceName string) (*tags.Category, error) {
 	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_tag_category.%s", resourceName))
@@ -660,6 +683,56 @@ func testGetDVPortgroupProperties(s *terraform.State, resourceName string) (*mo.
 	return dvportgroup.Properties(dvs)
 }
 
+// testGetDistributedPortMirrorSession is a convenience method to fetch a port
+// mirroring session from a DVS.
+func testGetDistributedPortMirrorSession(s *terraform.State, resourceName string) (*types.VMwareVspanSession, error) {
+	vars, err := testClientVariablesForResource(s, fmt.Sprintf("%s.%s", resourceVSphereDistributedPortMirrorSessionName, resourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	if vars.resourceID == "" {
+		return nil, errors.New("resource ID is empty")
+	}
+
+	dvsUUID, key, err := resourceVSphereDistributedPortMirrorSessionParseID(vars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dvs, err := dvsFromUUID(vars.client, dvsUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceVSphereDistributedPortMirrorSessionFindEntry(dvs, key)
+}
+
+// testGetDistributedNetworkResourcePool is a convenience method to fetch a
+// virtual machine network resource pool from a DVS.
+func testGetDistributedNetworkResourcePool(s *terraform.State, resourceName string) (*types.DVSVmVnicNetworkResourcePool, error) {
+	vars, err := testClientVariablesForResource(s, fmt.Sprintf("%s.%s", resourceVSphereDistributedNetworkResourcePoolName, resourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	if vars.resourceID == "" {
+		return nil, errors.New("resource ID is empty")
+	}
+
+	dvsUUID, key, err := resourceVSphereDistributedNetworkResourcePoolParseID(vars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dvs, err := dvsFromUUID(vars.client, dvsUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceVSphereDistributedNetworkResourcePoolFindEntry(dvs, key)
+}
+
 // testCheckResourceNotAttr is an inverse check of TestCheckResourceAttr. It
 // checks to make sure the resource attribute does *not* match a certain value.
 func testCheckResourceNotAttr(name, key, value string) resource.TestCheckFunc {
@@ -701,6 +774,95 @@ func testGetCustomAttribute(s *terraform.State, resourceName string) (*types.Cus
 	return field, nil
 }
 
+// testGetAlarm is a convenience method to fetch an alarm by resource name.
+func testGetAlarm(s *terraform.State, resourceName string) (*mo.Alarm, error) {
+	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_alarm.%s", resourceName))
+	if err != nil {
+		return nil, err
+	}
+	return alarm.Properties(tVars.client, tVars.resourceID)
+}
+
+// testGetScheduledTask is a convenience method to fetch a scheduled task by
+// resource name.
+func testGetScheduledTask(s *terraform.State, resourceName string) (*mo.ScheduledTask, error) {
+	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_scheduled_task.%s", resourceName))
+	if err != nil {
+		return nil, err
+	}
+	return scheduledtask.Properties(tVars.client, tVars.resourceID)
+}
+
+// testGetRole is a convenience method to fetch a role by resource name.
+func testGetRole(s *terraform.State, resourceName string) (*types.AuthorizationRole, error) {
+	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_role.%s", resourceName))
+	if err != nil {
+		return nil, err
+	}
+	id, err := resourceVSphereRoleParseID(tVars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return authorization.RoleFromID(tVars.client, id)
+}
+
+// testGetGuestOSCustomization is a convenience method to fetch a saved
+// customization specification by resource name. A nil item is returned if the
+// specification does not exist.
+func testGetGuestOSCustomization(s *terraform.State, resourceName string) (*types.CustomizationSpecItem, error) {
+	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_guest_os_customization.%s", resourceName))
+	if err != nil {
+		return nil, err
+	}
+	item, err := customizationspec.FromName(tVars.client, tVars.resourceID)
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// testGetFCD is a convenience method to fetch a first class disk by resource
+// name. A nil object is returned if the disk does not exist.
+func testGetFCD(s *terraform.State, resourceName string) (*types.VStorageObject, error) {
+	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_fcd.%s", resourceName))
+	if err != nil {
+		return nil, err
+	}
+	ds, err := datastore.FromID(tVars.client, tVars.resourceAttributes["datastore_id"])
+	if err != nil {
+		return nil, err
+	}
+	obj, err := firstclassdisk.FromID(tVars.client, tVars.resourceID, ds)
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+// testGetEntityPermission is a convenience method to fetch an entity
+// permission by resource name.
+func testGetEntityPermission(s *terraform.State, resourceName string) (*types.Permission, error) {
+	tVars, err := testClientVariablesForResource(s, fmt.Sprintf("vsphere_entity_permission.%s", resourceName))
+	if err != nil {
+		return nil, err
+	}
+	entity, principal, err := resourceVSphereEntityPermissionParseID(tVars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+	group, err := strconv.ParseBool(tVars.resourceAttributes["is_group"])
+	if err != nil {
+		return nil, err
+	}
+	return authorization.Permission(tVars.client, entity, principal, group)
+}
+
 func testResourceHasCustomAttributeValues(s *terraform.State, resourceType string, resourceName string, entity *mo.ManagedEntity) error {
 	testVars, err := testClientVariablesForResource(s, fmt.Sprintf("%s.%s", resourceType, resourceName))
 	if err != nil {
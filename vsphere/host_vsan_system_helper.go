@@ -0,0 +1,161 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// hostVsanSystemFromHostSystemID locates a HostVsanSystem from a specified
+// HostSystem managed object ID.
+func hostVsanSystemFromHostSystemID(client *govmomi.Client, hsID string) (*object.HostVsanSystem, error) {
+	hs, err := hostsystem.FromID(client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	return hs.ConfigManager().VsanSystem(ctx)
+}
+
+// hostVsanDisksByName returns the HostScsiDisk objects for the supplied
+// canonical names, as seen by the host's vSAN system. An error is returned if
+// any of the disks cannot be found.
+func hostVsanDisksByName(vs *object.HostVsanSystem, names []string) ([]types.HostScsiDisk, error) {
+	req := types.QueryDisksForVsan{
+		This:          vs.Reference(),
+		CanonicalName: names,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.QueryDisksForVsan(ctx, vs.Client(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot query disks for vSAN: %s", err)
+	}
+
+	var disks []types.HostScsiDisk
+	for _, name := range names {
+		var found bool
+		for _, result := range resp.Returnval {
+			if result.Disk.CanonicalName == name {
+				disks = append(disks, result.Disk)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("disk %q not found on host", name)
+		}
+	}
+	return disks, nil
+}
+
+// hostVsanDiskMapping returns the disk mapping (disk group) on the host that
+// uses the disk with the supplied canonical name as its cache tier. nil is
+// returned if no such disk group exists.
+func hostVsanDiskMapping(vs *object.HostVsanSystem, cacheName string) (*types.VsanHostDiskMapping, error) {
+	var props mo.HostVsanSystem
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := vs.Properties(ctx, vs.Reference(), []string{"config.storageInfo"}, &props); err != nil {
+		return nil, fmt.Errorf("error fetching vSAN system properties: %s", err)
+	}
+	if props.Config.StorageInfo == nil {
+		return nil, nil
+	}
+	for _, m := range props.Config.StorageInfo.DiskMapping {
+		if m.Ssd.CanonicalName == cacheName {
+			return &m, nil
+		}
+	}
+	return nil, nil
+}
+
+// hostVsanInitializeDisks claims the disks in the supplied mapping for vSAN.
+// If the cache disk in the mapping already belongs to a disk group, the
+// capacity disks are added to that group.
+func hostVsanInitializeDisks(vs *object.HostVsanSystem, mapping types.VsanHostDiskMapping) error {
+	req := types.InitializeDisks_Task{
+		This:    vs.Reference(),
+		Mapping: []types.VsanHostDiskMapping{mapping},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.InitializeDisks_Task(ctx, vs.Client(), &req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(vs.Client(), resp.Returnval)
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if results, ok := info.Result.(types.ArrayOfVsanHostDiskMapResult); ok {
+		for _, result := range results.VsanHostDiskMapResult {
+			if result.Error != nil {
+				return fmt.Errorf("error initializing disk group: %s", result.Error.LocalizedMessage)
+			}
+			for _, dr := range result.DiskResult {
+				if dr.Error != nil {
+					return fmt.Errorf("error initializing disk %q: %s", dr.Disk.CanonicalName, dr.Error.LocalizedMessage)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hostVsanRemoveDisks removes the supplied capacity disks from vSAN, using
+// the supplied decommission mode to handle data on the disks. timeout is in
+// seconds.
+func hostVsanRemoveDisks(vs *object.HostVsanSystem, disks []types.HostScsiDisk, mode string, timeout int) error {
+	req := types.RemoveDisk_Task{
+		This:            vs.Reference(),
+		Disk:            disks,
+		MaintenanceSpec: hostVsanMaintenanceSpec(mode),
+		Timeout:         int32(timeout),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout)+defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.RemoveDisk_Task(ctx, vs.Client(), &req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(vs.Client(), resp.Returnval)
+	return task.Wait(ctx)
+}
+
+// hostVsanRemoveDiskMapping removes an entire disk group from vSAN, using the
+// supplied decommission mode to handle data on the disks. timeout is in
+// seconds.
+func hostVsanRemoveDiskMapping(vs *object.HostVsanSystem, mapping types.VsanHostDiskMapping, mode string, timeout int) error {
+	req := types.RemoveDiskMapping_Task{
+		This:            vs.Reference(),
+		Mapping:         []types.VsanHostDiskMapping{mapping},
+		MaintenanceSpec: hostVsanMaintenanceSpec(mode),
+		Timeout:         int32(timeout),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout)+defaultAPITimeout)
+	defer cancel()
+	resp, err := methods.RemoveDiskMapping_Task(ctx, vs.Client(), &req)
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(vs.Client(), resp.Returnval)
+	return task.Wait(ctx)
+}
+
+func hostVsanMaintenanceSpec(mode string) *types.HostMaintenanceSpec {
+	return &types.HostMaintenanceSpec{
+		VsanMode: &types.VsanHostDecommissionMode{
+			ObjectAction: mode,
+		},
+	}
+}
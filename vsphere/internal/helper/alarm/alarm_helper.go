@@ -0,0 +1,116 @@
+package alarm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// VerifySupport checks to make sure that the connected endpoint supports
+// alarms. Alarms are only supported on vCenter.
+func VerifySupport(client *govmomi.Client) error {
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return errors.New("alarms are only supported on vCenter")
+	}
+	return nil
+}
+
+// Reference returns a ManagedObjectReference for the alarm with the supplied
+// managed object ID.
+func Reference(id string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  "Alarm",
+		Value: id,
+	}
+}
+
+// Properties fetches the Alarm MO for the supplied alarm managed object ID.
+func Properties(client *govmomi.Client, id string) (*mo.Alarm, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.Alarm
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, Reference(id), nil, &props); err != nil {
+		return nil, err
+	}
+	return &props, nil
+}
+
+// Create creates a new alarm on the supplied entity. The managed object ID of
+// the new alarm is returned.
+func Create(client *govmomi.Client, entity types.ManagedObjectReference, spec *types.AlarmSpec) (string, error) {
+	log.Printf("[DEBUG] Creating alarm %q on %s %q", spec.Name, entity.Type, entity.Value)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.CreateAlarm(ctx, client.Client, &types.CreateAlarm{
+		This:   *client.ServiceContent.AlarmManager,
+		Entity: entity,
+		Spec:   spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Returnval.Value, nil
+}
+
+// Reconfigure replaces the definition of an existing alarm.
+func Reconfigure(client *govmomi.Client, id string, spec *types.AlarmSpec) error {
+	log.Printf("[DEBUG] Reconfiguring alarm %q", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.ReconfigureAlarm(ctx, client.Client, &types.ReconfigureAlarm{
+		This: Reference(id),
+		Spec: spec,
+	})
+	return err
+}
+
+// Remove removes an alarm.
+func Remove(client *govmomi.Client, id string) error {
+	log.Printf("[DEBUG] Removing alarm %q", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.RemoveAlarm(ctx, client.Client, &types.RemoveAlarm{
+		This: Reference(id),
+	})
+	return err
+}
+
+// Counters returns a pair of lookup tables for the performance counters
+// defined on the connected endpoint. The first maps counter names, in the form
+// group.name.rollup (example: cpu.usage.average), to counter IDs, and the
+// second maps IDs back to names.
+func Counters(client *govmomi.Client) (map[string]int32, map[int32]string, error) {
+	if client.ServiceContent.PerfManager == nil {
+		return nil, nil, errors.New("performance manager is not available on this endpoint")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.PerformanceManager
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, *client.ServiceContent.PerfManager, []string{"perfCounter"}, &props); err != nil {
+		return nil, nil, fmt.Errorf("error fetching performance counters: %s", err)
+	}
+	byName := make(map[string]int32)
+	byID := make(map[int32]string)
+	for _, c := range props.PerfCounter {
+		name := fmt.Sprintf(
+			"%s.%s.%s",
+			c.GroupInfo.GetElementDescription().Key,
+			c.NameInfo.GetElementDescription().Key,
+			c.RollupType,
+		)
+		byName[name] = c.Key
+		byID[c.Key] = name
+	}
+	return byName, byID, nil
+}
@@ -0,0 +1,186 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// systemPrivileges are the privileges that vSphere implicitly adds to every
+// role. They are removed from the privilege list of a role when it is read so
+// that they do not need to be included in configuration.
+var systemPrivileges = []string{
+	"System.Anonymous",
+	"System.Read",
+	"System.View",
+}
+
+// newManager returns the AuthorizationManager for the supplied client.
+func newManager(client *govmomi.Client) *object.AuthorizationManager {
+	return object.NewAuthorizationManager(client.Client)
+}
+
+// Roles returns the full list of roles defined on the connected endpoint.
+func Roles(client *govmomi.Client) (object.AuthorizationRoleList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return newManager(client).RoleList(ctx)
+}
+
+// RoleFromID locates a role by its ID. A nil role is returned with no error
+// if the role could not be found.
+func RoleFromID(client *govmomi.Client, id int32) (*types.AuthorizationRole, error) {
+	log.Printf("[DEBUG] Locating role with ID %d", id)
+	roles, err := Roles(client)
+	if err != nil {
+		return nil, err
+	}
+	return roles.ById(id), nil
+}
+
+// RoleFromName locates a role by its name.
+func RoleFromName(client *govmomi.Client, name string) (*types.AuthorizationRole, error) {
+	log.Printf("[DEBUG] Locating role with name %q", name)
+	roles, err := Roles(client)
+	if err != nil {
+		return nil, err
+	}
+	role := roles.ByName(name)
+	if role == nil {
+		return nil, fmt.Errorf("could not locate role with name %q", name)
+	}
+	return role, nil
+}
+
+// Privileges returns the IDs of all privileges defined on the connected
+// endpoint.
+func Privileges(client *govmomi.Client) ([]string, error) {
+	m := newManager(client)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.AuthorizationManager
+	if err := m.Properties(ctx, m.Reference(), []string{"privilegeList"}, &props); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, p := range props.PrivilegeList {
+		ids = append(ids, p.PrivId)
+	}
+	return ids, nil
+}
+
+// ValidatePrivileges checks the supplied privilege IDs against the privileges
+// defined on the connected endpoint, and returns an error listing any that are
+// unknown.
+func ValidatePrivileges(client *govmomi.Client, ids []string) error {
+	known, err := Privileges(client)
+	if err != nil {
+		return fmt.Errorf("error fetching privilege list: %s", err)
+	}
+	m := make(map[string]struct{})
+	for _, id := range known {
+		m[id] = struct{}{}
+	}
+	var unknown []string
+	for _, id := range ids {
+		if _, ok := m[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown privileges: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// UserPrivileges returns the privileges of a role, less the system
+// privileges that vSphere adds to every role.
+func UserPrivileges(role *types.AuthorizationRole) []string {
+	var privs []string
+	for _, p := range role.Privilege {
+		if !isSystemPrivilege(p) {
+			privs = append(privs, p)
+		}
+	}
+	return privs
+}
+
+func isSystemPrivilege(id string) bool {
+	for _, p := range systemPrivileges {
+		if p == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateRole creates a new role with the supplied name and privileges, and
+// returns its ID.
+func CreateRole(client *govmomi.Client, name string, privileges []string) (int32, error) {
+	log.Printf("[DEBUG] Creating role %q", name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return newManager(client).AddRole(ctx, name, privileges)
+}
+
+// UpdateRole updates the name and privileges of an existing role.
+func UpdateRole(client *govmomi.Client, id int32, name string, privileges []string) error {
+	log.Printf("[DEBUG] Updating role %d", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return newManager(client).UpdateRole(ctx, id, name, privileges)
+}
+
+// RemoveRole removes a role. Any permissions that reference the role are
+// removed along with it.
+func RemoveRole(client *govmomi.Client, id int32) error {
+	log.Printf("[DEBUG] Removing role %d", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return newManager(client).RemoveRole(ctx, id, false)
+}
+
+// Permission returns the permission defined directly on an entity for the
+// supplied principal. A nil permission is returned with no error if no such
+// permission exists.
+func Permission(client *govmomi.Client, entity types.ManagedObjectReference, principal string, group bool) (*types.Permission, error) {
+	log.Printf("[DEBUG] Locating permission for %q on %s", principal, entity.Value)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	perms, err := newManager(client).RetrieveEntityPermissions(ctx, entity, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		if strings.EqualFold(p.Principal, principal) && p.Group == group {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+// SetPermission creates or updates the permission for a principal on an
+// entity.
+func SetPermission(client *govmomi.Client, entity types.ManagedObjectReference, perm types.Permission) error {
+	log.Printf("[DEBUG] Setting permission for %q on %s", perm.Principal, entity.Value)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return newManager(client).SetEntityPermissions(ctx, entity, []types.Permission{perm})
+}
+
+// RemovePermission removes the permission for a principal on an entity.
+func RemovePermission(client *govmomi.Client, entity types.ManagedObjectReference, principal string, group bool) error {
+	log.Printf("[DEBUG] Removing permission for %q on %s", principal, entity.Value)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return newManager(client).RemoveEntityPermission(ctx, entity, principal, group)
+}
@@ -0,0 +1,182 @@
+// Package cabinet contains a minimal writer for Microsoft cabinet (.cab)
+// archives. Only a single, uncompressed folder is written, which is readable
+// by every cabinet extractor shipped with Windows, including expand.exe and
+// the setup API.
+package cabinet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// The size of the fixed part of CFHEADER, CFFOLDER, CFFILE and CFDATA, in
+	// bytes.
+	headerSize     = 36
+	folderSize     = 8
+	fileEntrySize  = 16
+	dataHeaderSize = 8
+
+	// The maximum amount of uncompressed data in a single CFDATA block.
+	maxDataBlockSize = 0x8000
+
+	// The maximum length of a file name in a cabinet, not including the NUL
+	// terminator.
+	maxNameLength = 255
+
+	// The cabinet format version this package writes (1.3).
+	versionMinor = 3
+	versionMajor = 1
+
+	// The compression type for the folder. We never compress.
+	compressNone = 0
+
+	// File attributes.
+	attribArchive   = 0x20
+	attribNameIsUTF = 0x80
+)
+
+// File represents a single file to be written to a cabinet.
+type File struct {
+	// The name of the file in the cabinet. Path separators should be
+	// backslashes.
+	Name string
+
+	// The contents of the file.
+	Data []byte
+
+	// The modification time of the file. The zero value writes the current
+	// time.
+	ModTime time.Time
+}
+
+// Write writes a cabinet containing the supplied files to w.
+func Write(w io.Writer, files []File) error {
+	if len(files) > 0xFFFF {
+		return fmt.Errorf("too many files for a single cabinet: %d", len(files))
+	}
+
+	// Everything goes in a single folder, so the file data is simply
+	// concatenated and chunked up into data blocks.
+	var data bytes.Buffer
+	var entries bytes.Buffer
+	for _, f := range files {
+		if f.Name == "" {
+			return errors.New("file name cannot be empty")
+		}
+		if len(f.Name) > maxNameLength {
+			return fmt.Errorf("file name %q is longer than %d bytes", f.Name, maxNameLength)
+		}
+		if uint64(data.Len())+uint64(len(f.Data)) > 0x7FFFFFFF {
+			return errors.New("cabinet contents are too large")
+		}
+		mt := f.ModTime
+		if mt.IsZero() {
+			mt = time.Now()
+		}
+		attribs := uint16(attribArchive)
+		if !isASCII(f.Name) {
+			if !utf8.ValidString(f.Name) {
+				return fmt.Errorf("file name %q is not valid UTF-8", f.Name)
+			}
+			attribs |= attribNameIsUTF
+		}
+		date, tm := dosDateTime(mt)
+		writeLE(&entries, uint32(len(f.Data)))
+		writeLE(&entries, uint32(data.Len()))
+		writeLE(&entries, uint16(0))
+		writeLE(&entries, date)
+		writeLE(&entries, tm)
+		writeLE(&entries, attribs)
+		entries.WriteString(f.Name)
+		entries.WriteByte(0)
+		data.Write(f.Data)
+	}
+
+	blocks := (data.Len() + maxDataBlockSize - 1) / maxDataBlockSize
+	if blocks > 0xFFFF {
+		return errors.New("cabinet contents are too large")
+	}
+	filesOffset := headerSize + folderSize
+	dataOffset := filesOffset + entries.Len()
+	total := dataOffset + blocks*dataHeaderSize + data.Len()
+
+	var buf bytes.Buffer
+	// CFHEADER
+	buf.WriteString("MSCF")
+	writeLE(&buf, uint32(0))
+	writeLE(&buf, uint32(total))
+	writeLE(&buf, uint32(0))
+	writeLE(&buf, uint32(filesOffset))
+	writeLE(&buf, uint32(0))
+	buf.WriteByte(versionMinor)
+	buf.WriteByte(versionMajor)
+	writeLE(&buf, uint16(1))
+	writeLE(&buf, uint16(len(files)))
+	writeLE(&buf, uint16(0))
+	writeLE(&buf, uint16(0))
+	writeLE(&buf, uint16(0))
+	// CFFOLDER
+	writeLE(&buf, uint32(dataOffset))
+	writeLE(&buf, uint16(blocks))
+	writeLE(&buf, uint16(compressNone))
+	// CFFILE entries
+	buf.Write(entries.Bytes())
+	// CFDATA blocks. The checksum is optional and a value of zero indicates
+	// that it was not computed.
+	for b := data.Bytes(); len(b) > 0; {
+		n := len(b)
+		if n > maxDataBlockSize {
+			n = maxDataBlockSize
+		}
+		writeLE(&buf, uint32(0))
+		writeLE(&buf, uint16(n))
+		writeLE(&buf, uint16(n))
+		buf.Write(b[:n])
+		b = b[n:]
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Bytes returns a cabinet containing the supplied files.
+func Bytes(files []File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Write(&buf, files); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeLE writes v to buf in little-endian byte order. Writes to a
+// bytes.Buffer never fail.
+func writeLE(buf *bytes.Buffer, v interface{}) {
+	binary.Write(buf, binary.LittleEndian, v)
+}
+
+// dosDateTime converts t to an MS-DOS date and time, which is the format
+// used for file timestamps in a cabinet. Times before 1980 are clamped.
+func dosDateTime(t time.Time) (uint16, uint16) {
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	date := uint16((t.Year()-1980)<<9 | int(t.Month())<<5 | t.Day())
+	tm := uint16(t.Hour()<<11 | t.Minute()<<5 | t.Second()/2)
+	return date, tm
+}
+
+// isASCII returns true if s contains only 7-bit ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+package cabinet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testReadCabinet parses a cabinet written by Write and returns the file names
+// and contents it contains.
+func testReadCabinet(t *testing.T, b []byte) map[string][]byte {
+	le := binary.LittleEndian
+	if string(b[0:4]) != "MSCF" {
+		t.Fatalf("bad signature %q", b[0:4])
+	}
+	if size := le.Uint32(b[8:12]); int(size) != len(b) {
+		t.Fatalf("expected cbCabinet to be %d, got %d", len(b), size)
+	}
+	if b[24] != versionMinor || b[25] != versionMajor {
+		t.Fatalf("bad version %d.%d", b[25], b[24])
+	}
+	if folders := le.Uint16(b[26:28]); folders != 1 {
+		t.Fatalf("expected 1 folder, got %d", folders)
+	}
+	nfiles := int(le.Uint16(b[28:30]))
+	filesOffset := int(le.Uint32(b[16:20]))
+	dataOffset := int(le.Uint32(b[headerSize : headerSize+4]))
+	nblocks := int(le.Uint16(b[headerSize+4 : headerSize+6]))
+
+	var data []byte
+	p := dataOffset
+	for i := 0; i < nblocks; i++ {
+		cb := int(le.Uint16(b[p+4 : p+6]))
+		if cb > maxDataBlockSize {
+			t.Fatalf("data block %d is larger than the maximum: %d", i, cb)
+		}
+		if uncomp := int(le.Uint16(b[p+6 : p+8])); uncomp != cb {
+			t.Fatalf("data block %d: expected uncompressed size %d, got %d", i, cb, uncomp)
+		}
+		data = append(data, b[p+dataHeaderSize:p+dataHeaderSize+cb]...)
+		p += dataHeaderSize + cb
+	}
+	if p != len(b) {
+		t.Fatalf("expected data blocks to end at %d, got %d", len(b), p)
+	}
+
+	files := make(map[string][]byte)
+	p = filesOffset
+	for i := 0; i < nfiles; i++ {
+		size := int(le.Uint32(b[p : p+4]))
+		offset := int(le.Uint32(b[p+4 : p+8]))
+		end := bytes.IndexByte(b[p+fileEntrySize:], 0)
+		name := string(b[p+fileEntrySize : p+fileEntrySize+end])
+		files[name] = data[offset : offset+size]
+		p += fileEntrySize + end + 1
+	}
+	if p != dataOffset {
+		t.Fatalf("expected file entries to end at %d, got %d", dataOffset, p)
+	}
+	return files
+}
+
+func TestWrite(t *testing.T) {
+	large := []byte(strings.Repeat("0123456789abcdef", maxDataBlockSize/8+3))
+	cases := []struct {
+		name  string
+		files []File
+	}{
+		{
+			name:  "empty",
+			files: nil,
+		},
+		{
+			name: "single file",
+			files: []File{
+				{Name: "customization.json", Data: []byte(`{"computer_name":"foo"}`)},
+			},
+		},
+		{
+			name: "multiple blocks",
+			files: []File{
+				{Name: "small.txt", Data: []byte("foo")},
+				{Name: "large.bin", Data: large},
+				{Name: "dir\\empty.txt", Data: []byte{}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := Bytes(tc.files)
+			if err != nil {
+				t.Fatalf("bad: %s", err)
+			}
+			expected := make(map[string][]byte)
+			for _, f := range tc.files {
+				expected[f.Name] = f.Data
+			}
+			actual := testReadCabinet(t, b)
+			if len(expected) != len(actual) {
+				t.Fatalf("expected %d files, got %d", len(expected), len(actual))
+			}
+			for name, data := range expected {
+				if !bytes.Equal(data, actual[name]) {
+					t.Fatalf("contents of %q do not match", name)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteBadName(t *testing.T) {
+	cases := []struct {
+		name string
+		file File
+	}{
+		{
+			name: "empty",
+			file: File{Name: ""},
+		},
+		{
+			name: "too long",
+			file: File{Name: strings.Repeat("a", maxNameLength+1)},
+		},
+		{
+			name: "invalid utf-8",
+			file: File{Name: "foo\xff"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Bytes([]File{tc.file}); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestDosDateTime(t *testing.T) {
+	cases := []struct {
+		name     string
+		subject  time.Time
+		expected []uint16
+	}{
+		{
+			name:     "standard",
+			subject:  time.Date(2018, 6, 15, 13, 45, 31, 0, time.UTC),
+			expected: []uint16{38<<9 | 6<<5 | 15, 13<<11 | 45<<5 | 15},
+		},
+		{
+			name:     "before 1980",
+			subject:  time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []uint16{1<<5 | 1, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			date, tm := dosDateTime(tc.subject)
+			actual := []uint16{date, tm}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
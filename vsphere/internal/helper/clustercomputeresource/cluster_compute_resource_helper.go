@@ -13,6 +13,8 @@ import (
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
@@ -224,3 +226,142 @@ func moveHostOutOf(cluster *object.ClusterComputeResource, host *object.HostSyst
 	log.Printf("[DEBUG] Host %q moved out of cluster %q successfully", host.Name(), cluster.Name())
 	return nil
 }
+
+// EVCManager returns the reference to the ClusterEVCManager for the supplied
+// cluster. This requires vSphere 6.0 or higher.
+func EVCManager(cluster *object.ClusterComputeResource) (types.ManagedObjectReference, error) {
+	req := types.EvcManager{
+		This: cluster.Reference(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.EvcManager(ctx, cluster.Client(), &req)
+	if err != nil {
+		return types.ManagedObjectReference{}, err
+	}
+	if resp.Returnval == nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("cluster %q did not return an EVC manager", cluster.Name())
+	}
+	return *resp.Returnval, nil
+}
+
+// EVCState returns the current EVC state of the supplied cluster, including
+// the current EVC mode and the modes supported by the vSphere server.
+func EVCState(cluster *object.ClusterComputeResource) (*types.ClusterEVCManagerEVCState, error) {
+	ref, err := EVCManager(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.ClusterEVCManager
+	pc := property.DefaultCollector(cluster.Client())
+	if err := pc.RetrieveOne(ctx, ref, []string{"evcState"}, &props); err != nil {
+		return nil, err
+	}
+	return &props.EvcState, nil
+}
+
+// ConfigureEVC sets the EVC mode of the supplied cluster to the mode
+// represented by key.
+func ConfigureEVC(cluster *object.ClusterComputeResource, key string) error {
+	log.Printf("[DEBUG] Setting EVC mode on cluster %q to %q", cluster.Name(), key)
+	ref, err := EVCManager(cluster)
+	if err != nil {
+		return err
+	}
+
+	req := types.ConfigureEvcMode_Task{
+		This:       ref,
+		EvcModeKey: key,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.ConfigureEvcMode_Task(ctx, cluster.Client(), &req)
+	if err != nil {
+		return err
+	}
+
+	task := object.NewTask(cluster.Client(), resp.Returnval)
+	return task.Wait(ctx)
+}
+
+// DisableEVC turns off EVC on the supplied cluster.
+func DisableEVC(cluster *object.ClusterComputeResource) error {
+	log.Printf("[DEBUG] Disabling EVC on cluster %q", cluster.Name())
+	ref, err := EVCManager(cluster)
+	if err != nil {
+		return err
+	}
+
+	req := types.DisableEvcMode_Task{
+		This: ref,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.DisableEvcMode_Task(ctx, cluster.Client(), &req)
+	if err != nil {
+		return err
+	}
+
+	task := object.NewTask(cluster.Client(), resp.Returnval)
+	return task.Wait(ctx)
+}
+
+// SupportedEVCModes returns the list of EVC modes that the connected vSphere
+// server supports. This list is not tied to any specific cluster, which allows
+// it to be used to validate EVC settings before a cluster exists.
+func SupportedEVCModes(client *govmomi.Client) ([]types.EVCMode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.ServiceInstance
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, vim25.ServiceInstance, []string{"capability"}, &props); err != nil {
+		return nil, err
+	}
+	return props.Capability.SupportedEVCMode, nil
+}
+
+// ValidateHostsForEVCMode checks to see if all of the supplied hosts can run
+// in a cluster with the EVC mode represented by key. A host supports a mode if
+// the mode belongs to the same CPU vendor as the host's maximum supported EVC
+// mode, and is at the same or a lower tier.
+func ValidateHostsForEVCMode(modes []types.EVCMode, hosts []*object.HostSystem, key string) error {
+	mode, ok := findEVCMode(modes, key)
+	if !ok {
+		return fmt.Errorf("EVC mode %q is not supported by this vSphere server", key)
+	}
+
+	for _, host := range hosts {
+		hprops, err := hostsystem.Properties(host)
+		if err != nil {
+			return fmt.Errorf("error getting properties for host %q: %s", host.Name(), err)
+		}
+		maxKey := hprops.Summary.MaxEVCModeKey
+		if maxKey == "" {
+			return fmt.Errorf("host %q does not report a maximum EVC mode and cannot join a cluster with EVC mode %q", hprops.Name, key)
+		}
+		max, ok := findEVCMode(modes, maxKey)
+		if !ok {
+			return fmt.Errorf("maximum EVC mode %q of host %q is not known to this vSphere server", maxKey, hprops.Name)
+		}
+		if max.Vendor != mode.Vendor || max.VendorTier < mode.VendorTier {
+			return fmt.Errorf("host %q (maximum EVC mode %q) does not support EVC mode %q", hprops.Name, maxKey, key)
+		}
+	}
+
+	return nil
+}
+
+func findEVCMode(modes []types.EVCMode, key string) (types.EVCMode, bool) {
+	for _, mode := range modes {
+		if mode.Key == key {
+			return mode, true
+		}
+	}
+	return types.EVCMode{}, false
+}
@@ -161,6 +161,33 @@ func OSFamily(client *govmomi.Client, ref types.ManagedObjectReference, guest st
 	return b.OSFamily(ctx, guest)
 }
 
+// ConfigOptionFromReference uses the compute resource's environment browser to
+// fetch the VirtualMachineConfigOption for the supplied descriptor key and
+// optional host.
+func ConfigOptionFromReference(client *govmomi.Client, ref types.ManagedObjectReference, key string, host *object.HostSystem) (*types.VirtualMachineConfigOption, error) {
+	log.Printf("[DEBUG] Fetching config option %q for object reference %q", key, ref.Value)
+	b, err := EnvironmentBrowserFromReference(client, ref)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return b.ConfigOption(ctx, key, host)
+}
+
+// ConfigOptionDescriptorsFromReference uses the compute resource's environment
+// browser to fetch the descriptors of the virtual machine versions that the
+// compute resource supports.
+func ConfigOptionDescriptorsFromReference(client *govmomi.Client, ref types.ManagedObjectReference) ([]types.VirtualMachineConfigOptionDescriptor, error) {
+	b, err := EnvironmentBrowserFromReference(client, ref)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return b.QueryConfigOptionDescriptor(ctx)
+}
+
 // EnvironmentBrowserFromReference loads an environment browser for the
 // specific compute resource reference. The reference can be either a
 // standalone host or cluster.
@@ -0,0 +1,70 @@
+package customizationspec
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// VerifySupport checks to make sure that the connected endpoint supports
+// saved customization specifications. The customization specification
+// manager is only available on vCenter.
+func VerifySupport(client *govmomi.Client) error {
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return errors.New("guest OS customization specifications are only supported on vCenter")
+	}
+	return nil
+}
+
+// manager returns the CustomizationSpecManager for the supplied client.
+func manager(client *govmomi.Client) *object.CustomizationSpecManager {
+	return object.NewCustomizationSpecManager(client.Client)
+}
+
+// FromName fetches the customization specification with the supplied name.
+func FromName(client *govmomi.Client, name string) (*types.CustomizationSpecItem, error) {
+	log.Printf("[DEBUG] Fetching customization specification %q", name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return manager(client).GetCustomizationSpec(ctx, name)
+}
+
+// Create creates a new customization specification.
+func Create(client *govmomi.Client, item types.CustomizationSpecItem) error {
+	log.Printf("[DEBUG] Creating customization specification %q", item.Info.Name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return manager(client).CreateCustomizationSpec(ctx, item)
+}
+
+// Overwrite replaces an existing customization specification. The change
+// version in the item's info must match the current change version of the
+// specification.
+func Overwrite(client *govmomi.Client, item types.CustomizationSpecItem) error {
+	log.Printf("[DEBUG] Overwriting customization specification %q", item.Info.Name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return manager(client).OverwriteCustomizationSpec(ctx, item)
+}
+
+// Rename renames an existing customization specification.
+func Rename(client *govmomi.Client, name, newName string) error {
+	log.Printf("[DEBUG] Renaming customization specification %q to %q", name, newName)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return manager(client).RenameCustomizationSpec(ctx, name, newName)
+}
+
+// Delete deletes a customization specification.
+func Delete(client *govmomi.Client, name string) error {
+	log.Printf("[DEBUG] Deleting customization specification %q", name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return manager(client).DeleteCustomizationSpec(ctx, name)
+}
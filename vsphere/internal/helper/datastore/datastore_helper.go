@@ -177,3 +177,61 @@ func FileExists(ds *object.Datastore, name string) (bool, error) {
 	}
 	return path.Base(name) == files[0].Path, nil
 }
+
+// SearchDatastoreFolder searches a single directory in a datastore using the
+// supplied search spec. The directory should be a bare path, not a datastore
+// path.
+func SearchDatastoreFolder(ds *object.Datastore, dir string, spec *types.HostDatastoreBrowserSearchSpec) (*types.HostDatastoreBrowserSearchResults, error) {
+	browser, err := Browser(ds)
+	if err != nil {
+		return nil, err
+	}
+	dp := &object.DatastorePath{
+		Datastore: ds.Name(),
+		Path:      dir,
+	}
+	log.Printf("[DEBUG] Searching %q", dp)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	task, err := browser.SearchDatastore(ctx, dp.String(), spec)
+	if err != nil {
+		return nil, err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	info, err := task.WaitForResult(tctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	r := info.Result.(types.HostDatastoreBrowserSearchResults)
+	return &r, nil
+}
+
+// SearchDatastoreSubFolders searches a directory in a datastore and all of
+// its subdirectories using the supplied search spec. The directory should be
+// a bare path, not a datastore path. One result is returned for each folder
+// that was searched.
+func SearchDatastoreSubFolders(ds *object.Datastore, dir string, spec *types.HostDatastoreBrowserSearchSpec) ([]types.HostDatastoreBrowserSearchResults, error) {
+	browser, err := Browser(ds)
+	if err != nil {
+		return nil, err
+	}
+	dp := &object.DatastorePath{
+		Datastore: ds.Name(),
+		Path:      dir,
+	}
+	log.Printf("[DEBUG] Searching %q and its subfolders", dp)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	task, err := browser.SearchDatastoreSubFolders(ctx, dp.String(), spec)
+	if err != nil {
+		return nil, err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	info, err := task.WaitForResult(tctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return info.Result.(types.ArrayOfHostDatastoreBrowserSearchResults).HostDatastoreBrowserSearchResults, nil
+}
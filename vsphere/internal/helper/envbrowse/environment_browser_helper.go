@@ -111,3 +111,27 @@ func (b *EnvironmentBrowser) QueryConfigOptionDescriptor(ctx context.Context) ([
 	}
 	return res.Returnval, nil
 }
+
+// ConfigOption returns the full VirtualMachineConfigOption for the optionally
+// supplied descriptor key and host. This contains the supported guest
+// operating systems and hardware limits for the virtual machine version
+// referenced by key. If no key is supplied, the results generally reflect the
+// default VM hardware version of the environment.
+func (b *EnvironmentBrowser) ConfigOption(ctx context.Context, key string, host *object.HostSystem) (*types.VirtualMachineConfigOption, error) {
+	req := types.QueryConfigOption{
+		This: b.Reference(),
+		Key:  key,
+	}
+	if host != nil {
+		ref := host.Reference()
+		req.Host = &ref
+	}
+	res, err := methods.QueryConfigOption(ctx, b.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Returnval == nil {
+		return nil, errors.New("no config options were found for the supplied criteria")
+	}
+	return res.Returnval, nil
+}
@@ -0,0 +1,255 @@
+package firstclassdisk
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// minSupportedVersion is the minimum version of vCenter that first class
+// disks can be managed on.
+var minSupportedVersion = viapi.VSphereVersion{
+	Product: "VMware vCenter Server",
+	Major:   6,
+	Minor:   5,
+}
+
+// VerifySupport checks to make sure that the connected endpoint supports
+// first class disks. The VStorageObjectManager used here is only available on
+// vCenter 6.5 and higher.
+func VerifySupport(client *govmomi.Client) error {
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return fmt.Errorf("first class disks are only supported on vCenter")
+	}
+	version := viapi.ParseVersionFromClient(client)
+	if version.Older(minSupportedVersion) {
+		return fmt.Errorf("first class disks are only supported on vCenter %s and higher (current version: %s)", minSupportedVersion, version)
+	}
+	return nil
+}
+
+// manager returns the reference to the VStorageObjectManager for the supplied
+// client.
+func manager(client *govmomi.Client) types.ManagedObjectReference {
+	return *client.ServiceContent.VStorageObjectManager
+}
+
+// FromID fetches the first class disk with the supplied ID in the supplied
+// datastore.
+func FromID(client *govmomi.Client, id string, ds *object.Datastore) (*types.VStorageObject, error) {
+	log.Printf("[DEBUG] Fetching first class disk %q on datastore %q", id, ds.Reference().Value)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.RetrieveVStorageObject{
+		This:      manager(client),
+		Id:        types.ID{Id: id},
+		Datastore: ds.Reference(),
+	}
+	res, err := methods.RetrieveVStorageObject(ctx, client.Client, &req)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Returnval, nil
+}
+
+// FromName fetches the first class disk with the supplied name in the
+// supplied datastore. An error is returned if there is not exactly one disk
+// with the name.
+func FromName(client *govmomi.Client, name string, ds *object.Datastore) (*types.VStorageObject, error) {
+	log.Printf("[DEBUG] Looking for first class disk %q on datastore %q", name, ds.Reference().Value)
+	ids, err := List(client, ds)
+	if err != nil {
+		return nil, err
+	}
+	var found *types.VStorageObject
+	for _, id := range ids {
+		obj, err := FromID(client, id.Id, ds)
+		if err != nil {
+			return nil, err
+		}
+		if obj.Config.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("multiple first class disks named %q found", name)
+		}
+		found = obj
+	}
+	if found == nil {
+		return nil, fmt.Errorf("first class disk %q not found", name)
+	}
+	return found, nil
+}
+
+// List returns the IDs of all first class disks in the supplied datastore.
+func List(client *govmomi.Client, ds *object.Datastore) ([]types.ID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.ListVStorageObject{
+		This:      manager(client),
+		Datastore: ds.Reference(),
+	}
+	res, err := methods.ListVStorageObject(ctx, client.Client, &req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Returnval, nil
+}
+
+// Create creates a new first class disk from the supplied spec.
+func Create(client *govmomi.Client, spec types.VslmCreateSpec) (*types.VStorageObject, error) {
+	log.Printf("[DEBUG] Creating first class disk %q", spec.Name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.CreateDisk_Task{
+		This: manager(client),
+		Spec: spec,
+	}
+	res, err := methods.CreateDisk_Task(ctx, client.Client, &req)
+	if err != nil {
+		return nil, err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	result, err := object.NewTask(client.Client, res.Returnval).WaitForResult(tctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	obj := result.Result.(types.VStorageObject)
+	log.Printf("[DEBUG] First class disk %q created (ID: %q)", spec.Name, obj.Config.Id.Id)
+	return &obj, nil
+}
+
+// Rename renames a first class disk.
+func Rename(client *govmomi.Client, id string, ds *object.Datastore, name string) error {
+	log.Printf("[DEBUG] Renaming first class disk %q to %q", id, name)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.RenameVStorageObject{
+		This:      manager(client),
+		Id:        types.ID{Id: id},
+		Datastore: ds.Reference(),
+		Name:      name,
+	}
+	_, err := methods.RenameVStorageObject(ctx, client.Client, &req)
+	return err
+}
+
+// Extend grows a first class disk to capacityInMB.
+func Extend(client *govmomi.Client, id string, ds *object.Datastore, capacityInMB int64) error {
+	log.Printf("[DEBUG] Extending first class disk %q to %d MiB", id, capacityInMB)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.ExtendDisk_Task{
+		This:            manager(client),
+		Id:              types.ID{Id: id},
+		Datastore:       ds.Reference(),
+		NewCapacityInMB: capacityInMB,
+	}
+	res, err := methods.ExtendDisk_Task(ctx, client.Client, &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(client.Client, res.Returnval).Wait(tctx)
+}
+
+// UpdatePolicy sets the storage policy of a first class disk to the policy
+// with the supplied profile ID.
+func UpdatePolicy(client *govmomi.Client, id string, ds *object.Datastore, profileID string) error {
+	log.Printf("[DEBUG] Setting storage policy of first class disk %q to %q", id, profileID)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.UpdateVStorageObjectPolicy_Task{
+		This:      manager(client),
+		Id:        types.ID{Id: id},
+		Datastore: ds.Reference(),
+		Profile:   ProfileSpec(profileID),
+	}
+	res, err := methods.UpdateVStorageObjectPolicy_Task(ctx, client.Client, &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(client.Client, res.Returnval).Wait(tctx)
+}
+
+// Delete deletes a first class disk.
+func Delete(client *govmomi.Client, id string, ds *object.Datastore) error {
+	log.Printf("[DEBUG] Deleting first class disk %q", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.DeleteVStorageObject_Task{
+		This:      manager(client),
+		Id:        types.ID{Id: id},
+		Datastore: ds.Reference(),
+	}
+	res, err := methods.DeleteVStorageObject_Task(ctx, client.Client, &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(client.Client, res.Returnval).Wait(tctx)
+}
+
+// Attach attaches a first class disk to a virtual machine, at the supplied
+// controller key and unit number.
+func Attach(vm *object.VirtualMachine, id string, ds *object.Datastore, controllerKey, unitNumber int32) error {
+	log.Printf("[DEBUG] Attaching first class disk %q to virtual machine %q", id, vm.InventoryPath)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.AttachDisk_Task{
+		This:          vm.Reference(),
+		DiskId:        types.ID{Id: id},
+		Datastore:     ds.Reference(),
+		ControllerKey: controllerKey,
+		UnitNumber:    &unitNumber,
+	}
+	res, err := methods.AttachDisk_Task(ctx, vm.Client(), &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(vm.Client(), res.Returnval).Wait(tctx)
+}
+
+// Detach detaches a first class disk from a virtual machine. The disk is not
+// deleted.
+func Detach(vm *object.VirtualMachine, id string) error {
+	log.Printf("[DEBUG] Detaching first class disk %q from virtual machine %q", id, vm.InventoryPath)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	req := types.DetachDisk_Task{
+		This:   vm.Reference(),
+		DiskId: types.ID{Id: id},
+	}
+	res, err := methods.DetachDisk_Task(ctx, vm.Client(), &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(vm.Client(), res.Returnval).Wait(tctx)
+}
+
+// ProfileSpec returns the profile spec for the storage policy with the
+// supplied profile ID, or nil if profileID is empty.
+func ProfileSpec(profileID string) []types.BaseVirtualMachineProfileSpec {
+	if profileID == "" {
+		return nil
+	}
+	return []types.BaseVirtualMachineProfileSpec{
+		&types.VirtualMachineDefinedProfileSpec{ProfileId: profileID},
+	}
+}
@@ -0,0 +1,280 @@
+package hostprofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Reference returns a ManagedObjectReference for the host profile with the
+// supplied managed object ID.
+func Reference(id string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  "HostProfile",
+		Value: id,
+	}
+}
+
+// Properties fetches the HostProfile MO for the supplied host profile managed
+// object ID.
+func Properties(client *govmomi.Client, id string) (*mo.HostProfile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.HostProfile
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, Reference(id), nil, &props); err != nil {
+		return nil, err
+	}
+	return &props, nil
+}
+
+// Create extracts a new host profile from the supplied reference host. The
+// managed object ID of the new profile is returned.
+func Create(client *govmomi.Client, name, description string, host *object.HostSystem) (string, error) {
+	log.Printf("[DEBUG] Creating host profile %q from host %q", name, host.Name())
+	m, err := hostProfileManager(client)
+	if err != nil {
+		return "", err
+	}
+
+	req := types.CreateProfile{
+		This:       m,
+		CreateSpec: hostBasedConfigSpec(name, description, host),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.CreateProfile(ctx, client.Client, &req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Returnval.Value, nil
+}
+
+// Update re-extracts the host profile from the supplied reference host and
+// updates its name and description.
+func Update(client *govmomi.Client, id, name, description string, host *object.HostSystem) error {
+	log.Printf("[DEBUG] Updating host profile %q from host %q", id, host.Name())
+	ref := host.Reference()
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	if _, err := methods.UpdateReferenceHost(ctx, client.Client, &types.UpdateReferenceHost{
+		This: Reference(id),
+		Host: &ref,
+	}); err != nil {
+		return fmt.Errorf("error updating reference host: %s", err)
+	}
+
+	_, err := methods.UpdateHostProfile(ctx, client.Client, &types.UpdateHostProfile{
+		This:   Reference(id),
+		Config: hostBasedConfigSpec(name, description, host),
+	})
+	return err
+}
+
+// UpdateNameAndDescription updates the name and description of the host
+// profile without re-extracting it from its reference host, so that any
+// changes made to the profile itself are kept.
+func UpdateNameAndDescription(client *govmomi.Client, id, name, description string) error {
+	log.Printf("[DEBUG] Updating name and description of host profile %q", id)
+	enabled := true
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.UpdateHostProfile(ctx, client.Client, &types.UpdateHostProfile{
+		This: Reference(id),
+		Config: &types.HostProfileCompleteConfigSpec{
+			HostProfileConfigSpec: types.HostProfileConfigSpec{
+				ProfileCreateSpec: types.ProfileCreateSpec{
+					Name:       name,
+					Annotation: description,
+					Enabled:    &enabled,
+				},
+			},
+		},
+	})
+	return err
+}
+
+// Delete destroys the host profile. Any entities still associated with the
+// profile are dissociated from it.
+func Delete(client *govmomi.Client, id string) error {
+	log.Printf("[DEBUG] Deleting host profile %q", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.DestroyProfile(ctx, client.Client, &types.DestroyProfile{
+		This: Reference(id),
+	})
+	return err
+}
+
+// Associate attaches the host profile to the supplied entities.
+func Associate(client *govmomi.Client, id string, entities []types.ManagedObjectReference) error {
+	if len(entities) < 1 {
+		return nil
+	}
+	log.Printf("[DEBUG] Attaching host profile %q to %d entities", id, len(entities))
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.AssociateProfile(ctx, client.Client, &types.AssociateProfile{
+		This:   Reference(id),
+		Entity: entities,
+	})
+	return err
+}
+
+// Dissociate detaches the host profile from the supplied entities.
+func Dissociate(client *govmomi.Client, id string, entities []types.ManagedObjectReference) error {
+	if len(entities) < 1 {
+		return nil
+	}
+	log.Printf("[DEBUG] Detaching host profile %q from %d entities", id, len(entities))
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.DissociateProfile(ctx, client.Client, &types.DissociateProfile{
+		This:   Reference(id),
+		Entity: entities,
+	})
+	return err
+}
+
+// CheckCompliance runs a compliance check of the host profile against the
+// supplied entities and returns the results.
+func CheckCompliance(client *govmomi.Client, id string, entities []types.ManagedObjectReference) ([]types.ComplianceResult, error) {
+	if len(entities) < 1 {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Checking compliance of %d entities against host profile %q", len(entities), id)
+	m, err := complianceManager(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.CheckCompliance_Task(ctx, client.Client, &types.CheckCompliance_Task{
+		This:    m,
+		Profile: []types.ManagedObjectReference{Reference(id)},
+		Entity:  entities,
+	})
+	if err != nil {
+		return nil, err
+	}
+	task := object.NewTask(client.Client, resp.Returnval)
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if results, ok := info.Result.(types.ArrayOfComplianceResult); ok {
+		return results.ComplianceResult, nil
+	}
+	return nil, nil
+}
+
+// QueryComplianceStatus returns the last known compliance results of the
+// supplied entities against the host profile, without running a new check.
+func QueryComplianceStatus(client *govmomi.Client, id string, entities []types.ManagedObjectReference) ([]types.ComplianceResult, error) {
+	if len(entities) < 1 {
+		return nil, nil
+	}
+	m, err := complianceManager(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.QueryComplianceStatus(ctx, client.Client, &types.QueryComplianceStatus{
+		This:    m,
+		Profile: []types.ManagedObjectReference{Reference(id)},
+		Entity:  entities,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Returnval, nil
+}
+
+// Remediate applies the configuration in the host profile to the supplied
+// host. The host profile engine computes the configuration necessary to bring
+// the host into compliance, which is then applied to the host. Hosts
+// generally need to be in maintenance mode for this to succeed.
+func Remediate(client *govmomi.Client, id string, host *object.HostSystem) error {
+	log.Printf("[DEBUG] Remediating host %q against host profile %q", host.Name(), id)
+	m, err := hostProfileManager(client)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.ExecuteHostProfile(ctx, client.Client, &types.ExecuteHostProfile{
+		This: Reference(id),
+		Host: host.Reference(),
+	})
+	if err != nil {
+		return err
+	}
+	result := resp.Returnval.GetProfileExecuteResult()
+	if result.Status != "success" {
+		if len(result.RequireInput) > 0 {
+			return fmt.Errorf("host %q requires customization input that cannot be supplied through Terraform", host.Name())
+		}
+		if len(result.Error) > 0 {
+			return fmt.Errorf("error computing configuration for host %q: %s", host.Name(), result.Error[0].Message.Message)
+		}
+		return fmt.Errorf("could not compute configuration for host %q: status %q", host.Name(), result.Status)
+	}
+	if result.ConfigSpec == nil {
+		log.Printf("[DEBUG] No configuration changes necessary for host %q", host.Name())
+		return nil
+	}
+
+	applyResp, err := methods.ApplyHostConfig_Task(ctx, client.Client, &types.ApplyHostConfig_Task{
+		This:       m,
+		Host:       host.Reference(),
+		ConfigSpec: *result.ConfigSpec,
+	})
+	if err != nil {
+		return err
+	}
+	task := object.NewTask(client.Client, applyResp.Returnval)
+	return task.Wait(ctx)
+}
+
+func hostBasedConfigSpec(name, description string, host *object.HostSystem) *types.HostProfileHostBasedConfigSpec {
+	enabled := true
+	return &types.HostProfileHostBasedConfigSpec{
+		HostProfileConfigSpec: types.HostProfileConfigSpec{
+			ProfileCreateSpec: types.ProfileCreateSpec{
+				Name:       name,
+				Annotation: description,
+				Enabled:    &enabled,
+			},
+		},
+		Host:                 host.Reference(),
+		UseHostProfileEngine: &enabled,
+	}
+}
+
+func hostProfileManager(client *govmomi.Client) (types.ManagedObjectReference, error) {
+	if client.ServiceContent.HostProfileManager == nil {
+		return types.ManagedObjectReference{}, errors.New("host profiles are not supported on this connection")
+	}
+	return *client.ServiceContent.HostProfileManager, nil
+}
+
+func complianceManager(client *govmomi.Client) (types.ManagedObjectReference, error) {
+	if client.ServiceContent.ComplianceManager == nil {
+		return types.ManagedObjectReference{}, errors.New("profile compliance is not supported on this connection")
+	}
+	return *client.ServiceContent.ComplianceManager, nil
+}
@@ -0,0 +1,86 @@
+package scheduledtask
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// VerifySupport checks to make sure that the connected endpoint supports
+// scheduled tasks. Scheduled tasks are only supported on vCenter.
+func VerifySupport(client *govmomi.Client) error {
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return errors.New("scheduled tasks are only supported on vCenter")
+	}
+	return nil
+}
+
+// Reference returns a ManagedObjectReference for the scheduled task with the
+// supplied managed object ID.
+func Reference(id string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  "ScheduledTask",
+		Value: id,
+	}
+}
+
+// Properties fetches the ScheduledTask MO for the supplied scheduled task
+// managed object ID.
+func Properties(client *govmomi.Client, id string) (*mo.ScheduledTask, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	var props mo.ScheduledTask
+	pc := property.DefaultCollector(client.Client)
+	if err := pc.RetrieveOne(ctx, Reference(id), nil, &props); err != nil {
+		return nil, err
+	}
+	return &props, nil
+}
+
+// Create creates a new scheduled task on the supplied entity. The managed
+// object ID of the new task is returned.
+func Create(client *govmomi.Client, entity types.ManagedObjectReference, spec *types.ScheduledTaskSpec) (string, error) {
+	log.Printf("[DEBUG] Creating scheduled task %q on %s %q", spec.Name, entity.Type, entity.Value)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	resp, err := methods.CreateScheduledTask(ctx, client.Client, &types.CreateScheduledTask{
+		This:   *client.ServiceContent.ScheduledTaskManager,
+		Entity: entity,
+		Spec:   spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Returnval.Value, nil
+}
+
+// Reconfigure replaces the definition of an existing scheduled task.
+func Reconfigure(client *govmomi.Client, id string, spec *types.ScheduledTaskSpec) error {
+	log.Printf("[DEBUG] Reconfiguring scheduled task %q", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.ReconfigureScheduledTask(ctx, client.Client, &types.ReconfigureScheduledTask{
+		This: Reference(id),
+		Spec: spec,
+	})
+	return err
+}
+
+// Remove removes a scheduled task.
+func Remove(client *govmomi.Client, id string) error {
+	log.Printf("[DEBUG] Removing scheduled task %q", id)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	_, err := methods.RemoveScheduledTask(ctx, client.Client, &types.RemoveScheduledTask{
+		This: Reference(id),
+	})
+	return err
+}
@@ -97,6 +97,17 @@ func IsResourceInUseError(err error) bool {
 	return false
 }
 
+// IsFileAlreadyExistsError checks an error to see if it's of the
+// FileAlreadyExists type.
+func IsFileAlreadyExistsError(err error) bool {
+	if f, ok := vimSoapFault(err); ok {
+		if _, ok := f.(types.FileAlreadyExists); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // isConcurrentAccessError checks an error to see if it's of the
 // ConcurrentAccess type.
 func isConcurrentAccessError(err error) bool {
@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"strings"
 
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datacenter"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
@@ -143,3 +148,139 @@ func Delete(client *govmomi.Client, name string, dc *object.Datacenter) error {
 	log.Printf("[DEBUG] Virtual disk %q in datacenter %s deleted succesfully", name, dc)
 	return nil
 }
+
+// Extend grows the virtual disk at the specified datastore path to
+// capacityKb. If eagerZero is true, the added space is zeroed out.
+func Extend(client *govmomi.Client, name string, dc *object.Datacenter, capacityKb int64, eagerZero bool) error {
+	log.Printf("[DEBUG] Extending virtual disk %q in datacenter %s to %d KiB", name, dc, capacityKb)
+	req := types.ExtendVirtualDisk_Task{
+		This:          *client.ServiceContent.VirtualDiskManager,
+		Name:          name,
+		NewCapacityKb: capacityKb,
+		EagerZero:     &eagerZero,
+	}
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	res, err := methods.ExtendVirtualDisk_Task(ctx, client.Client, &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(client.Client, res.Returnval).Wait(tctx)
+}
+
+// Inflate inflates the thin-provisioned virtual disk at the specified
+// datastore path to an eager-zeroed thick disk.
+func Inflate(client *govmomi.Client, name string, dc *object.Datacenter) error {
+	log.Printf("[DEBUG] Inflating virtual disk %q in datacenter %s", name, dc)
+	vdm := object.NewVirtualDiskManager(client.Client)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	task, err := vdm.InflateVirtualDisk(ctx, name, dc)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return task.Wait(tctx)
+}
+
+// EagerZero zeroes out the lazily-zeroed thick virtual disk at the specified
+// datastore path, making it an eager-zeroed thick disk.
+func EagerZero(client *govmomi.Client, name string, dc *object.Datacenter) error {
+	log.Printf("[DEBUG] Eagerly zeroing virtual disk %q in datacenter %s", name, dc)
+	req := types.EagerZeroVirtualDisk_Task{
+		This: *client.ServiceContent.VirtualDiskManager,
+		Name: name,
+	}
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	res, err := methods.EagerZeroVirtualDisk_Task(ctx, client.Client, &req)
+	if err != nil {
+		return err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	return object.NewTask(client.Client, res.Returnval).Wait(tctx)
+}
+
+// QueryUUID queries the UUID of the specified virtual disk. The UUID is
+// returned in the same format as the UUID in the backing of a virtual disk
+// device (ie: 6000C29a-1b2c-3d4e-5f60-718293a4b5c6), so that the two can be
+// compared.
+func QueryUUID(client *govmomi.Client, name string, dc *object.Datacenter) (string, error) {
+	vdm := object.NewVirtualDiskManager(client.Client)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	uuid, err := vdm.QueryVirtualDiskUuid(ctx, name, dc)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[DEBUG] QueryUUID: Disk %q has UUID %q", name, uuid)
+	return backingUUIDFromDiskManagerUUID(uuid)
+}
+
+// FromUUID searches the supplied datastore for the virtual disk with the
+// supplied UUID, and returns its datastore path. The UUID should be in the
+// format used in virtual disk backings, as returned by QueryUUID.
+//
+// The virtual disk manager cannot look up disks by UUID, so the UUID of every
+// virtual disk on the datastore is queried until a match is found. Disks whose
+// UUID cannot be queried, such as disks locked by a running virtual machine,
+// are skipped.
+func FromUUID(client *govmomi.Client, ds *object.Datastore, uuid string) (string, error) {
+	dcPath, err := folder.RootPathParticleDatastore.SplitDatacenter(ds.InventoryPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine datacenter for datastore %q: %s", ds.Name(), err)
+	}
+	dc, err := datacenter.FromPath(client, dcPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot locate datacenter %q: %s", dcPath, err)
+	}
+
+	spec := &types.HostDatastoreBrowserSearchSpec{
+		Query:        []types.BaseFileQuery{&types.VmDiskFileQuery{}},
+		MatchPattern: []string{"*.vmdk"},
+	}
+	results, err := datastore.SearchDatastoreSubFolders(ds, "", spec)
+	if err != nil {
+		return "", fmt.Errorf("error searching datastore %q for virtual disks: %s", ds.Name(), err)
+	}
+	for _, result := range results {
+		var dir object.DatastorePath
+		dir.FromString(result.FolderPath)
+		for _, file := range result.File {
+			p := ds.Path(path.Join(dir.Path, file.GetFileInfo().Path))
+			u, err := QueryUUID(client, p, dc)
+			if err != nil {
+				log.Printf("[DEBUG] FromUUID: Skipping disk %q: %s", p, err)
+				continue
+			}
+			if strings.EqualFold(u, uuid) {
+				log.Printf("[DEBUG] FromUUID: Found disk with UUID %q at %q", uuid, p)
+				return p, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no virtual disk with UUID %q found on datastore %q", uuid, ds.Name())
+}
+
+// backingUUIDFromDiskManagerUUID converts a UUID as returned by the virtual
+// disk manager (ie: "60 00 C2 9a 1b 2c 3d 4e-5f 60 71 82 93 a4 b5 c6") to the
+// format used in virtual disk backings.
+func backingUUIDFromDiskManagerUUID(s string) (string, error) {
+	h := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	if len(h) != 32 {
+		return "", fmt.Errorf("unexpected virtual disk UUID format %q", s)
+	}
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]), nil
+}
@@ -122,3 +122,46 @@ func TestDstDataStorePathFromLocalSrc(t *testing.T) {
 		})
 	}
 }
+
+func TestBackingUUIDFromDiskManagerUUID(t *testing.T) {
+	cases := []struct {
+		name     string
+		uuid     string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "disk manager format",
+			uuid:     "60 00 C2 9a 1b 2c 3d 4e-5f 60 71 82 93 a4 b5 c6",
+			expected: "6000C29a-1b2c-3d4e-5f60-718293a4b5c6",
+		},
+		{
+			name:     "already in backing format",
+			uuid:     "6000C29a-1b2c-3d4e-5f60-718293a4b5c6",
+			expected: "6000C29a-1b2c-3d4e-5f60-718293a4b5c6",
+		},
+		{
+			name: "too short",
+			uuid: "60 00 C2 9a",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := backingUUIDFromDiskManagerUUID(tc.uuid)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bad: %s", err)
+			}
+			if tc.expected != actual {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
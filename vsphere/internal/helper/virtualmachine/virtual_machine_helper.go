@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
@@ -32,6 +33,19 @@ var vmUUIDSearchIndexVersion = viapi.VSphereVersion{
 	Minor: 5,
 }
 
+// UUIDQuestionAnswerMoved and UUIDQuestionAnswerCopied are the answers that
+// can be given to the question vSphere asks when a virtual machine registered
+// from a different location is powered on. Answering moved keeps the UUID of
+// the virtual machine, answering copied generates a new one.
+const (
+	UUIDQuestionAnswerMoved  = "moved"
+	UUIDQuestionAnswerCopied = "copied"
+)
+
+// questionPollInterval is the interval at which the question pending on a
+// virtual machine is checked for while it is being powered on.
+const questionPollInterval = time.Second * 2
+
 // UUIDNotFoundError is an error type that is returned when a
 // virtual machine could not be found by UUID.
 type UUIDNotFoundError struct {
@@ -489,6 +503,28 @@ func Clone(c *govmomi.Client, src *object.VirtualMachine, f *object.Folder, name
 	return FromMOID(c, result.Result.(types.ManagedObjectReference).Value)
 }
 
+// Register wraps the registration of an existing virtual machine
+// configuration file and the subsequent waiting of the task. The path is a
+// full datastore path to the .vmx file. A higher-level virtual machine object
+// is returned.
+func Register(c *govmomi.Client, f *object.Folder, path, name string, p *object.ResourcePool, h *object.HostSystem) (*object.VirtualMachine, error) {
+	log.Printf("[DEBUG] Registering virtual machine %q from %q", fmt.Sprintf("%s/%s", f.InventoryPath, name), path)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	task, err := f.RegisterVM(ctx, path, name, false, p, h)
+	if err != nil {
+		return nil, err
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer tcancel()
+	result, err := task.WaitForResult(tctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Virtual machine %q: registration complete (MOID: %q)", fmt.Sprintf("%s/%s", f.InventoryPath, name), result.Result.(types.ManagedObjectReference).Value)
+	return FromMOID(c, result.Result.(types.ManagedObjectReference).Value)
+}
+
 // Customize wraps the customization of a virtual machine and the subsequent
 // waiting of the task.
 func Customize(vm *object.VirtualMachine, spec types.CustomizationSpec) error {
@@ -518,6 +554,69 @@ func PowerOn(vm *object.VirtualMachine) error {
 	return task.Wait(tctx)
 }
 
+// PowerOnAnswerUUIDQuestion powers on a VM and waits for the task, answering
+// the question of whether the virtual machine was moved or copied if it is
+// asked. answer is one of UUIDQuestionAnswerMoved or UUIDQuestionAnswerCopied.
+//
+// Any other question blocks the power on, so an error containing the question
+// is returned if one is encountered.
+func PowerOnAnswerUUIDQuestion(vm *object.VirtualMachine, answer string) error {
+	log.Printf("[DEBUG] Powering on virtual machine %q", vm.InventoryPath)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	task, err := vm.PowerOn(ctx)
+	if err != nil {
+		return err
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- task.Wait(ctx)
+	}()
+
+	var answered string
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case <-time.After(questionPollInterval):
+		}
+		var props mo.VirtualMachine
+		if err := vm.Properties(ctx, vm.Reference(), []string{"runtime.question"}, &props); err != nil {
+			return err
+		}
+		q := props.Runtime.Question
+		// The question can stay visible for a short period after it has been
+		// answered, so make sure we only answer it once.
+		if q == nil || q.Id == answered {
+			continue
+		}
+		choice, ok := uuidQuestionChoice(q, answer)
+		if !ok {
+			return fmt.Errorf("virtual machine is waiting for an answer to an unexpected question: %s", q.Text)
+		}
+		log.Printf("[DEBUG] Answering question %q on virtual machine %q with %q", q.Id, vm.InventoryPath, answer)
+		if err := vm.Answer(ctx, q.Id, choice); err != nil {
+			return fmt.Errorf("error answering question on virtual machine: %s", err)
+		}
+		answered = q.Id
+	}
+}
+
+// uuidQuestionChoice returns the key of the choice in a moved or copied
+// question that matches answer. The labels of the choices differ between
+// versions of vSphere (ie: "I _Moved It" or "button.uuid.movedTheVM"), so they
+// are matched loosely.
+func uuidQuestionChoice(q *types.VirtualMachineQuestionInfo, answer string) (string, bool) {
+	for _, bed := range q.Choice.ChoiceInfo {
+		ed := bed.GetElementDescription()
+		label := strings.ToLower(strings.Replace(ed.Label, "_", "", -1))
+		if strings.Contains(label, answer) {
+			return ed.Key, true
+		}
+	}
+	return "", false
+}
+
 // PowerOff wraps powering off a VM and the waiting for the subsequent task.
 func PowerOff(vm *object.VirtualMachine) error {
 	log.Printf("[DEBUG] Forcing power off of virtual machine of %q", vm.InventoryPath)
@@ -668,6 +767,15 @@ func Destroy(vm *object.VirtualMachine) error {
 	return task.Wait(tctx)
 }
 
+// Unregister removes a virtual machine from inventory without deleting any of
+// its files.
+func Unregister(vm *object.VirtualMachine) error {
+	log.Printf("[DEBUG] Unregistering virtual machine %q", vm.InventoryPath)
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	return vm.Unregister(ctx)
+}
+
 // MOIDForUUIDResult is a struct that holds a virtual machine UUID -> MOID
 // association, designed to be used as a helper for mass returning the results
 // of translating multiple UUIDs to managed object IDs for various virtual
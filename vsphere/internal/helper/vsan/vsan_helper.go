@@ -0,0 +1,167 @@
+// Package vsan contains a minimal client for the vSAN management API, which
+// is served from a separate endpoint on vCenter and is not part of the vim25
+// SDK. Only the calls and types necessary for the provider are implemented.
+package vsan
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/provider"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	// Namespace is the SOAP namespace of the vSAN management API.
+	Namespace = "vsan"
+
+	// Path is the path to the vSAN management API endpoint on vCenter.
+	Path = "/vsanHealth"
+)
+
+// vcClusterConfigSystem is the well-known reference to the
+// VsanVcClusterConfigSystem managed object on vCenter.
+var vcClusterConfigSystem = types.ManagedObjectReference{
+	Type:  "VsanVcClusterConfigSystem",
+	Value: "vsan-cluster-config-system",
+}
+
+// DataEfficiencyConfig represents the VsanDataEfficiencyConfig data object,
+// which controls deduplication and compression on a vSAN cluster.
+type DataEfficiencyConfig struct {
+	types.DynamicData
+
+	DedupEnabled       bool  `xml:"dedupEnabled"`
+	CompressionEnabled *bool `xml:"compressionEnabled"`
+}
+
+// DataEncryptionConfig represents the VsanDataEncryptionConfig data object,
+// which controls encryption at rest on a vSAN cluster.
+type DataEncryptionConfig struct {
+	types.DynamicData
+
+	EncryptionEnabled   bool                 `xml:"encryptionEnabled"`
+	KmsProviderID       *types.KeyProviderId `xml:"kmsProviderId,omitempty"`
+	EraseDisksBeforeUse *bool                `xml:"eraseDisksBeforeUse"`
+}
+
+// ReconfigSpec represents the VimVsanReconfigSpec data object. Only the parts
+// of the spec used by the provider are included.
+type ReconfigSpec struct {
+	types.DynamicData
+
+	DataEfficiencyConfig *DataEfficiencyConfig `xml:"dataEfficiencyConfig,omitempty"`
+	DataEncryptionConfig *DataEncryptionConfig `xml:"dataEncryptionConfig,omitempty"`
+	Modify               bool                  `xml:"modify"`
+}
+
+// ConfigInfoEx represents the VsanConfigInfoEx data object. Only the parts of
+// the object used by the provider are included.
+type ConfigInfoEx struct {
+	types.VsanClusterConfigInfo
+
+	DataEfficiencyConfig *DataEfficiencyConfig `xml:"dataEfficiencyConfig,omitempty"`
+	DataEncryptionConfig *DataEncryptionConfig `xml:"dataEncryptionConfig,omitempty"`
+}
+
+type clusterGetConfigRequest struct {
+	This    types.ManagedObjectReference `xml:"_this"`
+	Cluster types.ManagedObjectReference `xml:"cluster"`
+}
+
+type clusterGetConfigResponse struct {
+	Returnval *ConfigInfoEx `xml:"returnval,omitempty"`
+}
+
+type clusterGetConfigBody struct {
+	Req    *clusterGetConfigRequest  `xml:"urn:vsan VsanClusterGetConfig,omitempty"`
+	Res    *clusterGetConfigResponse `xml:"urn:vsan VsanClusterGetConfigResponse,omitempty"`
+	Fault_ *soap.Fault               `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty"`
+}
+
+func (b *clusterGetConfigBody) Fault() *soap.Fault { return b.Fault_ }
+
+type clusterReconfigRequest struct {
+	This             types.ManagedObjectReference `xml:"_this"`
+	Cluster          types.ManagedObjectReference `xml:"cluster"`
+	VsanReconfigSpec ReconfigSpec                 `xml:"vsanReconfigSpec"`
+}
+
+type clusterReconfigResponse struct {
+	Returnval types.ManagedObjectReference `xml:"returnval"`
+}
+
+type clusterReconfigBody struct {
+	Req    *clusterReconfigRequest  `xml:"urn:vsan VsanClusterReconfig,omitempty"`
+	Res    *clusterReconfigResponse `xml:"urn:vsan VsanClusterReconfigResponse,omitempty"`
+	Fault_ *soap.Fault              `xml:"http://schemas.xmlsoap.org/soap/envelope/ Fault,omitempty"`
+}
+
+func (b *clusterReconfigBody) Fault() *soap.Fault { return b.Fault_ }
+
+func init() {
+	types.Add("vsan:VsanDataEfficiencyConfig", reflect.TypeOf((*DataEfficiencyConfig)(nil)).Elem())
+	types.Add("vsan:VsanDataEncryptionConfig", reflect.TypeOf((*DataEncryptionConfig)(nil)).Elem())
+	types.Add("vsan:VimVsanReconfigSpec", reflect.TypeOf((*ReconfigSpec)(nil)).Elem())
+	types.Add("vsan:VsanConfigInfoEx", reflect.TypeOf((*ConfigInfoEx)(nil)).Elem())
+}
+
+// newServiceClient returns a SOAP client for the vSAN management endpoint,
+// sharing the session of the supplied vim25 client.
+func newServiceClient(client *govmomi.Client) *soap.Client {
+	sc := client.Client.Client.NewServiceClient(Path, Namespace)
+	sc.Version = client.Client.Client.Version
+	return sc
+}
+
+// GetConfig returns the extended vSAN configuration for the supplied cluster.
+func GetConfig(client *govmomi.Client, cluster *object.ClusterComputeResource) (*ConfigInfoEx, error) {
+	req := clusterGetConfigRequest{
+		This:    vcClusterConfigSystem,
+		Cluster: cluster.Reference(),
+	}
+	var reqBody, resBody clusterGetConfigBody
+	reqBody.Req = &req
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	if err := newServiceClient(client).RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return nil, err
+	}
+	if resBody.Res == nil || resBody.Res.Returnval == nil {
+		return nil, fmt.Errorf("no vSAN configuration returned for cluster %q", cluster.Name())
+	}
+	return resBody.Res.Returnval, nil
+}
+
+// Reconfigure sends the supplied ReconfigSpec to the vSAN management API for
+// the supplied cluster and waits for the resulting task to complete.
+func Reconfigure(client *govmomi.Client, cluster *object.ClusterComputeResource, spec ReconfigSpec) error {
+	log.Printf("[DEBUG] Reconfiguring vSAN on cluster %q", cluster.Name())
+	req := clusterReconfigRequest{
+		This:             vcClusterConfigSystem,
+		Cluster:          cluster.Reference(),
+		VsanReconfigSpec: spec,
+	}
+	var reqBody, resBody clusterReconfigBody
+	reqBody.Req = &req
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.DefaultAPITimeout)
+	defer cancel()
+	if err := newServiceClient(client).RoundTrip(ctx, &reqBody, &resBody); err != nil {
+		return err
+	}
+	if resBody.Res == nil {
+		return fmt.Errorf("no task returned when reconfiguring vSAN on cluster %q", cluster.Name())
+	}
+
+	// The task returned by the vSAN endpoint is a regular vim25 task, and can
+	// be waited on through the main client.
+	task := object.NewTask(client.Client, resBody.Res.Returnval)
+	return task.Wait(ctx)
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/mitchellh/copystructure"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/firstclassdisk"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/storagepod"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualdisk"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/types"
@@ -214,6 +216,16 @@ func DiskSubresourceSchema() map[string]*schema.Schema {
 			ConflictsWith: []string{"datastore_cluster_id"},
 			Description:   "If this is true, the disk is attached instead of created. Implies keep_on_remove.",
 		},
+		"fcd_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The ID of a first class disk to attach. Requires attach to be set, and datastore_id to be set to the datastore of the first class disk.",
+		},
+		"attach_uuid": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The UUID of an existing virtual disk to attach, such as the uuid attribute of a vsphere_virtual_disk resource. Requires attach to be set, and datastore_id to be set to the datastore of the virtual disk.",
+		},
 	}
 	structure.MergeSchema(s, subresourceSchema())
 	return s
@@ -325,6 +337,10 @@ func diskApplyOperationDelete(
 		return nil
 	}
 	r := NewDiskSubresource(c, d, oldData, nil, index)
+	if r.isFirstClassDisk() {
+		// First class disks are detached in DiskFCDDetachOperation.
+		return nil
+	}
 	dspec, err := r.Delete(*l)
 	if err != nil {
 		return fmt.Errorf("%s: %s", r.Addr(), err)
@@ -535,6 +551,10 @@ func DiskDestroyOperation(d *schema.ResourceData, c *govmomi.Client, l object.Vi
 			continue
 		}
 		r := NewDiskSubresource(c, d, m, nil, oi)
+		if r.isFirstClassDisk() {
+			// First class disks are detached in DiskFCDDestroyOperation.
+			continue
+		}
 		dspec, err := r.Delete(l)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %s", r.Addr(), err)
@@ -580,11 +600,18 @@ func DiskDiffOperation(d *schema.ResourceDiff, c *govmomi.Client) error {
 		if _, ok := names[name]; ok {
 			return fmt.Errorf("disk: duplicate name %s", name)
 		}
-		// If attach is set, we need to validate that there's no other duplicate paths.
+		// If attach is set, we need to validate that there's no other duplicate
+		// paths. First class disks are tracked by their ID.
 		if nm["attach"].(bool) {
 			path := diskPathOrName(nm)
+			if id, ok := nm["fcd_id"].(string); ok && id != "" {
+				path = id
+			}
+			if uuid, ok := nm["attach_uuid"].(string); ok && uuid != "" {
+				path = uuid
+			}
 			if path == "" {
-				return fmt.Errorf("disk.%d: path or name cannot be empty when using attach", ni)
+				return fmt.Errorf("disk.%d: path, name, fcd_id, or attach_uuid must be set when using attach", ni)
 			}
 			if _, ok := attachments[path]; ok {
 				return fmt.Errorf("disk: multiple entries trying to attach external disk %s", path)
@@ -950,6 +977,12 @@ func DiskPostCloneOperation(d *schema.ResourceData, c *govmomi.Client, l object.
 	sort.Sort(virtualDiskSubresourceSorter(curSet))
 	log.Printf("[DEBUG] DiskPostCloneOperation: Resource set order after sort: %s", subresourceListString(curSet))
 
+	// This is validated during diff for clones, but not for registered virtual
+	// machines, where the disks are not known until the VM is in inventory.
+	if len(devices) > len(curSet) {
+		return nil, nil, fmt.Errorf("not enough disks in configuration - you need at least %d to use this virtual machine (current: %d)", len(devices), len(curSet))
+	}
+
 	var spec []types.BaseVirtualDeviceConfigSpec
 	var updates []interface{}
 
@@ -1147,6 +1180,138 @@ func ReadDiskAttrsForDataSource(l object.VirtualDeviceList, count int) ([]map[st
 	return out, nil
 }
 
+// DiskFCDDetachOperation detaches first class disks that have been removed
+// from configuration from the virtual machine. This needs to be run before
+// DiskApplyOperation, as the removed disks are determined from the diff, and
+// before the device changes are applied, so that the unit numbers of the
+// detached disks are free for any new devices. The supplied
+// VirtualDeviceList should be the current device list of the virtual machine.
+func DiskFCDDetachOperation(d *schema.ResourceData, vm *object.VirtualMachine, l object.VirtualDeviceList) error {
+	log.Printf("[DEBUG] DiskFCDDetachOperation: Looking for first class disks to detach")
+	attached := attachedFirstClassDisks(l)
+	o, n := d.GetChange(subresourceTypeDisk)
+	current := make(map[string]struct{})
+	for _, ne := range n.([]interface{}) {
+		nm := ne.(map[string]interface{})
+		if name, _ := diskLabelOrName(nm); name == diskDeletedName || name == diskDetachedName {
+			continue
+		}
+		if id, ok := nm["fcd_id"].(string); ok && id != "" {
+			current[id] = struct{}{}
+		}
+	}
+	for _, oe := range o.([]interface{}) {
+		id, ok := oe.(map[string]interface{})["fcd_id"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		if _, ok := current[id]; ok {
+			continue
+		}
+		if _, ok := attached[id]; !ok {
+			log.Printf("[DEBUG] DiskFCDDetachOperation: First class disk %q is already detached", id)
+			continue
+		}
+		if err := firstclassdisk.Detach(vm, id); err != nil {
+			return fmt.Errorf("error detaching first class disk %q: %s", id, err)
+		}
+	}
+	return nil
+}
+
+// DiskFCDAttachOperation attaches first class disks in configuration that are
+// not yet attached to the virtual machine. This needs to be run after the
+// device changes from DiskApplyOperation or DiskPostCloneOperation have been
+// applied, as the disks are attached with their own API call. The supplied
+// VirtualDeviceList should be the current device list of the virtual machine.
+func DiskFCDAttachOperation(d *schema.ResourceData, c *govmomi.Client, vm *object.VirtualMachine, l object.VirtualDeviceList) error {
+	log.Printf("[DEBUG] DiskFCDAttachOperation: Looking for first class disks to attach")
+	attached := attachedFirstClassDisks(l)
+	for i, e := range d.Get(subresourceTypeDisk).([]interface{}) {
+		r := NewDiskSubresource(c, d, e.(map[string]interface{}), nil, i)
+		if !r.isFirstClassDisk() {
+			continue
+		}
+		id := r.Get("fcd_id").(string)
+		if _, ok := attached[id]; ok {
+			continue
+		}
+		if err := r.attachFirstClassDisk(vm, l); err != nil {
+			return fmt.Errorf("%s: %s", r.Addr(), err)
+		}
+	}
+	return nil
+}
+
+// DiskFCDDestroyOperation detaches all first class disks from the virtual
+// machine, so that they are not destroyed with it. The supplied
+// VirtualDeviceList should be the current device list of the virtual machine.
+func DiskFCDDestroyOperation(d *schema.ResourceData, vm *object.VirtualMachine, l object.VirtualDeviceList) error {
+	log.Printf("[DEBUG] DiskFCDDestroyOperation: Detaching first class disks")
+	attached := attachedFirstClassDisks(l)
+	for _, e := range d.Get(subresourceTypeDisk).([]interface{}) {
+		id, ok := e.(map[string]interface{})["fcd_id"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		if _, ok := attached[id]; !ok {
+			continue
+		}
+		if err := firstclassdisk.Detach(vm, id); err != nil {
+			return fmt.Errorf("error detaching first class disk %q: %s", id, err)
+		}
+	}
+	return nil
+}
+
+// attachedFirstClassDisks returns the IDs of the first class disks in the
+// supplied device list.
+func attachedFirstClassDisks(l object.VirtualDeviceList) map[string]struct{} {
+	attached := make(map[string]struct{})
+	for _, device := range l.SelectByType((*types.VirtualDisk)(nil)) {
+		if id := device.(*types.VirtualDisk).VDiskId; id != nil {
+			attached[id.Id] = struct{}{}
+		}
+	}
+	return attached
+}
+
+// isFirstClassDisk returns true if the disk sub-resource is a first class
+// disk attached by its ID.
+func (r *DiskSubresource) isFirstClassDisk() bool {
+	id, ok := r.Get("fcd_id").(string)
+	return ok && id != ""
+}
+
+// attachUUID returns the UUID of the existing virtual disk that the disk
+// sub-resource attaches, or an empty string if the disk is not attached by
+// UUID.
+func (r *DiskSubresource) attachUUID() string {
+	uuid, _ := r.Get("attach_uuid").(string)
+	return uuid
+}
+
+// attachFirstClassDisk attaches the first class disk referenced by the disk
+// sub-resource to the virtual machine, on the controller and unit that
+// unit_number maps to.
+func (r *DiskSubresource) attachFirstClassDisk(vm *object.VirtualMachine, l object.VirtualDeviceList) error {
+	id := r.Get("fcd_id").(string)
+	ds, err := datastore.FromID(r.client, r.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore for first class disk %q: %s", id, err)
+	}
+	// assignDisk does the work of translating unit_number to a controller and
+	// unit, and validating that the unit is free.
+	disk := new(types.VirtualDisk)
+	if _, err := r.assignDisk(l, disk); err != nil {
+		return fmt.Errorf("cannot assign first class disk %q: %s", id, err)
+	}
+	if disk.ControllerKey < 0 {
+		return fmt.Errorf("SCSI controller for first class disk %q has not been created yet", id)
+	}
+	return firstclassdisk.Attach(vm, id, ds, disk.ControllerKey, *disk.UnitNumber)
+}
+
 // Create creates a vsphere_virtual_machine disk sub-resource.
 func (r *DiskSubresource) Create(l object.VirtualDeviceList) ([]types.BaseVirtualDeviceConfigSpec, error) {
 	log.Printf("[DEBUG] %s: Creating disk", r)
@@ -1171,6 +1336,13 @@ func (r *DiskSubresource) Create(l object.VirtualDeviceList) ([]types.BaseVirtua
 	if err := r.SaveDevIDs(disk, ctlr); err != nil {
 		return nil, err
 	}
+	// First class disks are attached in DiskFCDAttachOperation, after the
+	// device changes have been applied. The device address saved above is used
+	// to locate the disk on the next read.
+	if r.isFirstClassDisk() {
+		log.Printf("[DEBUG] %s: First class disk %q will be attached after reconfiguration", r, r.Get("fcd_id").(string))
+		return nil, nil
+	}
 	dspec, err := object.VirtualDeviceList{disk}.ConfigSpec(types.VirtualDeviceConfigSpecOperationAdd)
 	if err != nil {
 		return nil, err
@@ -1409,6 +1581,14 @@ func (r *DiskSubresource) DiffExisting() error {
 	if _, err = r.GetWithVeto("thin_provisioned"); err != nil {
 		return fmt.Errorf("virtual disk %q: %s", name, err)
 	}
+	// The first class disk backing a disk cannot be swapped in place.
+	if _, err = r.GetWithVeto("fcd_id"); err != nil {
+		return fmt.Errorf("virtual disk %q: %s", name, err)
+	}
+	// Neither can a virtual disk attached by UUID.
+	if _, err = r.GetWithVeto("attach_uuid"); err != nil {
+		return fmt.Errorf("virtual disk %q: %s", name, err)
+	}
 
 	log.Printf("[DEBUG] %s: Normalization of existing disk diff complete", r)
 	return nil
@@ -1443,7 +1623,24 @@ func (r *DiskSubresource) DiffGeneral() error {
 		case r.Get("keep_on_remove").(bool):
 			return fmt.Errorf("keep_on_remove for disk %q is implicit when attach is set, please remove this setting", name)
 		}
+		if r.isFirstClassDisk() && r.Get("path").(string) != "" {
+			return fmt.Errorf("path for disk %q cannot be defined when fcd_id is set", name)
+		}
+		if r.attachUUID() != "" {
+			switch {
+			case r.Get("path").(string) != "":
+				return fmt.Errorf("path for disk %q cannot be defined when attach_uuid is set", name)
+			case r.isFirstClassDisk():
+				return fmt.Errorf("fcd_id and attach_uuid for disk %q cannot both be defined", name)
+			}
+		}
 	} else {
+		if r.isFirstClassDisk() {
+			return fmt.Errorf("fcd_id for disk %q can only be defined when attach is set", name)
+		}
+		if r.attachUUID() != "" {
+			return fmt.Errorf("attach_uuid for disk %q can only be defined when attach is set", name)
+		}
 		// Enforce size as a required field when attach is not set
 		if r.Get("size").(float64) < 1 {
 			return fmt.Errorf("size for disk %q: required option not set", name)
@@ -1669,6 +1866,15 @@ func (r *DiskSubresource) assignBackingInfo(disk *types.VirtualDisk) error {
 	backing.FileName = ds.Path(diskName)
 	backing.Datastore = &dsref
 
+	// Disks attached by UUID are located on the datastore by their UUID.
+	if uuid := r.attachUUID(); uuid != "" && r.Get("attach").(bool) {
+		p, err := virtualdisk.FromUUID(r.client, ds, uuid)
+		if err != nil {
+			return err
+		}
+		backing.FileName = p
+	}
+
 	return nil
 }
 
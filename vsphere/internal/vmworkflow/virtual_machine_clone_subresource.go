@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/customizationspec"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/resourcepool"
@@ -104,7 +105,13 @@ func ValidateVirtualMachineClone(d *schema.ResourceDiff, c *govmomi.Client) erro
 
 	// If a customization spec was defined, we need to check some items in it as well.
 	if len(d.Get("clone.0.customize").([]interface{})) > 0 {
-		if poolID, ok := d.GetOk("resource_pool_id"); ok {
+		if name, ok := d.GetOk("clone.0.customize.0.spec_name"); ok {
+			// The identity settings come from the saved spec, so there is no need to
+			// check them against the OS family.
+			if err := ValidateCustomizationSpecName(d, c, name.(string), "clone.0."); err != nil {
+				return err
+			}
+		} else if poolID, ok := d.GetOk("resource_pool_id"); ok {
 			pool, err := resourcepool.FromID(c, poolID.(string))
 			if err != nil {
 				return fmt.Errorf("could not find resource pool ID %q: %s", poolID, err)
@@ -124,6 +131,30 @@ func ValidateVirtualMachineClone(d *schema.ResourceDiff, c *govmomi.Client) erro
 	return nil
 }
 
+// ValidateCustomizationSpecName checks that a customization specification
+// referenced by spec_name in the customize block under prefix exists, and that
+// no conflicting identity settings have been defined alongside it.
+func ValidateCustomizationSpecName(d *schema.ResourceDiff, c *govmomi.Client, name, prefix string) error {
+	for _, k := range []string{"linux_options", "windows_options", "cohesity_windows_customization_options"} {
+		if len(d.Get(prefix+"customize.0."+k).([]interface{})) > 0 {
+			return fmt.Errorf("%s cannot be used with spec_name", k)
+		}
+	}
+	if d.Get(prefix+"customize.0.windows_sysprep_text").(string) != "" {
+		return fmt.Errorf("windows_sysprep_text cannot be used with spec_name")
+	}
+	if !d.NewValueKnown(prefix + "customize.0.spec_name") {
+		return nil
+	}
+	if err := customizationspec.VerifySupport(c); err != nil {
+		return err
+	}
+	if _, err := customizationspec.FromName(c, name); err != nil {
+		return fmt.Errorf("cannot locate customization specification %q: %s", name, err)
+	}
+	return nil
+}
+
 // validateCloneSnapshots checks a VM to make sure it has a single snapshot
 // with no children, to make sure there is no ambiguity when selecting a
 // snapshot for linked clones.
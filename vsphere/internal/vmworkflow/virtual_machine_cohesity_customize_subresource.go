@@ -0,0 +1,120 @@
+package vmworkflow
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/cabinet"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+)
+
+// CohesityWindowsCustomizationFileName is the name of the file containing the
+// customization settings in a generated cabinet.
+const CohesityWindowsCustomizationFileName = "customization.json"
+
+// cohesityWindowsGeneratedKeys returns the keys in
+// cohesity_windows_customization_options that are used to generate a cabinet
+// file. These conflict with source_file.
+func cohesityWindowsGeneratedKeys() []string {
+	var keys []string
+	for _, k := range []string{
+		"computer_name",
+		"admin_password",
+		"join_domain",
+		"domain_admin_user",
+		"domain_admin_password",
+		"workgroup",
+		"run_once_command_list",
+	} {
+		keys = append(keys, cCohesityWindowsKeyPrefix+"."+k)
+	}
+	return keys
+}
+
+// CohesityWindowsCustomization contains the settings that are written to a
+// generated cohesity windows customization cabinet, and read by the guest
+// agent.
+//
+// This contains secrets - never log it.
+type CohesityWindowsCustomization struct {
+	ComputerName        string                                  `json:"computer_name,omitempty"`
+	AdminPassword       string                                  `json:"admin_password,omitempty"`
+	JoinDomain          string                                  `json:"join_domain,omitempty"`
+	DomainAdminUser     string                                  `json:"domain_admin_user,omitempty"`
+	DomainAdminPassword string                                  `json:"domain_admin_password,omitempty"`
+	Workgroup           string                                  `json:"workgroup,omitempty"`
+	DNSServerList       []string                                `json:"dns_server_list,omitempty"`
+	DNSSuffixList       []string                                `json:"dns_suffix_list,omitempty"`
+	IPv4Gateway         string                                  `json:"ipv4_gateway,omitempty"`
+	IPv6Gateway         string                                  `json:"ipv6_gateway,omitempty"`
+	NetworkInterfaces   []CohesityWindowsCustomizationInterface `json:"network_interfaces,omitempty"`
+	RunOnceCommandList  []string                                `json:"run_once_command_list,omitempty"`
+}
+
+// CohesityWindowsCustomizationInterface contains the IP settings for a single
+// network interface in a CohesityWindowsCustomization. Interfaces are in the
+// order of the network_interface blocks in the customize block, which should
+// match the order of the network interfaces of the virtual machine. An empty
+// IPv4 address means DHCP.
+type CohesityWindowsCustomizationInterface struct {
+	DNSServerList []string `json:"dns_server_list,omitempty"`
+	DNSDomain     string   `json:"dns_domain,omitempty"`
+	IPv4Address   string   `json:"ipv4_address,omitempty"`
+	IPv4Netmask   int      `json:"ipv4_netmask,omitempty"`
+	IPv6Address   string   `json:"ipv6_address,omitempty"`
+	IPv6Netmask   int      `json:"ipv6_netmask,omitempty"`
+}
+
+// ExpandCohesityWindowsCustomization reads certain ResourceData keys and
+// returns a CohesityWindowsCustomization. An error is returned if the domain
+// join settings are incomplete or are combined with a workgroup.
+func ExpandCohesityWindowsCustomization(d *schema.ResourceData, prefix string) (*CohesityWindowsCustomization, error) {
+	obj := &CohesityWindowsCustomization{
+		ComputerName:        d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "computer_name").(string),
+		AdminPassword:       d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "admin_password").(string),
+		JoinDomain:          d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "join_domain").(string),
+		DomainAdminUser:     d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "domain_admin_user").(string),
+		DomainAdminPassword: d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "domain_admin_password").(string),
+		Workgroup:           d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "workgroup").(string),
+		DNSServerList:       structure.SliceInterfacesToStrings(d.Get(prefix + cKeyPrefix + "." + "dns_server_list").([]interface{})),
+		DNSSuffixList:       structure.SliceInterfacesToStrings(d.Get(prefix + cKeyPrefix + "." + "dns_suffix_list").([]interface{})),
+		IPv4Gateway:         d.Get(prefix + cKeyPrefix + "." + "ipv4_gateway").(string),
+		IPv6Gateway:         d.Get(prefix + cKeyPrefix + "." + "ipv6_gateway").(string),
+		RunOnceCommandList:  structure.SliceInterfacesToStrings(d.Get(prefix + cCohesityWindowsKeyPrefix + "." + "run_once_command_list").([]interface{})),
+	}
+	switch {
+	case obj.JoinDomain != "" && obj.Workgroup != "":
+		return nil, errors.New("join_domain and workgroup cannot both be set")
+	case obj.JoinDomain != "" && (obj.DomainAdminUser == "" || obj.DomainAdminPassword == ""):
+		return nil, errors.New("domain_admin_user and domain_admin_password are required when join_domain is set")
+	case obj.JoinDomain == "" && (obj.DomainAdminUser != "" || obj.DomainAdminPassword != ""):
+		return nil, errors.New("domain_admin_user and domain_admin_password can only be used with join_domain")
+	}
+	for i := range d.Get(prefix + cKeyPrefix + "." + "network_interface").([]interface{}) {
+		obj.NetworkInterfaces = append(obj.NetworkInterfaces, CohesityWindowsCustomizationInterface{
+			DNSServerList: structure.SliceInterfacesToStrings(d.Get(netifKey("dns_server_list", i, prefix)).([]interface{})),
+			DNSDomain:     d.Get(netifKey("dns_domain", i, prefix)).(string),
+			IPv4Address:   d.Get(netifKey("ipv4_address", i, prefix)).(string),
+			IPv4Netmask:   d.Get(netifKey("ipv4_netmask", i, prefix)).(int),
+			IPv6Address:   d.Get(netifKey("ipv6_address", i, prefix)).(string),
+			IPv6Netmask:   d.Get(netifKey("ipv6_netmask", i, prefix)).(int),
+		})
+	}
+	return obj, nil
+}
+
+// Cabinet returns a cabinet file containing the customization settings, for
+// upload to the datastore.
+func (c *CohesityWindowsCustomization) Cabinet() ([]byte, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return cabinet.Bytes([]cabinet.File{
+		{
+			Name: CohesityWindowsCustomizationFileName,
+			Data: b,
+		},
+	})
+}
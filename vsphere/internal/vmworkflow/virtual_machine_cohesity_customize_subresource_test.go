@@ -0,0 +1,116 @@
+package vmworkflow
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func testCohesityWindowsCustomizationResourceData(t *testing.T, options map[string]interface{}) *schema.ResourceData {
+	s := map[string]*schema.Schema{
+		"customize": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: VirtualMachineCustomizeSchema()},
+		},
+	}
+	raw := map[string]interface{}{
+		"customize": []interface{}{
+			map[string]interface{}{
+				"cohesity_windows_customization_options": []interface{}{options},
+			},
+		},
+	}
+	return schema.TestResourceDataRaw(t, s, raw)
+}
+
+func TestExpandCohesityWindowsCustomization(t *testing.T) {
+	cases := []struct {
+		name     string
+		options  map[string]interface{}
+		expected *CohesityWindowsCustomization
+		err      string
+	}{
+		{
+			name: "workgroup",
+			options: map[string]interface{}{
+				"computer_name":  "terraform-test",
+				"admin_password": "VMw4re",
+				"workgroup":      "test",
+			},
+			expected: &CohesityWindowsCustomization{
+				ComputerName:  "terraform-test",
+				AdminPassword: "VMw4re",
+				Workgroup:     "test",
+			},
+		},
+		{
+			name: "join domain",
+			options: map[string]interface{}{
+				"join_domain":           "example.com",
+				"domain_admin_user":     "admin",
+				"domain_admin_password": "VMw4re",
+			},
+			expected: &CohesityWindowsCustomization{
+				JoinDomain:          "example.com",
+				DomainAdminUser:     "admin",
+				DomainAdminPassword: "VMw4re",
+			},
+		},
+		{
+			name: "join domain and workgroup",
+			options: map[string]interface{}{
+				"join_domain":           "example.com",
+				"domain_admin_user":     "admin",
+				"domain_admin_password": "VMw4re",
+				"workgroup":             "test",
+			},
+			err: "join_domain and workgroup cannot both be set",
+		},
+		{
+			name: "join domain without user",
+			options: map[string]interface{}{
+				"join_domain":           "example.com",
+				"domain_admin_password": "VMw4re",
+			},
+			err: "domain_admin_user and domain_admin_password are required when join_domain is set",
+		},
+		{
+			name: "join domain without password",
+			options: map[string]interface{}{
+				"join_domain":       "example.com",
+				"domain_admin_user": "admin",
+			},
+			err: "domain_admin_user and domain_admin_password are required when join_domain is set",
+		},
+		{
+			name: "domain password without join domain",
+			options: map[string]interface{}{
+				"workgroup":             "test",
+				"domain_admin_password": "VMw4re",
+			},
+			err: "domain_admin_user and domain_admin_password can only be used with join_domain",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := testCohesityWindowsCustomizationResourceData(t, tc.options)
+			actual, err := ExpandCohesityWindowsCustomization(d, "")
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bad: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ const (
 	cLinuxKeyPrefix   = "customize.0.linux_options.0"
 	cWindowsKeyPrefix = "customize.0.windows_options.0"
 	cNetifKeyPrefix   = "customize.0.network_interface"
+
+	cCohesityWindowsKeyPrefix = "customize.0.cohesity_windows_customization_options.0"
 )
 
 // netifKey renders a specific network_interface key for a specific resource
@@ -73,6 +75,13 @@ func VirtualMachineCustomizeSchema() map[string]*schema.Schema {
 			Elem:        &schema.Schema{Type: schema.TypeString},
 		},
 
+		// CustomizationSpecItem
+		"spec_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of a customization specification saved in vCenter to use instead of linux_options, windows_options or windows_sysprep_text. Any network_interface, dns_server_list and dns_suffix_list settings replace the ones in the saved specification.",
+		},
+
 		// CustomizationLinuxPrep
 		"linux_options": {
 			Type:          schema.TypeList,
@@ -109,16 +118,15 @@ func VirtualMachineCustomizeSchema() map[string]*schema.Schema {
 			}},
 		},
 
-		// This is the spec of resource_vsphere_file.To customize a windows machine we
-		// copy the cabinet file inside the VM. This file should be present inside the
-		// vm folder. So when the vm is created we check if there are any customization
-		// for the VM. We do not use the windows_options as it syspreps the machine and
-		// changes the identity of the machine. TODO(Mradul): Take only the source path
-		// and compute the other options from the VM resource definition.
+		// To customize a windows machine in cohesity we stage a cabinet file in
+		// the VM folder, which the guest agent picks up on boot. We do not use
+		// windows_options as it syspreps the machine and changes the identity of
+		// the machine.
 		//
-		// Note: This schema is similar to schema in resource_vsphere_file.go.
-		// source_file.ForceNew has been set to false, otherwise the existing
-		// resource is replaced with a new one.
+		// The cabinet is either copied from an existing source_file (see
+		// resource_vsphere_file.go), or generated from the structured options
+		// below together with the network_interface, dns_server_list,
+		// dns_suffix_list and gateway settings of the customize block.
 		"cohesity_windows_customization_options": {
 			Type:          schema.TypeList,
 			Optional:      true,
@@ -127,8 +135,9 @@ func VirtualMachineCustomizeSchema() map[string]*schema.Schema {
 			Description:   "Customization of windows vm in cohesity",
 			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
 				"datacenter": {
-					Type:     schema.TypeString,
-					Optional: true,
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the datacenter the cabinet file is staged in. If not set, the default datacenter is used.",
 				},
 
 				"source_datacenter": {
@@ -138,8 +147,9 @@ func VirtualMachineCustomizeSchema() map[string]*schema.Schema {
 				},
 
 				"datastore": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the datastore the cabinet file is staged in. Required when source_file is set. If not set, the datastore of the virtual machine configuration is used.",
 				},
 
 				"source_datastore": {
@@ -149,20 +159,65 @@ func VirtualMachineCustomizeSchema() map[string]*schema.Schema {
 				},
 
 				"source_file": {
-					Type:     schema.TypeString,
-					Required: true,
-					ForceNew: false,
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: cohesityWindowsGeneratedKeys(),
+					Description:   "The path to an existing cabinet file to copy. If not set, the cabinet file is generated from the customization options.",
 				},
 
 				"destination_file": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The path the cabinet file is staged at in the datastore. Required when source_file is set. If not set, the cabinet file is staged in the folder of the virtual machine.",
 				},
 
 				"create_directories": {
 					Type:     schema.TypeBool,
 					Optional: true,
 				},
+
+				"computer_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The new computer name for this virtual machine.",
+				},
+				"admin_password": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "The new administrator password for this virtual machine.",
+				},
+				"join_domain": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{cCohesityWindowsKeyPrefix + "." + "workgroup"},
+					Description:   "The domain that the virtual machine should join.",
+				},
+				"domain_admin_user": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{cCohesityWindowsKeyPrefix + "." + "workgroup"},
+					Description:   "The user account of the domain administrator used to join this virtual machine to the domain.",
+				},
+				"domain_admin_password": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{cCohesityWindowsKeyPrefix + "." + "workgroup"},
+					Description:   "The password of the domain administrator used to join this virtual machine to the domain.",
+				},
+				"workgroup": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{cCohesityWindowsKeyPrefix + "." + "join_domain"},
+					Description:   "The workgroup for this virtual machine if not joining a domain.",
+				},
+				"run_once_command_list": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "A list of commands to run once in the guest after customization.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
 			}},
 		},
 
@@ -325,6 +380,12 @@ func VirtualMachineCustomizeSchema() map[string]*schema.Schema {
 			Default:     10,
 			Description: "The amount of time, in minutes, to wait for guest OS customization to complete before returning with an error. Setting this value to 0 or a negative value skips the waiter.",
 		},
+		"reapply_on_change": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Re-apply the customization to the existing virtual machine when the customization settings change, instead of ignoring the change or re-creating the virtual machine. The virtual machine is powered off to do this, and powered back on if it was running.",
+		},
 	}
 }
 
@@ -467,7 +528,7 @@ func expandCustomizationIPSettingsIPV6AddressSpec(d *schema.ResourceData, n int,
 	}
 	addr := v.(string)
 	mask := d.Get(netifKey("ipv6_netmask", n, prefix)).(int)
-	gw, gwOk := d.Get(cKeyPrefix + "." + "ipv6_gateway").(string)
+	gw, gwOk := d.Get(prefix + cKeyPrefix + "." + "ipv6_gateway").(string)
 	obj := &types.CustomizationIPSettingsIpV6AddressSpec{
 		Ip: []types.BaseCustomizationIpV6Generator{
 			&types.CustomizationFixedIpV6{
@@ -489,7 +550,7 @@ func expandCustomizationIPSettings(d *schema.ResourceData, n int, v4gwAdd, v6gwA
 	var v4gwFound, v6gwFound bool
 	v4addr, v4addrOk := d.GetOk(netifKey("ipv4_address", n, prefix))
 	v4mask := d.Get(netifKey("ipv4_netmask", n, prefix)).(int)
-	v4gw, v4gwOk := d.Get(cKeyPrefix + "." + "ipv4_gateway").(string)
+	v4gw, v4gwOk := d.Get(prefix + cKeyPrefix + "." + "ipv4_gateway").(string)
 	var obj types.CustomizationIPSettings
 	switch {
 	case v4addrOk:
@@ -559,6 +620,171 @@ func ExpandCustomizationSpec(d *schema.ResourceData, family, prefix string) type
 	return obj
 }
 
+// customizeReapplyIgnoredKeys are the keys in the customize block that do not
+// cause the customization to be re-applied when they change.
+var customizeReapplyIgnoredKeys = map[string]bool{
+	"timeout":           true,
+	"reapply_on_change": true,
+}
+
+// CustomizationNeedsReapply returns true if reapply_on_change is enabled in the
+// customize block and any of the customization settings in it have changed.
+func CustomizationNeedsReapply(d *schema.ResourceData, prefix string) bool {
+	if !d.Get(prefix + cKeyPrefix + "." + "reapply_on_change").(bool) {
+		return false
+	}
+	for k := range VirtualMachineCustomizeSchema() {
+		if customizeReapplyIgnoredKeys[k] {
+			continue
+		}
+		if d.HasChange(prefix + cKeyPrefix + "." + k) {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomizationSpecFamily returns the guest OS family that the identity
+// settings in the customize block are for, or an empty string if no identity
+// settings are defined.
+func CustomizationSpecFamily(d *schema.ResourceData, prefix string) string {
+	switch {
+	case len(d.Get(prefix+cKeyPrefix+"."+"linux_options").([]interface{})) > 0:
+		return string(types.VirtualMachineGuestOsFamilyLinuxGuest)
+	case len(d.Get(prefix+cKeyPrefix+"."+"windows_options").([]interface{})) > 0:
+		fallthrough
+	case d.Get(prefix+cKeyPrefix+"."+"windows_sysprep_text").(string) != "":
+		return string(types.VirtualMachineGuestOsFamilyWindowsGuest)
+	}
+	return ""
+}
+
+// OverlayCustomizationSpec applies the per-VM settings in the customize block
+// on top of a saved CustomizationSpec, for use with spec_name. The identity
+// settings of the saved spec are always used. The NIC settings are replaced
+// if there are any network_interface blocks, and the global DNS settings are
+// replaced if either dns_server_list or dns_suffix_list is set.
+func OverlayCustomizationSpec(d *schema.ResourceData, spec types.CustomizationSpec, prefix string) (types.CustomizationSpec, error) {
+	if CustomizationSpecFamily(d, prefix) != "" {
+		return spec, errors.New("linux_options, windows_options and windows_sysprep_text cannot be used with spec_name")
+	}
+	if nics := expandSliceOfCustomizationAdapterMapping(d, prefix); nics != nil {
+		spec.NicSettingMap = nics
+	}
+	global := expandCustomizationGlobalIPSettings(d, prefix)
+	if len(global.DnsServerList) > 0 || len(global.DnsSuffixList) > 0 {
+		spec.GlobalIPSettings = global
+	}
+	return spec, nil
+}
+
+// FlattenCustomizationSpec reads a CustomizationSpec into the customize block
+// of the supplied ResourceData. Passwords cannot be read back in plain text,
+// so the values in state are kept.
+func FlattenCustomizationSpec(d *schema.ResourceData, obj types.CustomizationSpec) error {
+	m := map[string]interface{}{
+		"dns_server_list": obj.GlobalIPSettings.DnsServerList,
+		"dns_suffix_list": obj.GlobalIPSettings.DnsSuffixList,
+	}
+
+	switch identity := obj.Identity.(type) {
+	case *types.CustomizationLinuxPrep:
+		m["linux_options"] = []interface{}{flattenCustomizationLinuxPrep(identity)}
+	case *types.CustomizationSysprep:
+		m["windows_options"] = []interface{}{flattenCustomizationSysprep(d, identity)}
+	case *types.CustomizationSysprepText:
+		m["windows_sysprep_text"] = identity.Value
+	}
+
+	var nics []interface{}
+	for _, nic := range obj.NicSettingMap {
+		nm, v4gw, v6gw := flattenCustomizationIPSettings(nic.Adapter)
+		if _, ok := m["ipv4_gateway"]; !ok && v4gw != "" {
+			m["ipv4_gateway"] = v4gw
+		}
+		if _, ok := m["ipv6_gateway"]; !ok && v6gw != "" {
+			m["ipv6_gateway"] = v6gw
+		}
+		nics = append(nics, nm)
+	}
+	m["network_interface"] = nics
+
+	return d.Set("customize", []interface{}{m})
+}
+
+// flattenCustomizationLinuxPrep converts a CustomizationLinuxPrep into the
+// linux_options block.
+func flattenCustomizationLinuxPrep(obj *types.CustomizationLinuxPrep) map[string]interface{} {
+	m := map[string]interface{}{
+		"domain":       obj.Domain,
+		"time_zone":    obj.TimeZone,
+		"hw_clock_utc": obj.HwClockUTC == nil || *obj.HwClockUTC,
+	}
+	if name, ok := obj.HostName.(*types.CustomizationFixedName); ok {
+		m["host_name"] = name.Name
+	}
+	return m
+}
+
+// flattenCustomizationSysprep converts a CustomizationSysprep into the
+// windows_options block.
+func flattenCustomizationSysprep(d *schema.ResourceData, obj *types.CustomizationSysprep) map[string]interface{} {
+	m := map[string]interface{}{
+		"auto_logon":            obj.GuiUnattended.AutoLogon,
+		"auto_logon_count":      int(obj.GuiUnattended.AutoLogonCount),
+		"time_zone":             int(obj.GuiUnattended.TimeZone),
+		"admin_password":        d.Get(cWindowsKeyPrefix + "." + "admin_password").(string),
+		"domain_admin_user":     obj.Identification.DomainAdmin,
+		"domain_admin_password": d.Get(cWindowsKeyPrefix + "." + "domain_admin_password").(string),
+		"join_domain":           obj.Identification.JoinDomain,
+		"workgroup":             obj.Identification.JoinWorkgroup,
+		"full_name":             obj.UserData.FullName,
+		"organization_name":     obj.UserData.OrgName,
+		"product_key":           obj.UserData.ProductId,
+	}
+	if obj.GuiRunOnce != nil {
+		m["run_once_command_list"] = obj.GuiRunOnce.CommandList
+	}
+	if name, ok := obj.UserData.ComputerName.(*types.CustomizationFixedName); ok {
+		m["computer_name"] = name.Name
+	}
+	return m
+}
+
+// flattenCustomizationIPSettings converts a CustomizationIPSettings into a
+// network_interface block. The first IPv4 and IPv6 gateways are also
+// returned.
+func flattenCustomizationIPSettings(obj types.CustomizationIPSettings) (map[string]interface{}, string, string) {
+	m := map[string]interface{}{
+		"dns_server_list": obj.DnsServerList,
+		"dns_domain":      obj.DnsDomain,
+	}
+	var v4gw, v6gw string
+	if ip, ok := obj.Ip.(*types.CustomizationFixedIp); ok {
+		m["ipv4_address"] = ip.IpAddress
+		if mask := net.ParseIP(obj.SubnetMask).To4(); mask != nil {
+			ones, _ := net.IPMask(mask).Size()
+			m["ipv4_netmask"] = ones
+		}
+		if len(obj.Gateway) > 0 {
+			v4gw = obj.Gateway[0]
+		}
+	}
+	if obj.IpV6Spec != nil {
+		for _, gen := range obj.IpV6Spec.Ip {
+			if ip, ok := gen.(*types.CustomizationFixedIpV6); ok {
+				m["ipv6_address"] = ip.IpAddress
+				m["ipv6_netmask"] = int(ip.SubnetMask)
+				break
+			}
+		}
+		if len(obj.IpV6Spec.Gateway) > 0 {
+			v6gw = obj.IpV6Spec.Gateway[0]
+		}
+	}
+	return m, v4gw, v6gw
+}
+
 // ValidateCustomizationSpec checks the validity of the supplied customization
 // spec. It should be called during diff customization to veto invalid configs.
 func ValidateCustomizationSpec(d *schema.ResourceDiff, family string) error {
@@ -0,0 +1,267 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// schemaInventoryListFilter returns the container and filter attributes that
+// are common to the data sources that list inventory objects, such as
+// vsphere_hosts and vsphere_virtual_machines.
+func schemaInventoryListFilter() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"datacenter_id": {
+			Type:        schema.TypeString,
+			Description: "The managed object ID of the datacenter to search for objects in.",
+			Required:    true,
+		},
+		"cluster_id": {
+			Type:          schema.TypeString,
+			Description:   "The managed object ID of a cluster to limit the search to.",
+			Optional:      true,
+			ConflictsWith: []string{"folder_id"},
+		},
+		"folder_id": {
+			Type:          schema.TypeString,
+			Description:   "The managed object ID of a folder to limit the search to. Objects in subfolders are included.",
+			Optional:      true,
+			ConflictsWith: []string{"cluster_id"},
+		},
+		"name_regex": {
+			Type:         schema.TypeString,
+			Description:  "A regular expression to filter the objects against. Only objects with names that match will be included.",
+			Optional:     true,
+			ValidateFunc: validation.ValidateRegexp,
+		},
+		"tag_ids": {
+			Type:        schema.TypeSet,
+			Description: "A list of tag IDs. Only objects that have all of the supplied tags attached will be included.",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"custom_attributes": {
+			Type:        schema.TypeMap,
+			Description: "A map of custom attribute IDs to values. Only objects that have all of the supplied custom attribute values will be included.",
+			Optional:    true,
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Description: "The managed object IDs of the objects that matched the search, sorted by name.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// inventoryListFilter matches objects against the name_regex, tag_ids, and
+// custom_attributes attributes of an inventory list data source.
+type inventoryListFilter struct {
+	// The compiled name_regex, or nil if it was not set.
+	name *regexp.Regexp
+
+	// The objects that have all of the tags in tag_ids attached, keyed by
+	// type and managed object ID. This is nil if tag_ids was not set.
+	tagged map[string]bool
+
+	// The custom attribute values that objects need to have, keyed by custom
+	// attribute ID.
+	customAttributes map[int32]string
+}
+
+// newInventoryListFilter returns an inventoryListFilter for the filter
+// attributes in the supplied ResourceData.
+func newInventoryListFilter(d *schema.ResourceData, meta interface{}) (*inventoryListFilter, error) {
+	f := &inventoryListFilter{
+		customAttributes: make(map[int32]string),
+	}
+
+	if v, ok := d.GetOk("name_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error compiling name_regex: %s", err)
+		}
+		f.name = re
+	}
+
+	for k, v := range d.Get("custom_attributes").(map[string]interface{}) {
+		var key int32
+		if _, err := fmt.Sscan(k, &key); err != nil {
+			return nil, fmt.Errorf("invalid custom attribute ID %q: %s", k, err)
+		}
+		f.customAttributes[key] = v.(string)
+	}
+
+	tagIDs := d.Get("tag_ids").(*schema.Set).List()
+	if len(tagIDs) < 1 {
+		return f, nil
+	}
+	tagged, err := inventoryListTaggedObjects(meta, structure.SliceInterfacesToStrings(tagIDs), true)
+	if err != nil {
+		return nil, err
+	}
+	f.tagged = tagged
+	return f, nil
+}
+
+// inventoryListTaggedObjects returns the objects that have the supplied tags
+// attached, keyed by inventoryListFilterKey. If all is true, only objects that
+// have all of the tags attached are returned, otherwise objects that have any
+// of the tags attached are returned.
+func inventoryListTaggedObjects(meta interface{}, tagIDs []string, all bool) (map[string]bool, error) {
+	tc, err := meta.(*VSphereClient).TagsClient()
+	if err != nil {
+		return nil, err
+	}
+	var tagged map[string]bool
+	for i, id := range tagIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		objs, err := tc.ListAttachedObjects(ctx, id)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("error listing objects attached to tag %q: %s", id, err)
+		}
+		attached := make(map[string]bool)
+		for _, obj := range objs {
+			if obj.Type == nil || obj.ID == nil {
+				continue
+			}
+			k := inventoryListFilterKey(types.ManagedObjectReference{Type: *obj.Type, Value: *obj.ID})
+			if !all || i == 0 || tagged[k] {
+				attached[k] = true
+			}
+		}
+		if !all {
+			for k := range tagged {
+				attached[k] = true
+			}
+		}
+		tagged = attached
+	}
+	return tagged, nil
+}
+
+// Match returns true if the supplied object matches the filter.
+func (f *inventoryListFilter) Match(entity mo.ManagedEntity) bool {
+	if f.name != nil && !f.name.MatchString(entity.Name) {
+		return false
+	}
+	if f.tagged != nil && !f.tagged[inventoryListFilterKey(entity.Reference())] {
+		return false
+	}
+	for key, value := range f.customAttributes {
+		var found bool
+		for _, cv := range entity.CustomValue {
+			if sv, ok := cv.(*types.CustomFieldStringValue); ok && sv.Key == key && sv.Value == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// inventoryListFilterKey returns the key used for a managed object reference
+// in the tagged map of an inventoryListFilter.
+func inventoryListFilterKey(ref types.ManagedObjectReference) string {
+	return ref.Type + ":" + ref.Value
+}
+
+// inventoryListContainer returns the reference of the container to search for
+// objects in. This is the cluster in cluster_id or the folder in folder_id if
+// either is set, and the datacenter in datacenter_id otherwise.
+//
+// useCluster should be false for object types that are not descendants of a
+// cluster, in which case the datacenter is searched and the results should be
+// limited with inventoryListClusterMembers.
+func inventoryListContainer(d *schema.ResourceData, client *govmomi.Client, useCluster bool) (types.ManagedObjectReference, error) {
+	if id, ok := d.GetOk("cluster_id"); ok && useCluster {
+		return managedEntityReferenceFromID(client, "ClusterComputeResource", id.(string))
+	}
+	if id, ok := d.GetOk("folder_id"); ok {
+		fo, err := folder.FromID(client, id.(string))
+		if err != nil {
+			return types.ManagedObjectReference{}, fmt.Errorf("cannot locate folder: %s", err)
+		}
+		return fo.Reference(), nil
+	}
+	dc, err := datacenterFromID(client, d.Get("datacenter_id").(string))
+	if err != nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("cannot locate datacenter: %s", err)
+	}
+	return dc.Reference(), nil
+}
+
+// inventoryListRetrieve retrieves the supplied properties for all objects of
+// the supplied kind under a container, using a single recursive container
+// view. dst should be a pointer to a slice of the mo type for kind.
+func inventoryListRetrieve(client *govmomi.Client, container types.ManagedObjectReference, kind string, ps []string, dst interface{}) error {
+	log.Printf("[DEBUG] Listing %s objects in %s %q", kind, container.Type, container.Value)
+	m := view.NewManager(client.Client)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	v, err := m.CreateContainerView(ctx, container, []string{kind}, true)
+	if err != nil {
+		return fmt.Errorf("error creating container view: %s", err)
+	}
+	defer func() {
+		dctx, dcancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		defer dcancel()
+		if err := v.Destroy(dctx); err != nil {
+			log.Printf("[DEBUG] Error destroying container view %q: %s", v.Reference().Value, err)
+		}
+	}()
+
+	if err := v.Retrieve(ctx, []string{kind}, ps, dst); err != nil {
+		return fmt.Errorf("error retrieving %s objects: %s", kind, err)
+	}
+	return nil
+}
+
+// inventoryListClusterMembers returns the managed object IDs of the
+// datastores or networks, depending on the supplied property, that are
+// available to the cluster in cluster_id. nil is returned if cluster_id is not
+// set.
+//
+// This is used by vsphere_datastores and vsphere_networks to limit the search
+// to a cluster, as datastores and networks are not descendants of a cluster
+// in the inventory.
+func inventoryListClusterMembers(d *schema.ResourceData, client *govmomi.Client, property string) (map[string]bool, error) {
+	id, ok := d.GetOk("cluster_id")
+	if !ok {
+		return nil, nil
+	}
+	ref, err := managedEntityReferenceFromID(client, "ClusterComputeResource", id.(string))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	var props mo.ClusterComputeResource
+	pc := client.PropertyCollector()
+	if err := pc.RetrieveOne(ctx, ref, []string{property}, &props); err != nil {
+		return nil, fmt.Errorf("error fetching cluster properties: %s", err)
+	}
+	refs := props.Datastore
+	if property == "network" {
+		refs = props.Network
+	}
+	members := make(map[string]bool)
+	for _, r := range refs {
+		members[r.Value] = true
+	}
+	return members, nil
+}
@@ -0,0 +1,64 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/resourcepool"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// managedEntityTypeAllowedValues are the managed entity types that resources
+// such as vsphere_entity_permission and vsphere_alarm can be attached to.
+var managedEntityTypeAllowedValues = []string{
+	"ClusterComputeResource",
+	"ComputeResource",
+	"Datacenter",
+	"Datastore",
+	"DistributedVirtualPortgroup",
+	"Folder",
+	"HostSystem",
+	"Network",
+	"ResourcePool",
+	"StoragePod",
+	"VirtualApp",
+	"VirtualMachine",
+	"VmwareDistributedVirtualSwitch",
+}
+
+// managedEntityReferenceFromID validates that the managed entity referenced by
+// the supplied type and ID exists, and returns its reference.
+func managedEntityReferenceFromID(client *govmomi.Client, t, id string) (types.ManagedObjectReference, error) {
+	switch t {
+	case "Folder":
+		f, err := folder.FromID(client, id)
+		if err != nil {
+			return types.ManagedObjectReference{}, fmt.Errorf("cannot locate folder: %s", err)
+		}
+		return f.Reference(), nil
+	case "Datacenter":
+		dc, err := datacenterFromID(client, id)
+		if err != nil {
+			return types.ManagedObjectReference{}, err
+		}
+		return dc.Reference(), nil
+	case "ResourcePool":
+		rp, err := resourcepool.FromID(client, id)
+		if err != nil {
+			return types.ManagedObjectReference{}, fmt.Errorf("cannot locate resource pool: %s", err)
+		}
+		return rp.Reference(), nil
+	}
+
+	finder := find.NewFinder(client.Client, false)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	obj, err := finder.ObjectReference(ctx, types.ManagedObjectReference{Type: t, Value: id})
+	if err != nil {
+		return types.ManagedObjectReference{}, fmt.Errorf("cannot locate %s %q: %s", t, id, err)
+	}
+	return obj.Reference(), nil
+}
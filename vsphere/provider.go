@@ -94,6 +94,7 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"vsphere_alarm":                                   resourceVSphereAlarm(),
 			"vsphere_compute_cluster":                         resourceVSphereComputeCluster(),
 			"vsphere_compute_cluster_host_group":              resourceVSphereComputeClusterHostGroup(),
 			"vsphere_compute_cluster_vm_affinity_rule":        resourceVSphereComputeClusterVMAffinityRule(),
@@ -105,17 +106,26 @@ func Provider() terraform.ResourceProvider {
 			"vsphere_datacenter":                              resourceVSphereDatacenter(),
 			"vsphere_datastore_cluster":                       resourceVSphereDatastoreCluster(),
 			"vsphere_datastore_cluster_vm_anti_affinity_rule": resourceVSphereDatastoreClusterVMAntiAffinityRule(),
+			"vsphere_datastore_directory":                     resourceVSphereDatastoreDirectory(),
+			"vsphere_distributed_network_resource_pool":       resourceVSphereDistributedNetworkResourcePool(),
 			"vsphere_distributed_port_group":                  resourceVSphereDistributedPortGroup(),
+			"vsphere_distributed_port_mirror_session":         resourceVSphereDistributedPortMirrorSession(),
 			"vsphere_distributed_virtual_switch":              resourceVSphereDistributedVirtualSwitch(),
 			"vsphere_drs_vm_override":                         resourceVSphereDRSVMOverride(),
+			"vsphere_entity_permission":                       resourceVSphereEntityPermission(),
 			"vsphere_dpm_host_override":                       resourceVSphereDPMHostOverride(),
+			"vsphere_fcd":                                     resourceVSphereFCD(),
 			"vsphere_file":                                    resourceVSphereFile(),
 			"vsphere_folder":                                  resourceVSphereFolder(),
+			"vsphere_guest_os_customization":                  resourceVSphereGuestOSCustomization(),
 			"vsphere_ha_vm_override":                          resourceVSphereHAVMOverride(),
 			"vsphere_host_port_group":                         resourceVSphereHostPortGroup(),
+			"vsphere_host_profile":                            resourceVSphereHostProfile(),
 			"vsphere_host_virtual_switch":                     resourceVSphereHostVirtualSwitch(),
 			"vsphere_license":                                 resourceVSphereLicense(),
 			"vsphere_resource_pool":                           resourceVSphereResourcePool(),
+			"vsphere_role":                                    resourceVSphereRole(),
+			"vsphere_scheduled_task":                          resourceVSphereScheduledTask(),
 			"vsphere_tag":                                     resourceVSphereTag(),
 			"vsphere_tag_category":                            resourceVSphereTagCategory(),
 			"vsphere_virtual_disk":                            resourceVSphereVirtualDisk(),
@@ -125,6 +135,7 @@ func Provider() terraform.ResourceProvider {
 			"vsphere_vapp_container":                          resourceVSphereVAppContainer(),
 			"vsphere_vapp_entity":                             resourceVSphereVAppEntity(),
 			"vsphere_vmfs_datastore":                          resourceVSphereVmfsDatastore(),
+			"vsphere_vsan_disk_group":                         resourceVSphereVsanDiskGroup(),
 			"vsphere_virtual_machine_snapshot":                resourceVSphereVirtualMachineSnapshot(),
 			"vsphere_host":                                    resourceVsphereHost(),
 			"vsphere_cohesity_hot_standby_vm":                 resourceCohesityHotStandbyVM(),
@@ -136,15 +147,28 @@ func Provider() terraform.ResourceProvider {
 			"vsphere_datacenter":                 dataSourceVSphereDatacenter(),
 			"vsphere_datastore":                  dataSourceVSphereDatastore(),
 			"vsphere_datastore_cluster":          dataSourceVSphereDatastoreCluster(),
+			"vsphere_datastore_files":            dataSourceVSphereDatastoreFiles(),
+			"vsphere_datastore_stats":            dataSourceVSphereDatastoreStats(),
+			"vsphere_datastores":                 dataSourceVSphereDatastores(),
 			"vsphere_distributed_virtual_switch": dataSourceVSphereDistributedVirtualSwitch(),
+			"vsphere_events":                     dataSourceVSphereEvents(),
+			"vsphere_fcd":                        dataSourceVSphereFCD(),
 			"vsphere_folder":                     dataSourceVSphereFolder(),
+			"vsphere_guest_os":                   dataSourceVSphereGuestOS(),
+			"vsphere_guest_os_customization":     dataSourceVSphereGuestOSCustomization(),
 			"vsphere_host":                       dataSourceVSphereHost(),
+			"vsphere_hosts":                      dataSourceVSphereHosts(),
 			"vsphere_network":                    dataSourceVSphereNetwork(),
+			"vsphere_networks":                   dataSourceVSphereNetworks(),
+			"vsphere_orphaned_files":             dataSourceVSphereOrphanedFiles(),
 			"vsphere_resource_pool":              dataSourceVSphereResourcePool(),
+			"vsphere_role":                       dataSourceVSphereRole(),
 			"vsphere_tag":                        dataSourceVSphereTag(),
 			"vsphere_tag_category":               dataSourceVSphereTagCategory(),
 			"vsphere_vapp_container":             dataSourceVSphereVAppContainer(),
 			"vsphere_virtual_machine":            dataSourceVSphereVirtualMachine(),
+			"vsphere_virtual_machines":           dataSourceVSphereVirtualMachines(),
+			"vsphere_vm_hardware_options":        dataSourceVSphereVMHardwareOptions(),
 			"vsphere_vmfs_disks":                 dataSourceVSphereVmfsDisks(),
 		},
 
@@ -0,0 +1,156 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/alarm"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const resourceVSphereAlarmName = "vsphere_alarm"
+
+func resourceVSphereAlarm() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"entity_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The managed object ID of the entity to define the alarm on.",
+		},
+		"entity_type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The managed object type of the entity to define the alarm on, such as Datacenter or HostSystem.",
+			ValidateFunc: validation.StringInSlice(managedEntityTypeAllowedValues, false),
+		},
+	}
+	structure.MergeSchema(s, schemaAlarmSpec())
+
+	return &schema.Resource{
+		Create: resourceVSphereAlarmCreate,
+		Read:   resourceVSphereAlarmRead,
+		Update: resourceVSphereAlarmUpdate,
+		Delete: resourceVSphereAlarmDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereAlarmImport,
+		},
+		Schema: s,
+	}
+}
+
+func resourceVSphereAlarmCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := alarm.VerifySupport(client); err != nil {
+		return err
+	}
+	entity, err := managedEntityReferenceFromID(client, d.Get("entity_type").(string), d.Get("entity_id").(string))
+	if err != nil {
+		return err
+	}
+	counters, _, err := alarm.Counters(client)
+	if err != nil {
+		return err
+	}
+	spec, err := expandAlarmSpec(d, counters)
+	if err != nil {
+		return err
+	}
+
+	id, err := alarm.Create(client, entity, spec)
+	if err != nil {
+		return fmt.Errorf("error creating alarm: %s", err)
+	}
+	d.SetId(id)
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereAlarmIDString(d))
+	return resourceVSphereAlarmRead(d, meta)
+}
+
+func resourceVSphereAlarmRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := alarm.VerifySupport(client); err != nil {
+		return err
+	}
+	props, err := alarm.Properties(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Alarm is missing, marking as deleted", resourceVSphereAlarmIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching alarm properties: %s", err)
+	}
+	_, counters, err := alarm.Counters(client)
+	if err != nil {
+		return err
+	}
+
+	d.Set("entity_id", props.Info.Entity.Value)
+	d.Set("entity_type", props.Info.Entity.Type)
+	if err := flattenAlarmInfo(d, &props.Info, counters); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereAlarmIDString(d))
+	return nil
+}
+
+func resourceVSphereAlarmUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := alarm.VerifySupport(client); err != nil {
+		return err
+	}
+	counters, _, err := alarm.Counters(client)
+	if err != nil {
+		return err
+	}
+	spec, err := expandAlarmSpec(d, counters)
+	if err != nil {
+		return err
+	}
+	if err := alarm.Reconfigure(client, d.Id(), spec); err != nil {
+		return fmt.Errorf("error reconfiguring alarm: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereAlarmIDString(d))
+	return resourceVSphereAlarmRead(d, meta)
+}
+
+func resourceVSphereAlarmDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereAlarmIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := alarm.VerifySupport(client); err != nil {
+		return err
+	}
+	if err := alarm.Remove(client, d.Id()); err != nil {
+		return fmt.Errorf("error removing alarm: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereAlarmIDString(d))
+	return nil
+}
+
+func resourceVSphereAlarmImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := alarm.VerifySupport(client); err != nil {
+		return nil, err
+	}
+	if _, err := alarm.Properties(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error locating alarm %q: %s", d.Id(), err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereAlarmIDString prints a friendly string for the
+// vsphere_alarm resource.
+func resourceVSphereAlarmIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereAlarmName)
+}
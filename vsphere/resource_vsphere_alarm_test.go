@@ -0,0 +1,245 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereAlarm_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigMetric(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+					testAccResourceVSphereAlarmHasExpressions(1, false),
+					resource.TestCheckResourceAttr("vsphere_alarm.alarm", "metric_expression.0.metric", "cpu.usage.average"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereAlarm_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigMetric(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereAlarmConfigFull(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+					testAccResourceVSphereAlarmHasExpressions(3, true),
+					testAccResourceVSphereAlarmHasActions(2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereAlarm_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereAlarmPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereAlarmExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereAlarmConfigFull(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereAlarmExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_alarm.alarm",
+				ImportState:       true,
+				ImportStateVerify: true,
+				Config:            testAccResourceVSphereAlarmConfigFull(),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereAlarmPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_alarm acceptance tests")
+	}
+}
+
+func testAccResourceVSphereAlarmExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := testGetAlarm(s, "alarm")
+		if err != nil {
+			if viapi.IsManagedObjectNotFoundError(err) && !expected {
+				return nil
+			}
+			return err
+		}
+		if !expected {
+			return errors.New("expected alarm to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereAlarmHasExpressions(count int, and bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetAlarm(s, "alarm")
+		if err != nil {
+			return err
+		}
+		var exprs []types.BaseAlarmExpression
+		switch e := props.Info.Expression.(type) {
+		case *types.OrAlarmExpression:
+			if and {
+				return errors.New("expected alarm expression to be an AND expression")
+			}
+			exprs = e.Expression
+		case *types.AndAlarmExpression:
+			if !and {
+				return errors.New("expected alarm expression to be an OR expression")
+			}
+			exprs = e.Expression
+		default:
+			return fmt.Errorf("unexpected alarm expression type %T", e)
+		}
+		if len(exprs) != count {
+			return fmt.Errorf("expected %d expressions, got %d", count, len(exprs))
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereAlarmHasActions(count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetAlarm(s, "alarm")
+		if err != nil {
+			return err
+		}
+		group, ok := props.Info.Action.(*types.GroupAlarmAction)
+		if !ok {
+			return fmt.Errorf("unexpected alarm action type %T", props.Info.Action)
+		}
+		if len(group.Action) != count {
+			return fmt.Errorf("expected %d actions, got %d", count, len(group.Action))
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereAlarmConfigMetric() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_alarm" "alarm" {
+  name        = "terraform-test-alarm"
+  description = "Managed by Terraform"
+  entity_id   = "${data.vsphere_datacenter.dc.id}"
+  entity_type = "Datacenter"
+
+  metric_expression {
+    object_type     = "VirtualMachine"
+    metric          = "cpu.usage.average"
+    yellow          = 7500
+    yellow_interval = 300
+    red             = 9000
+    red_interval    = 300
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
+
+func testAccResourceVSphereAlarmConfigFull() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_alarm" "alarm" {
+  name                = "terraform-test-alarm"
+  description         = "Managed by Terraform"
+  entity_id           = "${data.vsphere_datacenter.dc.id}"
+  entity_type         = "Datacenter"
+  expression_operator = "and"
+  action_frequency    = 600
+  reporting_frequency = 60
+
+  metric_expression {
+    object_type = "HostSystem"
+    metric      = "cpu.usage.average"
+    yellow      = 7500
+    red         = 9000
+  }
+
+  state_expression {
+    object_type = "HostSystem"
+    state_path  = "runtime.connectionState"
+    operator    = "isEqual"
+    red         = "disconnected"
+  }
+
+  event_expression {
+    event_type  = "HostConnectionLostEvent"
+    object_type = "HostSystem"
+    status      = "red"
+  }
+
+  email_action {
+    to      = "ops@example.com"
+    subject = "vSphere alarm"
+
+    transition {
+      start_state = "yellow"
+      final_state = "red"
+      repeat      = true
+    }
+  }
+
+  snmp_action {
+    transition {
+      start_state = "green"
+      final_state = "yellow"
+    }
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
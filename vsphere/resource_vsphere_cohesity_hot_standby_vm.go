@@ -13,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/vmworkflow"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 func resourceCohesityHotStandbyVM() *schema.Resource {
@@ -100,6 +101,13 @@ func resourceCohesityHotStandbyVM() *schema.Resource {
 			Description: "The customization spec for this virtual machine. This allows the user to configure the virtual machine after creation.",
 			Elem:        &schema.Resource{Schema: vmworkflow.VirtualMachineCustomizeSchema()},
 		},
+		"cloud_init": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "cloud-init configuration data for this virtual machine, passed to the guest through the cloud-init VMware guestinfo datasource.",
+			Elem:        &schema.Resource{Schema: schemaVirtualMachineCloudInit()},
+		},
 		"ignored_guest_ips": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -180,6 +188,17 @@ func resourceCohesityHotStandbyVMCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[DEBUG] VM %q - UUID is %q", vm.InventoryPath, vprops.Config.Uuid)
 	d.SetId(vprops.Config.Uuid)
 
+	// Set the cloud-init guestinfo keys before the VM is powered on.
+	if len(d.Get("cloud_init").([]interface{})) > 0 {
+		opts, err := expandCloudInitExtraConfig(d)
+		if err != nil {
+			return err
+		}
+		if err := virtualmachine.Reconfigure(vm, types.VirtualMachineConfigSpec{ExtraConfig: opts}); err != nil {
+			return fmt.Errorf("error setting cloud_init configuration: %s", err)
+		}
+	}
+
 	var cw *virtualMachineCustomizationWaiter
 	// Send customization spec if any has been defined.
 	if len(d.Get("customize").([]interface{})) > 0 {
@@ -195,12 +214,10 @@ func resourceCohesityHotStandbyVMCreate(d *schema.ResourceData, meta interface{}
 		}
 		// TODO(Mradul) guestId would be provided by magneto. Currently hardcoding for testing purpose.
 		guestId := "centos7_64Guest"
-		//family, err := resourcepool.OSFamily(client, pool, d.Get("guest_id").(string))
-		family, err := resourcepool.OSFamily(client, pool, guestId)
+		custSpec, err := resourceVSphereVirtualMachineExpandCustomizationSpecForGuest(d, client, pool, guestId, "")
 		if err != nil {
-			return fmt.Errorf("cannot find OS family for guest ID %q: %s", d.Get("guest_id").(string), err)
+			return err
 		}
-		custSpec := vmworkflow.ExpandCustomizationSpec(d, family, "")
 		cw = newVirtualMachineCustomizationWaiter(client, vm, d.Get("customize.0.timeout").(int))
 		if err := virtualmachine.Customize(vm, custSpec); err != nil {
 			// Roll back the VMs as per the error handling in reconfigure.
@@ -1,6 +1,7 @@
 package vsphere
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/vsan"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/types"
@@ -119,6 +121,7 @@ func resourceVSphereComputeCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereComputeClusterImport,
 		},
+		CustomizeDiff: resourceVSphereComputeClusterCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -156,6 +159,12 @@ func resourceVSphereComputeCluster() *schema.Resource {
 				Optional:    true,
 				Description: "Force removal of all hosts in the cluster during destroy and make them standalone hosts. Use of this flag mainly exists for testing and is not recommended in normal use.",
 			},
+			// EVC
+			"evc_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Enhanced vMotion Compatibility (EVC) mode key to apply to the cluster, such as intel-broadwell or amd-zen. Leave unset to disable EVC.",
+			},
 			// DRS - General/automation
 			"drs_enabled": {
 				Type:        schema.TypeBool,
@@ -450,6 +459,32 @@ func resourceVSphereComputeCluster() *schema.Resource {
 				Description: "The list of IDs for health update providers configured for this cluster.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			// vSAN. These are computed so that vSAN settings made outside of
+			// Terraform are left alone when they are not in configuration.
+			"vsan_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Enables vSAN on the cluster.",
+			},
+			"vsan_dedup_compression_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Enables deduplication and compression on the cluster's vSAN datastore. Requires vsan_enabled and an all-flash configuration.",
+			},
+			"vsan_encryption_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Enables encryption at rest on the cluster's vSAN datastore. Requires vsan_enabled and vsan_encryption_kms_provider_id.",
+			},
+			"vsan_encryption_kms_provider_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the key management server cluster to use for vSAN encryption at rest.",
+			},
 			"resource_pool_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -481,6 +516,12 @@ func resourceVSphereComputeClusterCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	// Apply EVC before moving the hosts in. The cluster is still empty at this
+	// point, so the mode can be set without any host getting in the way.
+	if err := resourceVSphereComputeClusterApplyEVCMode(d, meta, cluster); err != nil {
+		return err
+	}
+
 	// Move the hosts in now.
 	if err := resourceVSphereComputeClusterProcessHostUpdate(d, meta, cluster); err != nil {
 		return err
@@ -492,6 +533,12 @@ func resourceVSphereComputeClusterCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	// vSAN data services can only be configured once vSAN has been enabled
+	// through the main cluster configuration.
+	if err := resourceVSphereComputeClusterApplyVsanConfiguration(d, meta, cluster); err != nil {
+		return err
+	}
+
 	// All done!
 	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereComputeClusterIDString(d))
 	return resourceVSphereComputeClusterRead(d, meta)
@@ -559,6 +606,14 @@ func resourceVSphereComputeClusterUpdate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	if err := resourceVSphereComputeClusterApplyVsanConfiguration(d, meta, cluster); err != nil {
+		return err
+	}
+
+	if err := resourceVSphereComputeClusterApplyEVCMode(d, meta, cluster); err != nil {
+		return err
+	}
+
 	if err := resourceVSphereComputeClusterApplyTags(d, meta, cluster); err != nil {
 		return err
 	}
@@ -594,6 +649,81 @@ func resourceVSphereComputeClusterDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
+func resourceVSphereComputeClusterCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning diff customization and validation", resourceVSphereComputeClusterIDString(d))
+
+	if err := resourceVSphereComputeClusterValidateEVCModeDiff(d, meta); err != nil {
+		return err
+	}
+	if err := resourceVSphereComputeClusterValidateVsanDiff(d); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Diff customization and validation complete", resourceVSphereComputeClusterIDString(d))
+	return nil
+}
+
+// resourceVSphereComputeClusterValidateEVCModeDiff checks that every host in
+// host_system_ids supports the EVC mode set in evc_mode. Validation is skipped
+// if either value is not known at plan time.
+func resourceVSphereComputeClusterValidateEVCModeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("evc_mode") || !d.NewValueKnown("host_system_ids") {
+		log.Printf("[DEBUG] %s: evc_mode or host_system_ids not known yet, skipping EVC validation", resourceVSphereComputeClusterIDString(d))
+		return nil
+	}
+	key := d.Get("evc_mode").(string)
+	if key == "" {
+		return nil
+	}
+	if !d.HasChange("evc_mode") && !d.HasChange("host_system_ids") {
+		return nil
+	}
+
+	client, err := resourceVSphereComputeClusterClient(meta)
+	if err != nil {
+		return err
+	}
+	version := viapi.ParseVersionFromClient(client)
+	if !version.Newer(viapi.VSphereVersion{Product: version.Product, Major: 6}) {
+		return fmt.Errorf("evc_mode is only supported on vSphere 6.0 and higher, connected to %s", version)
+	}
+
+	log.Printf("[DEBUG] %s: Validating hosts against EVC mode %q", resourceVSphereComputeClusterIDString(d), key)
+	hosts, err := resourceVSphereComputeClusterGetHostSystemObjects(
+		client,
+		structure.SliceInterfacesToStrings(d.Get("host_system_ids").(*schema.Set).List()),
+	)
+	if err != nil {
+		return err
+	}
+	modes, err := clustercomputeresource.SupportedEVCModes(client)
+	if err != nil {
+		return fmt.Errorf("error fetching supported EVC modes: %s", err)
+	}
+	if err := clustercomputeresource.ValidateHostsForEVCMode(modes, hosts, key); err != nil {
+		return fmt.Errorf("evc_mode: %s", err)
+	}
+	return nil
+}
+
+// resourceVSphereComputeClusterValidateVsanDiff checks that the vSAN data
+// services are only enabled when vSAN itself is enabled, and that encryption
+// has a key provider to use.
+func resourceVSphereComputeClusterValidateVsanDiff(d *schema.ResourceDiff) error {
+	if d.Get("vsan_enabled").(bool) || !d.NewValueKnown("vsan_enabled") {
+		if d.Get("vsan_encryption_enabled").(bool) && d.Get("vsan_encryption_kms_provider_id").(string) == "" && d.NewValueKnown("vsan_encryption_kms_provider_id") {
+			return errors.New("vsan_encryption_kms_provider_id must be set when vsan_encryption_enabled is true")
+		}
+		return nil
+	}
+	for _, k := range []string{"vsan_dedup_compression_enabled", "vsan_encryption_enabled"} {
+		if d.Get(k).(bool) {
+			return fmt.Errorf("%s cannot be enabled when vsan_enabled is false", k)
+		}
+	}
+	return nil
+}
+
 func resourceVSphereComputeClusterImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	p := d.Id()
 	cluster, err := resourceVSphereComputeClusterGetClusterFromPath(meta, p, "")
@@ -724,6 +854,9 @@ func resourceVSphereComputeClusterProcessHostUpdate(
 
 	// Add new hosts first
 	if len(newHosts) > 0 {
+		if err := resourceVSphereComputeClusterValidateEVCHosts(d, client, cluster, newHosts); err != nil {
+			return err
+		}
 		if err := clustercomputeresource.MoveHostsInto(cluster, newHosts); err != nil {
 			return fmt.Errorf("error moving new hosts into cluster: %s", err)
 		}
@@ -781,6 +914,113 @@ func resourceVSphereComputeClusterApplyClusterConfiguration(
 	return clustercomputeresource.Reconfigure(cluster, spec)
 }
 
+// resourceVSphereComputeClusterApplyVsanConfiguration applies the vSAN
+// deduplication, compression, and encryption settings to the cluster. These
+// settings are managed through the vSAN management API rather than the cluster
+// configuration spec. This is a no-op if none of the settings have changed.
+func resourceVSphereComputeClusterApplyVsanConfiguration(
+	d *schema.ResourceData,
+	meta interface{},
+	cluster *object.ClusterComputeResource,
+) error {
+	if !d.HasChange("vsan_dedup_compression_enabled") &&
+		!d.HasChange("vsan_encryption_enabled") &&
+		!d.HasChange("vsan_encryption_kms_provider_id") {
+		return nil
+	}
+	if !d.Get("vsan_enabled").(bool) {
+		// Disabling vSAN turns off all data services with it.
+		return nil
+	}
+
+	log.Printf("[DEBUG] %s: Applying vSAN data service configuration", resourceVSphereComputeClusterIDString(d))
+	client, err := resourceVSphereComputeClusterClient(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := vsan.Reconfigure(client, cluster, expandVsanReconfigSpec(d)); err != nil {
+		return fmt.Errorf("error reconfiguring vSAN: %s", err)
+	}
+	return nil
+}
+
+// resourceVSphereComputeClusterApplyEVCMode applies the EVC mode set in
+// evc_mode to the cluster, or disables EVC if evc_mode has been cleared. This
+// is a no-op if evc_mode has not changed. EVC can only be managed on vSphere
+// 6.0 and higher.
+func resourceVSphereComputeClusterApplyEVCMode(
+	d *schema.ResourceData,
+	meta interface{},
+	cluster *object.ClusterComputeResource,
+) error {
+	if !d.HasChange("evc_mode") {
+		return nil
+	}
+
+	client, err := resourceVSphereComputeClusterClient(meta)
+	if err != nil {
+		return err
+	}
+	version := viapi.ParseVersionFromClient(client)
+	if !version.Newer(viapi.VSphereVersion{Product: version.Product, Major: 6}) {
+		if d.Get("evc_mode").(string) == "" {
+			// EVC state is never read on these versions, so there is nothing to
+			// disable.
+			return nil
+		}
+		return fmt.Errorf("evc_mode is only supported on vSphere 6.0 and higher, connected to %s", version)
+	}
+
+	log.Printf("[DEBUG] %s: Applying EVC mode", resourceVSphereComputeClusterIDString(d))
+	key := d.Get("evc_mode").(string)
+	if key == "" {
+		if err := clustercomputeresource.DisableEVC(cluster); err != nil {
+			return fmt.Errorf("error disabling EVC: %s", err)
+		}
+		return nil
+	}
+	if err := clustercomputeresource.ConfigureEVC(cluster, key); err != nil {
+		return fmt.Errorf("error setting EVC mode to %q: %s", key, err)
+	}
+	return nil
+}
+
+// resourceVSphereComputeClusterValidateEVCHosts checks that the supplied hosts
+// can be added to the cluster without violating either the EVC mode the
+// cluster is currently running with, or the mode that is set in evc_mode. This
+// is run before the hosts are moved into the cluster so that incompatible
+// hosts are caught before membership changes.
+func resourceVSphereComputeClusterValidateEVCHosts(
+	d *schema.ResourceData,
+	client *govmomi.Client,
+	cluster *object.ClusterComputeResource,
+	hosts []*object.HostSystem,
+) error {
+	version := viapi.ParseVersionFromClient(client)
+	if !version.Newer(viapi.VSphereVersion{Product: version.Product, Major: 6}) {
+		return nil
+	}
+
+	state, err := clustercomputeresource.EVCState(cluster)
+	if err != nil {
+		return fmt.Errorf("error fetching EVC state: %s", err)
+	}
+	keys := make(map[string]struct{})
+	for _, key := range []string{state.CurrentEVCModeKey, d.Get("evc_mode").(string)} {
+		if key != "" {
+			keys[key] = struct{}{}
+		}
+	}
+	for key := range keys {
+		log.Printf("[DEBUG] %s: Validating new hosts against EVC mode %q", resourceVSphereComputeClusterIDString(d), key)
+		if err := clustercomputeresource.ValidateHostsForEVCMode(state.SupportedEVCMode, hosts, key); err != nil {
+			return fmt.Errorf("cannot add hosts to cluster: %s", err)
+		}
+	}
+	return nil
+}
+
 // resourceVSphereComputeClusterApplyTags processes the tags step for both
 // create and update for vsphere_compute_cluster.
 func resourceVSphereComputeClusterApplyTags(d *schema.ResourceData, meta interface{}, cluster *object.ClusterComputeResource) error {
@@ -1131,16 +1371,65 @@ func resourceVSphereComputeClusterFlattenData(
 		return err
 	}
 
+	if err := resourceVSphereComputeClusterReadEVCMode(d, cluster, version); err != nil {
+		return err
+	}
+
+	if err := resourceVSphereComputeClusterReadVsanConfiguration(d, client, cluster, props.ConfigurationEx.(*types.ClusterConfigInfoEx)); err != nil {
+		return err
+	}
+
 	return flattenClusterConfigSpecEx(d, props.ConfigurationEx.(*types.ClusterConfigInfoEx), version)
 }
 
+// resourceVSphereComputeClusterReadVsanConfiguration saves the vSAN data
+// service settings of the cluster. The vSAN management API is only queried if
+// vSAN is enabled on the cluster.
+func resourceVSphereComputeClusterReadVsanConfiguration(
+	d *schema.ResourceData,
+	client *govmomi.Client,
+	cluster *object.ClusterComputeResource,
+	obj *types.ClusterConfigInfoEx,
+) error {
+	if obj.VsanConfigInfo == nil || obj.VsanConfigInfo.Enabled == nil || !*obj.VsanConfigInfo.Enabled {
+		return structure.SetBatch(d, map[string]interface{}{
+			"vsan_dedup_compression_enabled": false,
+			"vsan_encryption_enabled":        false,
+		})
+	}
+
+	info, err := vsan.GetConfig(client, cluster)
+	if err != nil {
+		return fmt.Errorf("error fetching vSAN configuration: %s", err)
+	}
+	return flattenVsanConfigInfoEx(d, info)
+}
+
+// resourceVSphereComputeClusterReadEVCMode saves the cluster's current EVC
+// mode to evc_mode. EVC state can only be read on vSphere 6.0 and higher.
+func resourceVSphereComputeClusterReadEVCMode(
+	d *schema.ResourceData,
+	cluster *object.ClusterComputeResource,
+	version viapi.VSphereVersion,
+) error {
+	if !version.Newer(viapi.VSphereVersion{Product: version.Product, Major: 6}) {
+		return nil
+	}
+	state, err := clustercomputeresource.EVCState(cluster)
+	if err != nil {
+		return fmt.Errorf("error fetching EVC state: %s", err)
+	}
+	return d.Set("evc_mode", state.CurrentEVCModeKey)
+}
+
 // expandClusterConfigSpecEx reads certain ResourceData keys and returns a
 // ClusterConfigSpecEx.
 func expandClusterConfigSpecEx(d *schema.ResourceData, version viapi.VSphereVersion) *types.ClusterConfigSpecEx {
 	obj := &types.ClusterConfigSpecEx{
-		DasConfig: expandClusterDasConfigInfo(d, version),
-		DpmConfig: expandClusterDpmConfigInfo(d),
-		DrsConfig: expandClusterDrsConfigInfo(d),
+		DasConfig:  expandClusterDasConfigInfo(d, version),
+		DpmConfig:  expandClusterDpmConfigInfo(d),
+		DrsConfig:  expandClusterDrsConfigInfo(d),
+		VsanConfig: expandVsanClusterConfigInfo(d),
 	}
 
 	if version.Newer(viapi.VSphereVersion{Product: version.Product, Major: 6, Minor: 5}) {
@@ -1164,6 +1453,9 @@ func flattenClusterConfigSpecEx(d *schema.ResourceData, obj *types.ClusterConfig
 	if err := flattenClusterDrsConfigInfo(d, obj.DrsConfig); err != nil {
 		return err
 	}
+	if err := flattenVsanClusterConfigInfo(d, obj.VsanConfigInfo); err != nil {
+		return err
+	}
 
 	if version.Newer(viapi.VSphereVersion{Product: version.Product, Major: 6, Minor: 5}) {
 		if err := flattenClusterInfraUpdateHaConfigInfo(d, obj.InfraUpdateHaConfig); err != nil {
@@ -1661,6 +1953,83 @@ func flattenResourceVSphereComputeClusterDrsAdvancedOptions(d *schema.ResourceDa
 	return d.Set("drs_advanced_options", m)
 }
 
+// expandVsanClusterConfigInfo reads certain ResourceData keys and returns a
+// VsanClusterConfigInfo. Automatic disk claiming is always turned off, as disk
+// groups are managed by the vsphere_vsan_disk_group resource.
+//
+// nil is returned if vsan_enabled has not changed, so that the vSAN state of
+// the cluster is not touched when it is not managed in configuration.
+func expandVsanClusterConfigInfo(d *schema.ResourceData) *types.VsanClusterConfigInfo {
+	if !d.HasChange("vsan_enabled") {
+		return nil
+	}
+	obj := &types.VsanClusterConfigInfo{
+		Enabled: structure.GetBool(d, "vsan_enabled"),
+		DefaultConfig: &types.VsanClusterConfigInfoHostDefaultInfo{
+			AutoClaimStorage: structure.BoolPtr(false),
+		},
+	}
+
+	return obj
+}
+
+// flattenVsanClusterConfigInfo saves a VsanClusterConfigInfo into the supplied
+// ResourceData.
+func flattenVsanClusterConfigInfo(d *schema.ResourceData, obj *types.VsanClusterConfigInfo) error {
+	var enabled bool
+	if obj != nil && obj.Enabled != nil {
+		enabled = *obj.Enabled
+	}
+	return d.Set("vsan_enabled", enabled)
+}
+
+// expandVsanReconfigSpec reads certain ResourceData keys and returns a
+// vsan.ReconfigSpec for the vSAN data services on the cluster. Only the data
+// services that have changed are included in the spec.
+func expandVsanReconfigSpec(d *schema.ResourceData) vsan.ReconfigSpec {
+	obj := vsan.ReconfigSpec{
+		Modify: true,
+	}
+	if d.HasChange("vsan_dedup_compression_enabled") {
+		dedup := d.Get("vsan_dedup_compression_enabled").(bool)
+		obj.DataEfficiencyConfig = &vsan.DataEfficiencyConfig{
+			DedupEnabled:       dedup,
+			CompressionEnabled: &dedup,
+		}
+	}
+	if d.HasChange("vsan_encryption_enabled") || d.HasChange("vsan_encryption_kms_provider_id") {
+		obj.DataEncryptionConfig = &vsan.DataEncryptionConfig{
+			EncryptionEnabled: d.Get("vsan_encryption_enabled").(bool),
+		}
+		if id := d.Get("vsan_encryption_kms_provider_id").(string); id != "" {
+			obj.DataEncryptionConfig.KmsProviderID = &types.KeyProviderId{Id: id}
+		}
+	}
+
+	return obj
+}
+
+// flattenVsanConfigInfoEx saves the vSAN data service settings in a
+// vsan.ConfigInfoEx into the supplied ResourceData.
+func flattenVsanConfigInfoEx(d *schema.ResourceData, obj *vsan.ConfigInfoEx) error {
+	var dedup, encryption bool
+	var kmsID string
+	if obj.DataEfficiencyConfig != nil {
+		dedup = obj.DataEfficiencyConfig.DedupEnabled
+	}
+	if obj.DataEncryptionConfig != nil {
+		encryption = obj.DataEncryptionConfig.EncryptionEnabled
+		if obj.DataEncryptionConfig.KmsProviderID != nil {
+			kmsID = obj.DataEncryptionConfig.KmsProviderID.Id
+		}
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"vsan_dedup_compression_enabled":  dedup,
+		"vsan_encryption_enabled":         encryption,
+		"vsan_encryption_kms_provider_id": kmsID,
+	})
+}
+
 // expandClusterInfraUpdateHaConfigInfo reads certain ResourceData keys and returns a
 // ClusterInfraUpdateHaConfigInfo.
 func expandClusterInfraUpdateHaConfigInfo(d *schema.ResourceData) *types.ClusterInfraUpdateHaConfigInfo {
@@ -1770,6 +2139,10 @@ func resourceVSphereComputeClusterHasClusterConfigChangeExcluded(k string) bool
 		"folder",
 		"host_cluster_exit_timeout",
 		"force_evacuate_on_destroy",
+		"evc_mode",
+		"vsan_dedup_compression_enabled",
+		"vsan_encryption_enabled",
+		"vsan_encryption_kms_provider_id",
 		vSphereTagAttributeKey,
 		customattribute.ConfigKey,
 	}
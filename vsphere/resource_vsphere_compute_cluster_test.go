@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/clustercomputeresource"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
@@ -345,6 +346,66 @@ func TestAccResourceVSphereComputeCluster_createVM(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereComputeCluster_evcMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereComputeClusterPreCheck(t)
+			if os.Getenv("VSPHERE_EVC_MODE") == "" {
+				t.Skip("set VSPHERE_EVC_MODE to run vsphere_compute_cluster EVC acceptance tests")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereComputeClusterCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereComputeClusterConfigEVCMode(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereComputeClusterCheckExists(true),
+					testAccResourceVSphereComputeClusterCheckEVCMode(os.Getenv("VSPHERE_EVC_MODE")),
+				),
+			},
+			{
+				Config: testAccResourceVSphereComputeClusterConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereComputeClusterCheckExists(true),
+					testAccResourceVSphereComputeClusterCheckEVCMode(""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereComputeCluster_vsanEnabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereComputeClusterPreCheck(t)
+			if os.Getenv("VSPHERE_TEST_VSAN") == "" {
+				t.Skip("set VSPHERE_TEST_VSAN to run vsphere_compute_cluster vSAN acceptance tests")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereComputeClusterCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereComputeClusterConfigVsanEnabled(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereComputeClusterCheckExists(true),
+					testAccResourceVSphereComputeClusterCheckVsanEnabled(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereComputeClusterConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereComputeClusterCheckExists(true),
+					testAccResourceVSphereComputeClusterCheckVsanEnabled(false),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceVSphereComputeCluster_import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -550,6 +611,42 @@ func testAccResourceVSphereComputeClusterCheckDRSDefaultAutomationLevel(expected
 	}
 }
 
+func testAccResourceVSphereComputeClusterCheckEVCMode(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cluster, err := testGetComputeCluster(s, "compute_cluster")
+		if err != nil {
+			return err
+		}
+		state, err := clustercomputeresource.EVCState(cluster)
+		if err != nil {
+			return err
+		}
+		actual := state.CurrentEVCModeKey
+		if expected != actual {
+			return fmt.Errorf("expected EVC mode to be %q, got %q", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereComputeClusterCheckVsanEnabled(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetComputeClusterProperties(s, "compute_cluster")
+		if err != nil {
+			return err
+		}
+		var actual bool
+		info := props.ConfigurationEx.(*types.ClusterConfigInfoEx).VsanConfigInfo
+		if info != nil && info.Enabled != nil {
+			actual = *info.Enabled
+		}
+		if expected != actual {
+			return fmt.Errorf("expected vSAN enabled to be %t, got %t", expected, actual)
+		}
+		return nil
+	}
+}
+
 func testAccResourceVSphereComputeClusterCheckTags(tagResName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		cluster, err := testGetComputeCluster(s, "compute_cluster")
@@ -669,6 +766,83 @@ resource "vsphere_compute_cluster" "compute_cluster" {
 	)
 }
 
+func testAccResourceVSphereComputeClusterConfigEVCMode() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "hosts" {
+  default = [
+    "%s",
+    "%s",
+  ]
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "hosts" {
+  count         = "${length(var.hosts)}"
+  name          = "${var.hosts[count.index]}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_compute_cluster" "compute_cluster" {
+  name            = "terraform-compute-cluster-test"
+  datacenter_id   = "${data.vsphere_datacenter.dc.id}"
+  host_system_ids = "${data.vsphere_host.hosts.*.id}"
+  evc_mode        = "%s"
+
+  force_evacuate_on_destroy = true
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST4"),
+		os.Getenv("VSPHERE_ESXI_HOST5"),
+		os.Getenv("VSPHERE_EVC_MODE"),
+	)
+}
+
+func testAccResourceVSphereComputeClusterConfigVsanEnabled() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "hosts" {
+  default = [
+    "%s",
+    "%s",
+  ]
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "hosts" {
+  count         = "${length(var.hosts)}"
+  name          = "${var.hosts[count.index]}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_compute_cluster" "compute_cluster" {
+  name            = "terraform-compute-cluster-test"
+  datacenter_id   = "${data.vsphere_datacenter.dc.id}"
+  host_system_ids = "${data.vsphere_host.hosts.*.id}"
+  vsan_enabled    = true
+
+  force_evacuate_on_destroy = true
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST4"),
+		os.Getenv("VSPHERE_ESXI_HOST5"),
+	)
+}
+
 func testAccResourceVSphereComputeClusterConfigDRSHABasic() string {
 	return fmt.Sprintf(`
 variable "datacenter" {
@@ -0,0 +1,349 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func resourceVSphereDatastoreDirectory() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereDatastoreDirectoryCreate,
+		Read:          resourceVSphereDatastoreDirectoryRead,
+		Update:        resourceVSphereDatastoreDirectoryUpdate,
+		Delete:        resourceVSphereDatastoreDirectoryDelete,
+		CustomizeDiff: resourceVSphereDatastoreDirectoryCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Type:        schema.TypeString,
+				Description: "The name of the datacenter that the datastore is in.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"datastore": {
+				Type:        schema.TypeString,
+				Description: "The name of the datastore to sync the directory to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Description: "The path of the directory in the datastore.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source_dir": {
+				Type:        schema.TypeString,
+				Description: "The path of the local directory to sync to the datastore.",
+				Required:    true,
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Description: "The SHA-256 checksums of the synced files, keyed by their path relative to the directory.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceVSphereDatastoreDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	dc, ds, err := resourceVSphereDatastoreDirectoryDatastore(d, client)
+	if err != nil {
+		return err
+	}
+	dir := d.Get("path").(string)
+	if err := datastoreDirectoryMakeDirectory(client, dc, ds, dir); err != nil {
+		return err
+	}
+	d.SetId(ds.Path(dir))
+
+	local, err := datastoreDirectoryLocalFiles(d.Get("source_dir").(string))
+	if err != nil {
+		return err
+	}
+	synced, err := datastoreDirectorySync(client, dc, ds, dir, d.Get("source_dir").(string), nil, local)
+	// Only record the checksums of the files that were uploaded, so that any
+	// that failed are uploaded again on the next apply.
+	if serr := d.Set("files", synced); serr != nil {
+		return fmt.Errorf("error setting files: %s", serr)
+	}
+	if err != nil {
+		return err
+	}
+	return resourceVSphereDatastoreDirectoryRead(d, meta)
+}
+
+func resourceVSphereDatastoreDirectoryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	_, ds, err := resourceVSphereDatastoreDirectoryDatastore(d, client)
+	if err != nil {
+		return err
+	}
+	dir := d.Get("path").(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if _, err := ds.Stat(ctx, dir); err != nil {
+		if _, ok := err.(object.DatastoreNoSuchFileError); ok {
+			log.Printf("[DEBUG] Directory %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading directory %q: %s", d.Id(), err)
+	}
+
+	// Drop any files that have been removed from the datastore, so that they are
+	// uploaded again on the next apply.
+	remote, err := datastoreDirectoryRemoteFiles(ds, dir)
+	if err != nil {
+		return fmt.Errorf("error listing directory %q: %s", d.Id(), err)
+	}
+	files := make(map[string]interface{})
+	for name, sum := range d.Get("files").(map[string]interface{}) {
+		if remote[name] {
+			files[name] = sum
+		}
+	}
+	if err := d.Set("files", files); err != nil {
+		return fmt.Errorf("error setting files: %s", err)
+	}
+	return nil
+}
+
+func resourceVSphereDatastoreDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	dc, ds, err := resourceVSphereDatastoreDirectoryDatastore(d, client)
+	if err != nil {
+		return err
+	}
+	o, _ := d.GetChange("files")
+	current := make(map[string]string)
+	for k, v := range o.(map[string]interface{}) {
+		current[k] = v.(string)
+	}
+	local, err := datastoreDirectoryLocalFiles(d.Get("source_dir").(string))
+	if err != nil {
+		return err
+	}
+	// Use partial mode so that only the checksums of the files that were
+	// uploaded are saved if the sync fails, and the rest are retried on the
+	// next apply.
+	d.Partial(true)
+	synced, err := datastoreDirectorySync(client, dc, ds, d.Get("path").(string), d.Get("source_dir").(string), current, local)
+	if serr := d.Set("files", synced); serr != nil {
+		return fmt.Errorf("error setting files: %s", serr)
+	}
+	d.SetPartial("files")
+	if err != nil {
+		return err
+	}
+	d.Partial(false)
+	return resourceVSphereDatastoreDirectoryRead(d, meta)
+}
+
+func resourceVSphereDatastoreDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	dc, ds, err := resourceVSphereDatastoreDirectoryDatastore(d, client)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Deleting directory %q", d.Id())
+	fm := object.NewFileManager(client.Client)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	task, err := fm.DeleteDatastoreFile(ctx, ds.Path(d.Get("path").(string)), dc)
+	if err != nil {
+		return fmt.Errorf("error deleting directory %q: %s", d.Id(), err)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return fmt.Errorf("error deleting directory %q: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// resourceVSphereDatastoreDirectoryCustomizeDiff computes the checksums of
+// the files in source_dir, so that new, changed, and removed files are
+// picked up in the diff.
+func resourceVSphereDatastoreDirectoryCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source_dir") {
+		return nil
+	}
+	local, err := datastoreDirectoryLocalFiles(d.Get("source_dir").(string))
+	if err != nil {
+		return err
+	}
+	current := d.Get("files").(map[string]interface{})
+	changed := len(current) != len(local)
+	for k, v := range local {
+		if current[k] != v {
+			changed = true
+			break
+		}
+	}
+	if changed {
+		return d.SetNew("files", local)
+	}
+	return nil
+}
+
+// resourceVSphereDatastoreDirectoryDatastore locates the datacenter and
+// datastore of the directory.
+func resourceVSphereDatastoreDirectoryDatastore(d *schema.ResourceData, client *govmomi.Client) (*object.Datacenter, *object.Datastore, error) {
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error locating datacenter: %s", err)
+	}
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+	ds, err := getDatastore(finder, d.Get("datastore").(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error locating datastore: %s", err)
+	}
+	return dc, ds, nil
+}
+
+// datastoreDirectoryLocalFiles walks a local directory and returns the
+// SHA-256 of every regular file in it, keyed by the slash-separated path
+// relative to the directory.
+func datastoreDirectoryLocalFiles(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		f := file{sourceFile: p}
+		sum, err := f.sha256()
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading source_dir %q: %s", dir, err)
+	}
+	return files, nil
+}
+
+// datastoreDirectoryRemoteFiles returns the paths of all files under a
+// directory in a datastore, relative to the directory.
+func datastoreDirectoryRemoteFiles(ds *object.Datastore, dir string) (map[string]bool, error) {
+	spec := &types.HostDatastoreBrowserSearchSpec{
+		MatchPattern: []string{"*"},
+	}
+	results, err := datastore.SearchDatastoreSubFolders(ds, dir, spec)
+	if err != nil {
+		return nil, err
+	}
+	root := ds.Path(strings.TrimSuffix(dir, "/"))
+	files := make(map[string]bool)
+	for _, result := range results {
+		folder := strings.TrimSuffix(result.FolderPath, "/")
+		for _, bfi := range result.File {
+			if _, ok := bfi.(*types.FolderFileInfo); ok {
+				continue
+			}
+			fi := bfi.GetFileInfo()
+			rel := strings.TrimPrefix(strings.TrimPrefix(folder, root), "/")
+			files[path.Join(rel, fi.Path)] = true
+		}
+	}
+	return files, nil
+}
+
+// datastoreDirectorySync uploads the files in local that are new or have
+// changed from current, and deletes the files in current that are no longer in
+// local. It returns the checksums of the files that are in sync in the
+// datastore, which is local on success, and the files that were synced before
+// the error otherwise.
+func datastoreDirectorySync(
+	client *govmomi.Client,
+	dc *object.Datacenter,
+	ds *object.Datastore,
+	dir string,
+	sourceDir string,
+	current map[string]string,
+	local map[string]string,
+) (map[string]string, error) {
+	synced := make(map[string]string)
+	for name, sum := range current {
+		synced[name] = sum
+	}
+	var names []string
+	for name := range local {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	made := make(map[string]bool)
+	for _, name := range names {
+		if sum, ok := current[name]; ok && sum == local[name] {
+			continue
+		}
+		dst := path.Join(dir, name)
+		if parent := path.Dir(dst); !made[parent] {
+			if err := datastoreDirectoryMakeDirectory(client, dc, ds, parent); err != nil {
+				return synced, err
+			}
+			made[parent] = true
+		}
+		log.Printf("[DEBUG] Uploading %q to %q", name, ds.Path(dst))
+		p := soap.DefaultUpload
+		if err := ds.UploadFile(context.TODO(), filepath.Join(sourceDir, filepath.FromSlash(name)), dst, &p); err != nil {
+			return synced, fmt.Errorf("error uploading %q: %s", name, err)
+		}
+		synced[name] = local[name]
+	}
+
+	fm := ds.NewFileManager(dc, false)
+	for name := range current {
+		if _, ok := local[name]; ok {
+			continue
+		}
+		dst := path.Join(dir, name)
+		log.Printf("[DEBUG] Deleting %q", ds.Path(dst))
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+		err := fm.DeleteFile(ctx, dst)
+		cancel()
+		if err != nil {
+			return synced, fmt.Errorf("error deleting %q: %s", name, err)
+		}
+		delete(synced, name)
+	}
+	return synced, nil
+}
+
+// datastoreDirectoryMakeDirectory creates a directory in a datastore, along
+// with any missing parents. It is not an error if the directory exists.
+func datastoreDirectoryMakeDirectory(client *govmomi.Client, dc *object.Datacenter, ds *object.Datastore, dir string) error {
+	fm := object.NewFileManager(client.Client)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
+	defer cancel()
+	if err := fm.MakeDirectory(ctx, ds.Path(dir), dc, true); err != nil && !viapi.IsFileAlreadyExistsError(err) {
+		return fmt.Errorf("error creating directory %q: %s", ds.Path(dir), err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+)
+
+func TestAccResourceVSphereDatastoreDirectory_basic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf_test_dir")
+	if err != nil {
+		t.Fatalf("error %s", err)
+	}
+	defer os.RemoveAll(dir)
+	testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "a.txt", "a")
+	testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "sub/b.txt", "b")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDatastoreDirectoryFileExists("", false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDatastoreDirectoryConfig(dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDatastoreDirectoryFileExists("a.txt", true),
+					testAccResourceVSphereDatastoreDirectoryFileExists("sub/b.txt", true),
+					resource.TestCheckResourceAttr("vsphere_datastore_directory.dir", "files.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDatastoreDirectory_sync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf_test_dir")
+	if err != nil {
+		t.Fatalf("error %s", err)
+	}
+	defer os.RemoveAll(dir)
+	testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "a.txt", "a")
+	testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "sub/b.txt", "b")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDatastoreDirectoryFileExists("", false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDatastoreDirectoryConfig(dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDatastoreDirectoryFileExists("sub/b.txt", true),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "a.txt", "changed")
+					testAccResourceVSphereDatastoreDirectoryWriteFile(t, dir, "sub/c.txt", "c")
+					if err := os.Remove(filepath.Join(dir, "sub", "b.txt")); err != nil {
+						t.Fatalf("error %s", err)
+					}
+				},
+				Config: testAccResourceVSphereDatastoreDirectoryConfig(dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDatastoreDirectoryFileExists("a.txt", true),
+					testAccResourceVSphereDatastoreDirectoryFileExists("sub/b.txt", false),
+					testAccResourceVSphereDatastoreDirectoryFileExists("sub/c.txt", true),
+					resource.TestCheckResourceAttr("vsphere_datastore_directory.dir", "files.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereDatastoreDirectoryWriteFile(t *testing.T, dir, name, content string) {
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatalf("error %s", err)
+	}
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatalf("error %s", err)
+	}
+}
+
+// testAccResourceVSphereDatastoreDirectoryFileExists checks for a file
+// relative to the synced directory. An empty name checks the directory
+// itself.
+func testAccResourceVSphereDatastoreDirectoryFileExists(name string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["vsphere_datastore_directory.dir"]
+		if !ok {
+			return fmt.Errorf("resource not found: vsphere_datastore_directory.dir")
+		}
+		client := testAccProvider.Meta().(*VSphereClient).vimClient
+		finder := find.NewFinder(client.Client, true)
+		dc, err := finder.Datacenter(context.TODO(), rs.Primary.Attributes["datacenter"])
+		if err != nil {
+			return err
+		}
+		finder = finder.SetDatacenter(dc)
+		ds, err := getDatastore(finder, rs.Primary.Attributes["datastore"])
+		if err != nil {
+			return err
+		}
+		p := path.Join(rs.Primary.Attributes["path"], name)
+		_, err = ds.Stat(context.TODO(), p)
+		switch {
+		case err == nil && !expected:
+			return fmt.Errorf("expected %q to be missing", p)
+		case err == nil:
+			return nil
+		}
+		if _, ok := err.(object.DatastoreNoSuchFileError); ok {
+			if expected {
+				return fmt.Errorf("expected %q to exist", p)
+			}
+			return nil
+		}
+		return err
+	}
+}
+
+func testAccResourceVSphereDatastoreDirectoryConfig(dir string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+resource "vsphere_datastore_directory" "dir" {
+  datacenter = "${var.datacenter}"
+  datastore  = "${var.datastore}"
+  path       = "terraform-test-dir"
+  source_dir = "%s"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		dir,
+	)
+}
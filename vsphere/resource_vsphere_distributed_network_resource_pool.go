@@ -0,0 +1,368 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereDistributedNetworkResourcePoolName = "vsphere_distributed_network_resource_pool"
+
+func resourceVSphereDistributedNetworkResourcePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereDistributedNetworkResourcePoolCreate,
+		Read:   resourceVSphereDistributedNetworkResourcePoolRead,
+		Update: resourceVSphereDistributedNetworkResourcePoolUpdate,
+		Delete: resourceVSphereDistributedNetworkResourcePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereDistributedNetworkResourcePoolImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"distributed_virtual_switch_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UUID of the DVS to create the network resource pool on.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the network resource pool. Must be unique on the DVS.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the network resource pool.",
+			},
+			"reservation_mbit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The amount of bandwidth reserved for virtual machine network adapters in this network resource pool, in Mbits/sec.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the network resource pool, used to associate port groups with it.",
+			},
+		},
+	}
+}
+
+func resourceVSphereDistributedNetworkResourcePoolCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+
+	client, err := resourceVSphereDistributedNetworkResourcePoolClient(meta)
+	if err != nil {
+		return err
+	}
+	dvsUUID := d.Get("distributed_virtual_switch_uuid").(string)
+	dvs, err := dvsFromUUID(client, dvsUUID)
+	if err != nil {
+		return fmt.Errorf("cannot locate DVS: %s", err)
+	}
+	if err := resourceVSphereDistributedNetworkResourcePoolValidateVersion(dvs); err != nil {
+		return err
+	}
+
+	spec := expandDvsVmVnicResourcePoolConfigSpec(d)
+	spec.Operation = string(types.ConfigSpecOperationAdd)
+	if err := reconfigureDVSVmVnicNetworkResourcePool(client, dvs, []types.DvsVmVnicResourcePoolConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error creating network resource pool: %s", err)
+	}
+
+	pool, err := resourceVSphereDistributedNetworkResourcePoolFindEntryByName(dvs, spec.Name)
+	if err != nil {
+		return err
+	}
+	d.SetId(resourceVSphereDistributedNetworkResourcePoolFlattenID(dvsUUID, pool.Key))
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+	return resourceVSphereDistributedNetworkResourcePoolRead(d, meta)
+}
+
+func resourceVSphereDistributedNetworkResourcePoolRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+
+	dvs, key, err := resourceVSphereDistributedNetworkResourcePoolObjects(d, meta)
+	if err != nil {
+		return err
+	}
+
+	pool, err := resourceVSphereDistributedNetworkResourcePoolFindEntry(dvs, key)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		// The pool is missing, blank out the ID so it can be re-created.
+		d.SetId("")
+		return nil
+	}
+
+	dvsUUID, _, err := resourceVSphereDistributedNetworkResourcePoolParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := d.Set("distributed_virtual_switch_uuid", dvsUUID); err != nil {
+		return fmt.Errorf("error setting attribute \"distributed_virtual_switch_uuid\": %s", err)
+	}
+
+	if err := flattenDVSVmVnicNetworkResourcePool(d, pool); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedNetworkResourcePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+
+	dvs, key, err := resourceVSphereDistributedNetworkResourcePoolObjects(d, meta)
+	if err != nil {
+		return err
+	}
+	client, err := resourceVSphereDistributedNetworkResourcePoolClient(meta)
+	if err != nil {
+		return err
+	}
+
+	pool, err := resourceVSphereDistributedNetworkResourcePoolFindEntry(dvs, key)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return fmt.Errorf("network resource pool %q no longer exists", key)
+	}
+
+	spec := expandDvsVmVnicResourcePoolConfigSpec(d)
+	spec.Operation = string(types.ConfigSpecOperationEdit)
+	spec.Key = key
+	spec.ConfigVersion = pool.ConfigVersion
+	if err := reconfigureDVSVmVnicNetworkResourcePool(client, dvs, []types.DvsVmVnicResourcePoolConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error updating network resource pool: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+	return resourceVSphereDistributedNetworkResourcePoolRead(d, meta)
+}
+
+func resourceVSphereDistributedNetworkResourcePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+
+	dvs, key, err := resourceVSphereDistributedNetworkResourcePoolObjects(d, meta)
+	if err != nil {
+		return err
+	}
+	client, err := resourceVSphereDistributedNetworkResourcePoolClient(meta)
+	if err != nil {
+		return err
+	}
+
+	spec := types.DvsVmVnicResourcePoolConfigSpec{
+		Operation: string(types.ConfigSpecOperationRemove),
+		Key:       key,
+	}
+	if err := reconfigureDVSVmVnicNetworkResourcePool(client, dvs, []types.DvsVmVnicResourcePoolConfigSpec{spec}); err != nil {
+		return fmt.Errorf("error deleting network resource pool: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereDistributedNetworkResourcePoolIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedNetworkResourcePoolImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	var data map[string]string
+	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+		return nil, err
+	}
+	dvsPath, ok := data["distributed_virtual_switch_path"]
+	if !ok {
+		return nil, errors.New("missing distributed_virtual_switch_path in input data")
+	}
+	name, ok := data["name"]
+	if !ok {
+		return nil, errors.New("missing name in input data")
+	}
+
+	client, err := resourceVSphereDistributedNetworkResourcePoolClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	dvs, err := dvsFromPath(client, dvsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate DVS %q: %s", dvsPath, err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+
+	pool, err := resourceVSphereDistributedNetworkResourcePoolFindEntryByName(dvs, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(resourceVSphereDistributedNetworkResourcePoolFlattenID(props.Uuid, pool.Key))
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandDvsVmVnicResourcePoolConfigSpec reads certain ResourceData keys and
+// returns a DvsVmVnicResourcePoolConfigSpec. The operation, key, and config
+// version are left for the caller to set.
+func expandDvsVmVnicResourcePoolConfigSpec(d *schema.ResourceData) types.DvsVmVnicResourcePoolConfigSpec {
+	obj := types.DvsVmVnicResourcePoolConfigSpec{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		AllocationInfo: &types.DvsVmVnicResourceAllocation{
+			ReservationQuota: int64(d.Get("reservation_mbit").(int)),
+		},
+	}
+	return obj
+}
+
+// flattenDVSVmVnicNetworkResourcePool saves a DVSVmVnicNetworkResourcePool
+// into the supplied ResourceData.
+func flattenDVSVmVnicNetworkResourcePool(d *schema.ResourceData, obj *types.DVSVmVnicNetworkResourcePool) error {
+	var reservation int64
+	if obj.AllocationInfo != nil {
+		reservation = obj.AllocationInfo.ReservationQuota
+	}
+	return structure.SetBatch(d, map[string]interface{}{
+		"key":              obj.Key,
+		"name":             obj.Name,
+		"description":      obj.Description,
+		"reservation_mbit": reservation,
+	})
+}
+
+// resourceVSphereDistributedNetworkResourcePoolValidateVersion checks to make
+// sure that the supplied DVS is using network I/O control version 3, which is
+// required for user-defined virtual machine network resource pools.
+func resourceVSphereDistributedNetworkResourcePoolValidateVersion(dvs *object.VmwareDistributedVirtualSwitch) error {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	version := props.Config.(*types.VMwareDVSConfigInfo).NetworkResourceControlVersion
+	if version != string(types.DistributedVirtualSwitchNetworkResourceControlVersionVersion3) {
+		return fmt.Errorf(
+			"DVS %q must use network I/O control %s to manage network resource pools (current: %q)",
+			dvs.Name(),
+			types.DistributedVirtualSwitchNetworkResourceControlVersionVersion3,
+			version,
+		)
+	}
+	return nil
+}
+
+// resourceVSphereDistributedNetworkResourcePoolIDString prints a friendly
+// string for the vsphere_distributed_network_resource_pool resource.
+func resourceVSphereDistributedNetworkResourcePoolIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereDistributedNetworkResourcePoolName)
+}
+
+// resourceVSphereDistributedNetworkResourcePoolFlattenID makes an ID for the
+// vsphere_distributed_network_resource_pool resource.
+func resourceVSphereDistributedNetworkResourcePoolFlattenID(dvsUUID, key string) string {
+	return strings.Join([]string{dvsUUID, key}, ":")
+}
+
+// resourceVSphereDistributedNetworkResourcePoolParseID parses an ID for the
+// vsphere_distributed_network_resource_pool and outputs its parts.
+func resourceVSphereDistributedNetworkResourcePoolParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resourceVSphereDistributedNetworkResourcePoolFindEntry attempts to locate
+// an existing virtual machine network resource pool on a DVS by key. nil is
+// returned if the entry cannot be found.
+func resourceVSphereDistributedNetworkResourcePoolFindEntry(
+	dvs *object.VmwareDistributedVirtualSwitch,
+	key string,
+) (*types.DVSVmVnicNetworkResourcePool, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+
+	for _, pool := range props.Config.(*types.VMwareDVSConfigInfo).VmVnicNetworkResourcePool {
+		if pool.Key == key {
+			log.Printf("[DEBUG] Found network resource pool key %q in DVS %q", key, dvs.Name())
+			return &pool, nil
+		}
+	}
+
+	log.Printf("[DEBUG] No network resource pool key %q found in DVS %q", key, dvs.Name())
+	return nil, nil
+}
+
+// resourceVSphereDistributedNetworkResourcePoolFindEntryByName attempts to
+// locate an existing virtual machine network resource pool on a DVS by name.
+// Missing entries are an error.
+func resourceVSphereDistributedNetworkResourcePoolFindEntryByName(
+	dvs *object.VmwareDistributedVirtualSwitch,
+	name string,
+) (*types.DVSVmVnicNetworkResourcePool, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+
+	for _, pool := range props.Config.(*types.VMwareDVSConfigInfo).VmVnicNetworkResourcePool {
+		if pool.Name == name {
+			log.Printf("[DEBUG] Found network resource pool %q in DVS %q", name, dvs.Name())
+			return &pool, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no network resource pool %q found in DVS %q", name, dvs.Name())
+}
+
+// resourceVSphereDistributedNetworkResourcePoolObjects fetches the DVS and
+// network resource pool key from the resource ID.
+func resourceVSphereDistributedNetworkResourcePoolObjects(
+	d structure.ResourceIDStringer,
+	meta interface{},
+) (*object.VmwareDistributedVirtualSwitch, string, error) {
+	dvsUUID, key, err := resourceVSphereDistributedNetworkResourcePoolParseID(d.Id())
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := resourceVSphereDistributedNetworkResourcePoolClient(meta)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dvs, err := dvsFromUUID(client, dvsUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot locate DVS: %s", err)
+	}
+
+	return dvs, key, nil
+}
+
+func resourceVSphereDistributedNetworkResourcePoolClient(meta interface{}) (*govmomi.Client, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,206 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func TestAccResourceVSphereDistributedNetworkResourcePool_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedNetworkResourcePoolExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedNetworkResourcePoolConfig(100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedNetworkResourcePoolExists(true),
+					testAccResourceVSphereDistributedNetworkResourcePoolMatchReservation(100),
+					testAccResourceVSphereDistributedNetworkResourcePoolCheckPortgroup(),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedNetworkResourcePool_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedNetworkResourcePoolExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedNetworkResourcePoolConfig(100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedNetworkResourcePoolExists(true),
+					testAccResourceVSphereDistributedNetworkResourcePoolMatchReservation(100),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedNetworkResourcePoolConfig(200),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedNetworkResourcePoolExists(true),
+					testAccResourceVSphereDistributedNetworkResourcePoolMatchReservation(200),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedNetworkResourcePool_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedNetworkResourcePoolExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedNetworkResourcePoolConfig(100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedNetworkResourcePoolExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_distributed_network_resource_pool.pool",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					dvs, err := testGetDVS(s, "dvs")
+					if err != nil {
+						return "", err
+					}
+
+					b, err := json.Marshal(map[string]string{
+						"distributed_virtual_switch_path": dvs.InventoryPath,
+						"name":                            "terraform-test-network-resource-pool",
+					})
+					if err != nil {
+						return "", err
+					}
+
+					return string(b), nil
+				},
+				Config: testAccResourceVSphereDistributedNetworkResourcePoolConfig(100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedNetworkResourcePoolExists(true),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereDistributedNetworkResourcePoolExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pool, err := testGetDistributedNetworkResourcePool(s, "pool")
+		if err != nil {
+			if expected == false {
+				if viapi.IsManagedObjectNotFoundError(err) {
+					// This is more than likely a missing DVS, which happens during
+					// destroy as the dependent resources will be missing as well, so
+					// treat this as a deleted pool as well.
+					return nil
+				}
+			}
+			return err
+		}
+
+		switch {
+		case pool == nil && !expected:
+			// Expected missing
+			return nil
+		case pool == nil && expected:
+			// Expected to exist
+			return errors.New("network resource pool missing when expected to exist")
+		case !expected:
+			return errors.New("network resource pool still present when expected to be missing")
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedNetworkResourcePoolMatchReservation(expected int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pool, err := testGetDistributedNetworkResourcePool(s, "pool")
+		if err != nil {
+			return err
+		}
+		if pool == nil {
+			return errors.New("network resource pool missing")
+		}
+		if pool.AllocationInfo == nil || pool.AllocationInfo.ReservationQuota != expected {
+			return fmt.Errorf("expected reservation to be %d, got %#v", expected, pool.AllocationInfo)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedNetworkResourcePoolCheckPortgroup() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pool, err := testGetDistributedNetworkResourcePool(s, "pool")
+		if err != nil {
+			return err
+		}
+		props, err := testGetDVPortgroupProperties(s, "pg")
+		if err != nil {
+			return err
+		}
+		actual := props.Config.VmVnicNetworkResourcePoolKey
+		if actual != pool.Key {
+			return fmt.Errorf("expected port group network resource pool key to be %q, got %q", pool.Key, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedNetworkResourcePoolConfig(reservation int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "reservation" {
+  default = "%d"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name                             = "terraform-test-dvs"
+  datacenter_id                    = "${data.vsphere_datacenter.dc.id}"
+  network_resource_control_enabled = true
+  network_resource_control_version = "version3"
+  virtualmachine_reservation_mbit  = 1000
+}
+
+resource "vsphere_distributed_network_resource_pool" "pool" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  name                            = "terraform-test-network-resource-pool"
+  description                     = "Managed by Terraform"
+  reservation_mbit                = "${var.reservation}"
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  network_resource_pool_key       = "${vsphere_distributed_network_resource_pool.pool.key}"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		reservation,
+	)
+}
@@ -67,7 +67,7 @@ func resourceVSphereDistributedPortGroupCreate(d *schema.ResourceData, meta inte
 		return fmt.Errorf("could not find DVS %q: %s", dvsID, err)
 	}
 
-	spec := expandDVPortgroupConfigSpec(d)
+	spec := expandDVPortgroupConfigSpec(d, nil)
 	task, err := dvportgroup.Create(client, dvs, spec)
 	if err != nil {
 		return fmt.Errorf("error creating portgroup: %s", err)
@@ -161,7 +161,11 @@ func resourceVSphereDistributedPortGroupUpdate(d *schema.ResourceData, meta inte
 	if err != nil {
 		return fmt.Errorf("could not find portgroup %q: %s", pgID, err)
 	}
-	spec := expandDVPortgroupConfigSpec(d)
+	props, err := dvportgroup.Properties(pg)
+	if err != nil {
+		return fmt.Errorf("error fetching portgroup properties: %s", err)
+	}
+	spec := expandDVPortgroupConfigSpec(d, &props.Config)
 	ctx, cancel := context.WithTimeout(context.Background(), defaultAPITimeout)
 	defer cancel()
 	task, err := pg.Reconfigure(ctx, spec)
@@ -3,6 +3,8 @@ package vsphere
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -89,6 +91,40 @@ func TestAccResourceVSphereDistributedPortGroup_overrideVlan(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereDistributedPortGroup_trafficRules(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedPortGroupPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortGroupExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortGroupConfigTrafficRules(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortGroupExists(true),
+					testAccResourceVSphereDistributedPortGroupHasTrafficRules([]string{"tag-ssh", "drop-telnet"}),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedPortGroupConfigTrafficRulesReordered(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortGroupExists(true),
+					testAccResourceVSphereDistributedPortGroupHasTrafficRules([]string{"drop-telnet", "tag-ssh"}),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedPortGroupConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortGroupExists(true),
+					testAccResourceVSphereDistributedPortGroupHasTrafficRules(nil),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceVSphereDistributedPortGroup_singleTag(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -268,6 +304,28 @@ func testAccResourceVSphereDistributedPortGroupHasVlanRange(emin, emax int32) re
 	}
 }
 
+func testAccResourceVSphereDistributedPortGroupHasTrafficRules(expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVPortgroupProperties(s, "pg")
+		if err != nil {
+			return err
+		}
+		var actual []string
+		tfc := dvsTrafficFilterConfig(props.Config.DefaultPortConfig.GetDVPortSetting().FilterPolicy)
+		if tfc != nil && tfc.TrafficRuleset != nil {
+			rules := tfc.TrafficRuleset.Rules
+			sort.Slice(rules, func(i, j int) bool { return rules[i].Sequence < rules[j].Sequence })
+			for _, rule := range rules {
+				actual = append(actual, rule.Description)
+			}
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected traffic rules to be %#v, got %#v", expected, actual)
+		}
+		return nil
+	}
+}
+
 func testAccResourceVSphereDistributedPortGroupCheckTags(tagResName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		dvs, err := testGetDVPortgroup(s, "pg")
@@ -466,6 +524,88 @@ resource "vsphere_distributed_port_group" "pg" {
 	)
 }
 
+func testAccResourceVSphereDistributedPortGroupConfigTrafficRules() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+
+  traffic_rule {
+    description      = "tag-ssh"
+    action           = "tag"
+    dscp_tag         = 46
+    protocol         = 6
+    destination_port = "22"
+  }
+
+  traffic_rule {
+    description      = "drop-telnet"
+    action           = "drop"
+    direction        = "incomingPackets"
+    protocol         = 6
+    source_address   = "10.0.0.0/8"
+    destination_port = "23"
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
+
+func testAccResourceVSphereDistributedPortGroupConfigTrafficRulesReordered() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+
+  traffic_rule {
+    description      = "drop-telnet"
+    action           = "drop"
+    direction        = "incomingPackets"
+    protocol         = 6
+    source_address   = "10.0.0.0/8"
+    destination_port = "23"
+  }
+
+  traffic_rule {
+    description      = "tag-ssh"
+    action           = "tag"
+    dscp_tag         = 46
+    protocol         = 6
+    destination_port = "22"
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+	)
+}
+
 func testAccResourceVSphereDistributedPortGroupConfigSingleTag() string {
 	return fmt.Sprintf(`
 variable "datacenter" {
@@ -0,0 +1,679 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereDistributedPortMirrorSessionName = "vsphere_distributed_port_mirror_session"
+
+var vspanSessionTypeAllowedValues = []string{
+	string(types.VMwareDVSVspanSessionTypeMixedDestMirror),
+	string(types.VMwareDVSVspanSessionTypeDvPortMirror),
+	string(types.VMwareDVSVspanSessionTypeRemoteMirrorSource),
+	string(types.VMwareDVSVspanSessionTypeRemoteMirrorDest),
+	string(types.VMwareDVSVspanSessionTypeEncapsulatedRemoteMirrorSource),
+}
+
+var vspanSessionEncapTypeAllowedValues = []string{
+	string(types.VMwareDVSVspanSessionEncapTypeGre),
+	string(types.VMwareDVSVspanSessionEncapTypeErspan2),
+	string(types.VMwareDVSVspanSessionEncapTypeErspan3),
+}
+
+func resourceVSphereDistributedPortMirrorSession() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereDistributedPortMirrorSessionCreate,
+		Read:          resourceVSphereDistributedPortMirrorSessionRead,
+		Update:        resourceVSphereDistributedPortMirrorSessionUpdate,
+		Delete:        resourceVSphereDistributedPortMirrorSessionDelete,
+		CustomizeDiff: resourceVSphereDistributedPortMirrorSessionCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereDistributedPortMirrorSessionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"distributed_virtual_switch_uuid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The UUID of the DVS to create the session on.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the port mirroring session. Must be unique on the DVS.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the port mirroring session.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not the session is enabled.",
+			},
+			"session_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the session. Can be one of dvPortMirror, remoteMirrorSource, remoteMirrorDest, encapsulatedRemoteMirrorSource, or mixedDestMirror.",
+				ValidateFunc: validation.StringInSlice(vspanSessionTypeAllowedValues, false),
+			},
+			"source_transmitted_port_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The keys of the DVS ports to mirror transmitted traffic from.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source_received_port_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The keys of the DVS ports to mirror received traffic from.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source_port_group_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The keys of the port groups whose ports should be mirrored, in both directions.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source_port_group_port_keys": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The keys of the ports in source_port_group_keys that are mirrored by the session.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source_vlans": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The VLAN IDs to mirror traffic from. Used with the remoteMirrorDest session type.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 4094),
+				},
+			},
+			"destination_port_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The keys of the DVS ports to send mirrored traffic to.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"destination_port_group_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The keys of the port groups whose ports should receive mirrored traffic.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"destination_port_group_port_keys": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The keys of the ports in destination_port_group_keys that receive mirrored traffic from the session.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"destination_uplink_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The names of the DVS uplinks to send mirrored traffic to. Used with the remoteMirrorSource session type.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"destination_ip_addresses": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The IP addresses to send encapsulated mirrored traffic to. Used with the encapsulatedRemoteMirrorSource session type.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"encapsulation_vlan_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The VLAN ID used to encapsulate mirrored traffic.",
+				ValidateFunc: validation.IntBetween(0, 4094),
+			},
+			"encapsulation_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The encapsulation type for encapsulated remote mirroring. Can be one of gre, erspan2, or erspan3.",
+				ValidateFunc: validation.StringInSlice(vspanSessionEncapTypeAllowedValues, false),
+			},
+			"erspan_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The ERSPAN session ID, used with the erspan2 and erspan3 encapsulation types.",
+				ValidateFunc: validation.IntBetween(0, 1023),
+			},
+			"strip_original_vlan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether or not to strip the original VLAN tag from mirrored packets.",
+			},
+			"mirrored_packet_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum length of mirrored packets, in bytes. Packets are truncated to this length. A value of 0 mirrors the full packet.",
+				ValidateFunc: validation.IntBetween(0, 9000),
+			},
+			"sampling_rate": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The rate at which packets are sampled. A value of 1 mirrors every packet.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"normal_traffic_allowed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether or not destination ports can send and receive normal traffic in addition to mirrored traffic.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the port mirroring session.",
+			},
+		},
+	}
+}
+
+func resourceVSphereDistributedPortMirrorSessionCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereDistributedPortMirrorSessionIDString(d))
+
+	client, err := resourceVSphereDistributedPortMirrorSessionClient(meta)
+	if err != nil {
+		return err
+	}
+	dvs, err := dvsFromUUID(client, d.Get("distributed_virtual_switch_uuid").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate DVS: %s", err)
+	}
+
+	session, err := expandVMwareVspanSession(d, dvs)
+	if err != nil {
+		return err
+	}
+	if err := resourceVSphereDistributedPortMirrorSessionApply(client, dvs, session, types.ConfigSpecOperationAdd); err != nil {
+		return fmt.Errorf("error creating port mirroring session: %s", err)
+	}
+
+	session, err = resourceVSphereDistributedPortMirrorSessionFindEntryByName(dvs, session.Name)
+	if err != nil {
+		return err
+	}
+	d.SetId(resourceVSphereDistributedPortMirrorSessionFlattenID(d.Get("distributed_virtual_switch_uuid").(string), session.Key))
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereDistributedPortMirrorSessionIDString(d))
+	return resourceVSphereDistributedPortMirrorSessionRead(d, meta)
+}
+
+func resourceVSphereDistributedPortMirrorSessionRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereDistributedPortMirrorSessionIDString(d))
+
+	dvs, key, err := resourceVSphereDistributedPortMirrorSessionObjects(d, meta)
+	if err != nil {
+		return err
+	}
+
+	session, err := resourceVSphereDistributedPortMirrorSessionFindEntry(dvs, key)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		// The session is missing, blank out the ID so it can be re-created.
+		d.SetId("")
+		return nil
+	}
+
+	dvsUUID, _, err := resourceVSphereDistributedPortMirrorSessionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := d.Set("distributed_virtual_switch_uuid", dvsUUID); err != nil {
+		return fmt.Errorf("error setting attribute \"distributed_virtual_switch_uuid\": %s", err)
+	}
+
+	if err := flattenVMwareVspanSession(d, dvs, session); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereDistributedPortMirrorSessionIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedPortMirrorSessionUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereDistributedPortMirrorSessionIDString(d))
+
+	dvs, key, err := resourceVSphereDistributedPortMirrorSessionObjects(d, meta)
+	if err != nil {
+		return err
+	}
+	client, err := resourceVSphereDistributedPortMirrorSessionClient(meta)
+	if err != nil {
+		return err
+	}
+
+	session, err := expandVMwareVspanSession(d, dvs)
+	if err != nil {
+		return err
+	}
+	session.Key = key
+	if err := resourceVSphereDistributedPortMirrorSessionApply(client, dvs, session, types.ConfigSpecOperationEdit); err != nil {
+		return fmt.Errorf("error updating port mirroring session: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereDistributedPortMirrorSessionIDString(d))
+	return resourceVSphereDistributedPortMirrorSessionRead(d, meta)
+}
+
+func resourceVSphereDistributedPortMirrorSessionDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereDistributedPortMirrorSessionIDString(d))
+
+	dvs, key, err := resourceVSphereDistributedPortMirrorSessionObjects(d, meta)
+	if err != nil {
+		return err
+	}
+	client, err := resourceVSphereDistributedPortMirrorSessionClient(meta)
+	if err != nil {
+		return err
+	}
+
+	session := &types.VMwareVspanSession{Key: key}
+	if err := resourceVSphereDistributedPortMirrorSessionApply(client, dvs, session, types.ConfigSpecOperationRemove); err != nil {
+		return fmt.Errorf("error deleting port mirroring session: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereDistributedPortMirrorSessionIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedPortMirrorSessionCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning diff customization and validation", resourceVSphereDistributedPortMirrorSessionIDString(d))
+
+	// Port group membership is only checked for existing sessions. New sessions
+	// resolve their port groups on create.
+	if d.Id() == "" {
+		return nil
+	}
+	if !d.NewValueKnown("source_port_group_keys") || !d.NewValueKnown("destination_port_group_keys") {
+		log.Printf("[DEBUG] %s: Port groups not known yet, skipping port group membership check", resourceVSphereDistributedPortMirrorSessionIDString(d))
+		return nil
+	}
+	dvs, _, err := resourceVSphereDistributedPortMirrorSessionObjects(d, meta)
+	if err != nil {
+		return err
+	}
+	for _, k := range []string{"source_port_group", "destination_port_group"} {
+		ports, err := dvsPortKeysForPortgroups(dvs, structure.SliceInterfacesToStrings(d.Get(k+"_keys").(*schema.Set).List()))
+		if err != nil {
+			return fmt.Errorf("error fetching ports for %s_keys: %s", k, err)
+		}
+		old := structure.SliceInterfacesToStrings(d.Get(k + "_port_keys").(*schema.Set).List())
+		if len(subtractStrings(ports, old)) > 0 || len(subtractStrings(old, ports)) > 0 {
+			log.Printf("[DEBUG] %s: Ports in %s_keys have changed, updating session", resourceVSphereDistributedPortMirrorSessionIDString(d), k)
+			if err := d.SetNew(k+"_port_keys", ports); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Diff customization and validation complete", resourceVSphereDistributedPortMirrorSessionIDString(d))
+	return nil
+}
+
+func resourceVSphereDistributedPortMirrorSessionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	var data map[string]string
+	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+		return nil, err
+	}
+	dvsPath, ok := data["distributed_virtual_switch_path"]
+	if !ok {
+		return nil, errors.New("missing distributed_virtual_switch_path in input data")
+	}
+	name, ok := data["name"]
+	if !ok {
+		return nil, errors.New("missing name in input data")
+	}
+
+	client, err := resourceVSphereDistributedPortMirrorSessionClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	dvs, err := dvsFromPath(client, dvsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate DVS %q: %s", dvsPath, err)
+	}
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+
+	session, err := resourceVSphereDistributedPortMirrorSessionFindEntryByName(dvs, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(resourceVSphereDistributedPortMirrorSessionFlattenID(props.Uuid, session.Key))
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereDistributedPortMirrorSessionApply sends a single port
+// mirroring session update with the supplied operation to the DVS.
+func resourceVSphereDistributedPortMirrorSessionApply(
+	client *govmomi.Client,
+	dvs *object.VmwareDistributedVirtualSwitch,
+	session *types.VMwareVspanSession,
+	op types.ConfigSpecOperation,
+) error {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+	spec := &types.VMwareDVSConfigSpec{
+		DVSConfigSpec: types.DVSConfigSpec{
+			ConfigVersion: props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion,
+		},
+		VspanConfigSpec: []types.VMwareDVSVspanConfigSpec{
+			{
+				VspanSession: *session,
+				Operation:    string(op),
+			},
+		},
+	}
+	return updateDVSConfiguration(client, dvs, spec)
+}
+
+// expandVMwareVspanSession reads certain ResourceData keys and returns a
+// VMwareVspanSession. Any port groups supplied are resolved to their port
+// keys on the supplied DVS.
+func expandVMwareVspanSession(d *schema.ResourceData, dvs *object.VmwareDistributedVirtualSwitch) (*types.VMwareVspanSession, error) {
+	sourcePGPorts, err := dvsPortKeysForPortgroups(dvs, structure.SliceInterfacesToStrings(d.Get("source_port_group_keys").(*schema.Set).List()))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching ports for source port groups: %s", err)
+	}
+	destPGPorts, err := dvsPortKeysForPortgroups(dvs, structure.SliceInterfacesToStrings(d.Get("destination_port_group_keys").(*schema.Set).List()))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching ports for destination port groups: %s", err)
+	}
+
+	var vlans []int32
+	for _, v := range d.Get("source_vlans").(*schema.Set).List() {
+		vlans = append(vlans, int32(v.(int)))
+	}
+
+	obj := &types.VMwareVspanSession{
+		Name:                 d.Get("name").(string),
+		Description:          d.Get("description").(string),
+		Enabled:              d.Get("enabled").(bool),
+		SessionType:          d.Get("session_type").(string),
+		EncapsulationVlanId:  int32(d.Get("encapsulation_vlan_id").(int)),
+		EncapType:            d.Get("encapsulation_type").(string),
+		ErspanId:             int32(d.Get("erspan_id").(int)),
+		StripOriginalVlan:    d.Get("strip_original_vlan").(bool),
+		MirroredPacketLength: int32(d.Get("mirrored_packet_length").(int)),
+		SamplingRate:         int32(d.Get("sampling_rate").(int)),
+		NormalTrafficAllowed: d.Get("normal_traffic_allowed").(bool),
+		SourcePortTransmitted: expandVMwareVspanPort(
+			append(structure.SliceInterfacesToStrings(d.Get("source_transmitted_port_keys").(*schema.Set).List()), sourcePGPorts...),
+			nil,
+			nil,
+			nil,
+		),
+		SourcePortReceived: expandVMwareVspanPort(
+			append(structure.SliceInterfacesToStrings(d.Get("source_received_port_keys").(*schema.Set).List()), sourcePGPorts...),
+			nil,
+			vlans,
+			nil,
+		),
+		DestinationPort: expandVMwareVspanPort(
+			append(structure.SliceInterfacesToStrings(d.Get("destination_port_keys").(*schema.Set).List()), destPGPorts...),
+			structure.SliceInterfacesToStrings(d.Get("destination_uplink_names").(*schema.Set).List()),
+			nil,
+			structure.SliceInterfacesToStrings(d.Get("destination_ip_addresses").(*schema.Set).List()),
+		),
+	}
+	return obj, nil
+}
+
+// expandVMwareVspanPort returns a VMwareVspanPort for the supplied values, or
+// nil if all of them are empty.
+func expandVMwareVspanPort(portKeys, uplinks []string, vlans []int32, ips []string) *types.VMwareVspanPort {
+	obj := &types.VMwareVspanPort{
+		PortKey:        dedupeStrings(portKeys),
+		UplinkPortName: uplinks,
+		Vlans:          vlans,
+		IpAddress:      ips,
+	}
+	if structure.AllFieldsEmpty(obj) {
+		return nil
+	}
+	return obj
+}
+
+// flattenVMwareVspanSession saves a VMwareVspanSession into the supplied
+// ResourceData.
+//
+// The session only holds a flat list of port keys, so the ports that come from
+// port groups are told apart from explicitly configured ports with the help
+// of the current state. A mirrored port is counted as a port group port if it
+// is currently in one of the port groups, or was when the session was last
+// applied. Any other mirrored port is saved as an explicit port key, as are
+// port group ports that are also in the explicit port key attributes.
+func flattenVMwareVspanSession(d *schema.ResourceData, dvs *object.VmwareDistributedVirtualSwitch, obj *types.VMwareVspanSession) error {
+	sourcePGPorts, err := dvsPortKeysForPortgroups(dvs, structure.SliceInterfacesToStrings(d.Get("source_port_group_keys").(*schema.Set).List()))
+	if err != nil {
+		return fmt.Errorf("error fetching ports for source port groups: %s", err)
+	}
+	destPGPorts, err := dvsPortKeysForPortgroups(dvs, structure.SliceInterfacesToStrings(d.Get("destination_port_group_keys").(*schema.Set).List()))
+	if err != nil {
+		return fmt.Errorf("error fetching ports for destination port groups: %s", err)
+	}
+	sourcePGPorts = append(sourcePGPorts, structure.SliceInterfacesToStrings(d.Get("source_port_group_port_keys").(*schema.Set).List())...)
+	destPGPorts = append(destPGPorts, structure.SliceInterfacesToStrings(d.Get("destination_port_group_port_keys").(*schema.Set).List())...)
+
+	var txKeys, rxKeys, destKeys, destUplinks, destIPs []string
+	var vlans []int
+	if obj.SourcePortTransmitted != nil {
+		txKeys = obj.SourcePortTransmitted.PortKey
+	}
+	if obj.SourcePortReceived != nil {
+		rxKeys = obj.SourcePortReceived.PortKey
+		for _, v := range obj.SourcePortReceived.Vlans {
+			vlans = append(vlans, int(v))
+		}
+	}
+	if obj.DestinationPort != nil {
+		destKeys = obj.DestinationPort.PortKey
+		destUplinks = obj.DestinationPort.UplinkPortName
+		destIPs = obj.DestinationPort.IpAddress
+	}
+
+	txExplicit := structure.SliceInterfacesToStrings(d.Get("source_transmitted_port_keys").(*schema.Set).List())
+	rxExplicit := structure.SliceInterfacesToStrings(d.Get("source_received_port_keys").(*schema.Set).List())
+	destExplicit := structure.SliceInterfacesToStrings(d.Get("destination_port_keys").(*schema.Set).List())
+
+	// Port group ports are mirrored in both directions, so a port only counts
+	// as a source port group port if it is in both lists.
+	sourcePGPorts = dedupeStrings(intersectStrings(sourcePGPorts, intersectStrings(txKeys, rxKeys)))
+	destPGPorts = dedupeStrings(intersectStrings(destPGPorts, destKeys))
+
+	return structure.SetBatch(d, map[string]interface{}{
+		"key":                              obj.Key,
+		"name":                             obj.Name,
+		"description":                      obj.Description,
+		"enabled":                          obj.Enabled,
+		"session_type":                     obj.SessionType,
+		"encapsulation_vlan_id":            obj.EncapsulationVlanId,
+		"encapsulation_type":               obj.EncapType,
+		"erspan_id":                        obj.ErspanId,
+		"strip_original_vlan":              obj.StripOriginalVlan,
+		"mirrored_packet_length":           obj.MirroredPacketLength,
+		"sampling_rate":                    obj.SamplingRate,
+		"normal_traffic_allowed":           obj.NormalTrafficAllowed,
+		"source_transmitted_port_keys":     subtractStrings(txKeys, subtractStrings(sourcePGPorts, txExplicit)),
+		"source_received_port_keys":        subtractStrings(rxKeys, subtractStrings(sourcePGPorts, rxExplicit)),
+		"source_port_group_port_keys":      sourcePGPorts,
+		"source_vlans":                     vlans,
+		"destination_port_keys":            subtractStrings(destKeys, subtractStrings(destPGPorts, destExplicit)),
+		"destination_port_group_port_keys": destPGPorts,
+		"destination_uplink_names":         destUplinks,
+		"destination_ip_addresses":         destIPs,
+	})
+}
+
+// dedupeStrings returns the supplied slice with duplicate entries removed,
+// preserving order.
+func dedupeStrings(s []string) []string {
+	var r []string
+	seen := make(map[string]struct{})
+	for _, v := range s {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		r = append(r, v)
+	}
+	return r
+}
+
+// subtractStrings returns the entries in a that are not in b.
+func subtractStrings(a, b []string) []string {
+	var r []string
+	exclude := make(map[string]struct{})
+	for _, v := range b {
+		exclude[v] = struct{}{}
+	}
+	for _, v := range a {
+		if _, ok := exclude[v]; !ok {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// intersectStrings returns the entries in a that are also in b.
+func intersectStrings(a, b []string) []string {
+	var r []string
+	include := make(map[string]struct{})
+	for _, v := range b {
+		include[v] = struct{}{}
+	}
+	for _, v := range a {
+		if _, ok := include[v]; ok {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// resourceVSphereDistributedPortMirrorSessionIDString prints a friendly string
+// for the vsphere_distributed_port_mirror_session resource.
+func resourceVSphereDistributedPortMirrorSessionIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereDistributedPortMirrorSessionName)
+}
+
+// resourceVSphereDistributedPortMirrorSessionFlattenID makes an ID for the
+// vsphere_distributed_port_mirror_session resource.
+func resourceVSphereDistributedPortMirrorSessionFlattenID(dvsUUID, key string) string {
+	return strings.Join([]string{dvsUUID, key}, ":")
+}
+
+// resourceVSphereDistributedPortMirrorSessionParseID parses an ID for the
+// vsphere_distributed_port_mirror_session and outputs its parts. DVS UUIDs
+// contain spaces and dashes but no colons, so the session key is everything
+// after the first colon.
+func resourceVSphereDistributedPortMirrorSessionParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resourceVSphereDistributedPortMirrorSessionFindEntry attempts to locate an
+// existing port mirroring session on a DVS by key. nil is returned if the
+// entry cannot be found.
+func resourceVSphereDistributedPortMirrorSessionFindEntry(
+	dvs *object.VmwareDistributedVirtualSwitch,
+	key string,
+) (*types.VMwareVspanSession, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+
+	for _, session := range props.Config.(*types.VMwareDVSConfigInfo).VspanSession {
+		if session.Key == key {
+			log.Printf("[DEBUG] Found port mirroring session key %q in DVS %q", key, dvs.Name())
+			return &session, nil
+		}
+	}
+
+	log.Printf("[DEBUG] No port mirroring session key %q found in DVS %q", key, dvs.Name())
+	return nil, nil
+}
+
+// resourceVSphereDistributedPortMirrorSessionFindEntryByName attempts to
+// locate an existing port mirroring session on a DVS by name. Missing entries
+// are an error.
+func resourceVSphereDistributedPortMirrorSessionFindEntryByName(
+	dvs *object.VmwareDistributedVirtualSwitch,
+	name string,
+) (*types.VMwareVspanSession, error) {
+	props, err := dvsProperties(dvs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DVS properties: %s", err)
+	}
+
+	for _, session := range props.Config.(*types.VMwareDVSConfigInfo).VspanSession {
+		if session.Name == name {
+			log.Printf("[DEBUG] Found port mirroring session %q in DVS %q", name, dvs.Name())
+			return &session, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no port mirroring session %q found in DVS %q", name, dvs.Name())
+}
+
+// resourceVSphereDistributedPortMirrorSessionObjects fetches the DVS and
+// session key from the resource ID.
+func resourceVSphereDistributedPortMirrorSessionObjects(
+	d structure.ResourceIDStringer,
+	meta interface{},
+) (*object.VmwareDistributedVirtualSwitch, string, error) {
+	dvsUUID, key, err := resourceVSphereDistributedPortMirrorSessionParseID(d.Id())
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := resourceVSphereDistributedPortMirrorSessionClient(meta)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dvs, err := dvsFromUUID(client, dvsUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot locate DVS: %s", err)
+	}
+
+	return dvs, key, nil
+}
+
+func resourceVSphereDistributedPortMirrorSessionClient(meta interface{}) (*govmomi.Client, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,271 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func TestAccResourceVSphereDistributedPortMirrorSession_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortMirrorSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+					testAccResourceVSphereDistributedPortMirrorSessionMatch(true, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedPortMirrorSession_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortMirrorSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+					testAccResourceVSphereDistributedPortMirrorSessionMatch(true, 1),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(false, 10, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+					testAccResourceVSphereDistributedPortMirrorSessionMatch(false, 10),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedPortMirrorSession_portGroupMembership(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortMirrorSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+					testAccResourceVSphereDistributedPortMirrorSessionSourcePortCount(2),
+				),
+			},
+			{
+				// The new ports are added to the port group after the session is
+				// planned, so they show up as drift on the next plan.
+				Config:             testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 4),
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+					testAccResourceVSphereDistributedPortMirrorSessionSourcePortCount(2),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+					testAccResourceVSphereDistributedPortMirrorSessionSourcePortCount(4),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedPortMirrorSession_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedPortMirrorSessionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_distributed_port_mirror_session.session",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					dvs, err := testGetDVS(s, "dvs")
+					if err != nil {
+						return "", err
+					}
+
+					b, err := json.Marshal(map[string]string{
+						"distributed_virtual_switch_path": dvs.InventoryPath,
+						"name":                            "terraform-test-port-mirror-session",
+					})
+					if err != nil {
+						return "", err
+					}
+
+					return string(b), nil
+				},
+				Config: testAccResourceVSphereDistributedPortMirrorSessionConfig(true, 1, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedPortMirrorSessionExists(true),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereDistributedPortMirrorSessionExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		session, err := testGetDistributedPortMirrorSession(s, "session")
+		if err != nil {
+			if expected == false {
+				if viapi.IsManagedObjectNotFoundError(err) {
+					// This is more than likely a missing DVS, which happens during
+					// destroy as the dependent resources will be missing as well, so
+					// treat this as a deleted session as well.
+					return nil
+				}
+			}
+			return err
+		}
+
+		switch {
+		case session == nil && !expected:
+			// Expected missing
+			return nil
+		case session == nil && expected:
+			// Expected to exist
+			return errors.New("port mirroring session missing when expected to exist")
+		case !expected:
+			return errors.New("port mirroring session still present when expected to be missing")
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedPortMirrorSessionMatch(enabled bool, samplingRate int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		session, err := testGetDistributedPortMirrorSession(s, "session")
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return errors.New("port mirroring session missing")
+		}
+
+		if session.Enabled != enabled {
+			return fmt.Errorf("expected enabled to be %t, got %t", enabled, session.Enabled)
+		}
+		if session.SamplingRate != samplingRate {
+			return fmt.Errorf("expected sampling rate to be %d, got %d", samplingRate, session.SamplingRate)
+		}
+		if session.SourcePortTransmitted == nil || len(session.SourcePortTransmitted.PortKey) < 1 {
+			return errors.New("expected source ports to be resolved from source port group")
+		}
+		if session.DestinationPort == nil || len(session.DestinationPort.PortKey) < 1 {
+			return errors.New("expected destination ports to be resolved from destination port group")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedPortMirrorSessionSourcePortCount(expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		session, err := testGetDistributedPortMirrorSession(s, "session")
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return errors.New("port mirroring session missing")
+		}
+		var actual int
+		if session.SourcePortTransmitted != nil {
+			actual = len(session.SourcePortTransmitted.PortKey)
+		}
+		if actual != expected {
+			return fmt.Errorf("expected %d source ports, got %d", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedPortMirrorSessionConfig(enabled bool, samplingRate, sourcePorts int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "enabled" {
+  default = "%t"
+}
+
+variable "sampling_rate" {
+  default = "%d"
+}
+
+variable "source_ports" {
+  default = "%d"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_distributed_port_group" "source" {
+  name                            = "terraform-test-pg-source"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  number_of_ports                 = "${var.source_ports}"
+}
+
+resource "vsphere_distributed_port_group" "destination" {
+  name                            = "terraform-test-pg-destination"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  number_of_ports                 = 1
+}
+
+resource "vsphere_distributed_port_mirror_session" "session" {
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+  name                            = "terraform-test-port-mirror-session"
+  session_type                    = "dvPortMirror"
+  enabled                         = "${var.enabled}"
+  sampling_rate                   = "${var.sampling_rate}"
+  normal_traffic_allowed          = true
+
+  source_port_group_keys      = ["${vsphere_distributed_port_group.source.key}"]
+  destination_port_group_keys = ["${vsphere_distributed_port_group.destination.key}"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		enabled,
+		samplingRate,
+		sourcePorts,
+	)
+}
@@ -46,7 +46,8 @@ func resourceVSphereDistributedVirtualSwitch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceVSphereDistributedVirtualSwitchImport,
 		},
-		Schema: s,
+		CustomizeDiff: resourceVSphereDistributedVirtualSwitchCustomizeDiff,
+		Schema:        s,
 	}
 }
 
@@ -99,6 +100,13 @@ func resourceVSphereDistributedVirtualSwitchCreate(d *schema.ResourceData, meta
 
 	d.SetId(props.Uuid)
 
+	// Create any link aggregation groups. This needs to happen after the DVS
+	// has been created with the correct LACP API version.
+	_, lagSpecs := expandSliceOfVMwareDvsLacpGroupSpec(d, nil)
+	if err := updateDVSLacpGroupConfig(client, dvs, lagSpecs); err != nil {
+		return fmt.Errorf("error creating link aggregation groups: %s", err)
+	}
+
 	// Enable network resource I/O control if it needs to be enabled
 	if d.Get("network_resource_control_enabled").(bool) {
 		enableDVSNetworkResourceManagement(client, dvs, true)
@@ -223,11 +231,41 @@ func resourceVSphereDistributedVirtualSwitchUpdate(d *schema.ResourceData, meta
 		d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
 	}
 
+	// Link aggregation groups are managed outside of the DVS config spec.
+	// Removed groups are processed first so that any LACP API version change
+	// can succeed, and new or modified groups are processed after the rest of
+	// the configuration.
+	var lagSpecs []types.VMwareDvsLacpGroupSpec
+	if d.HasChange("lacp_group") {
+		props, err := dvsProperties(dvs)
+		if err != nil {
+			return fmt.Errorf("could not get DVS properties: %s", err)
+		}
+		var removeSpecs []types.VMwareDvsLacpGroupSpec
+		removeSpecs, lagSpecs = expandSliceOfVMwareDvsLacpGroupSpec(d, props.Config.(*types.VMwareDVSConfigInfo).LacpGroupConfig)
+		if len(removeSpecs) > 0 {
+			if err := updateDVSLacpGroupConfig(client, dvs, removeSpecs); err != nil {
+				return fmt.Errorf("could not remove link aggregation groups: %s", err)
+			}
+			props, err := dvsProperties(dvs)
+			if err != nil {
+				return fmt.Errorf("could not get DVS properties after removing link aggregation groups: %s", err)
+			}
+			// ConfigVersion increments with the update, so this needs to be set to
+			// avoid ConcurrentAccess errors, similar to the upgrade case above.
+			d.Set("config_version", props.Config.(*types.VMwareDVSConfigInfo).ConfigVersion)
+		}
+	}
+
 	spec := expandVMwareDVSConfigSpec(d)
 	if err := updateDVSConfiguration(client, dvs, spec); err != nil {
 		return fmt.Errorf("could not update DVS: %s", err)
 	}
 
+	if err := updateDVSLacpGroupConfig(client, dvs, lagSpecs); err != nil {
+		return fmt.Errorf("could not update link aggregation groups: %s", err)
+	}
+
 	// Modify network I/O control if necessary
 	if d.HasChange("network_resource_control_enabled") {
 		enableDVSNetworkResourceManagement(client, dvs, d.Get("network_resource_control_enabled").(bool))
@@ -276,6 +314,19 @@ func resourceVSphereDistributedVirtualSwitchDelete(d *schema.ResourceData, meta
 	return nil
 }
 
+func resourceVSphereDistributedVirtualSwitchCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// Link aggregation groups can only be defined when the switch is using the
+	// enhanced (multiple LAG) LACP API. Only check this if the API version is
+	// known, otherwise this will be caught by the API on apply.
+	if d.Get("lacp_group").(*schema.Set).Len() < 1 || !d.NewValueKnown("lacp_api_version") {
+		return nil
+	}
+	if v := d.Get("lacp_api_version").(string); v != string(types.VMwareDvsLacpApiVersionMultipleLag) {
+		return fmt.Errorf("lacp_api_version must be %s to use lacp_group (current: %q)", types.VMwareDvsLacpApiVersionMultipleLag, v)
+	}
+	return nil
+}
+
 func resourceVSphereDistributedVirtualSwitchImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	// Due to the relative difficulty in trying to fetch a DVS's UUID, we use the
 	// inventory path to the DVS instead, and just run it through finder. A full
@@ -345,6 +345,65 @@ func TestAccResourceVSphereDistributedVirtualSwitch_vlanRanges(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereDistributedVirtualSwitch_lacpGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroup(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup("lag1", 2),
+					testAccResourceVSphereDistributedVirtualSwitchHasLacpPortGroupUplinks(),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroup(4),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup("lag1", 4),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereDistributedVirtualSwitch_pvlanMappings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereDistributedVirtualSwitchPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereDistributedVirtualSwitchExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigPvlanMapping("community"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1000, "promiscuous"),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1001, "community"),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1002, "community"),
+				),
+			},
+			{
+				Config: testAccResourceVSphereDistributedVirtualSwitchConfigPvlanMapping("isolated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereDistributedVirtualSwitchExists(true),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1000, "promiscuous"),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1001, "community"),
+					testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(1000, 1002, "isolated"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceVSphereDistributedVirtualSwitch_import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -601,6 +660,63 @@ func testAccResourceVSphereDistributedVirtualSwitchHasVlanRange(emin, emax int32
 	}
 }
 
+func testAccResourceVSphereDistributedVirtualSwitchHasLacpGroup(name string, uplinks int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVSProperties(s, "dvs")
+		if err != nil {
+			return err
+		}
+		groups := props.Config.(*types.VMwareDVSConfigInfo).LacpGroupConfig
+		for _, group := range groups {
+			if group.Name != name {
+				continue
+			}
+			if group.UplinkNum != uplinks {
+				return fmt.Errorf("expected LAG %q to have %d uplinks, got %d", name, uplinks, group.UplinkNum)
+			}
+			return nil
+		}
+		return fmt.Errorf("could not find LAG %q in %#v", name, groups)
+	}
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchHasLacpPortGroupUplinks() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVPortgroupProperties(s, "pg")
+		if err != nil {
+			return err
+		}
+		policy := props.Config.DefaultPortConfig.(*types.VMwareDVSPortSetting).UplinkTeamingPolicy
+		expected := []string{"lag1"}
+		actual := policy.UplinkPortOrder.ActiveUplinkPort
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected active uplinks to be %#v, got %#v", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchHasPvlanMapping(primary, secondary int32, pvlanType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetDVSProperties(s, "dvs")
+		if err != nil {
+			return err
+		}
+		expected := types.VMwareDVSPvlanMapEntry{
+			PrimaryVlanId:   primary,
+			SecondaryVlanId: secondary,
+			PvlanType:       pvlanType,
+		}
+		entries := props.Config.(*types.VMwareDVSConfigInfo).PvlanConfig
+		for _, entry := range entries {
+			if reflect.DeepEqual(expected, entry) {
+				return nil
+			}
+		}
+		return fmt.Errorf("could not find PVLAN mapping %#v in %#v", expected, entries)
+	}
+}
+
 func testAccResourceVSphereDistributedVirtualSwitchMatchInventoryPath(expected string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		dvs, err := testGetDVS(s, "dvs")
@@ -1198,6 +1314,94 @@ resource "vsphere_distributed_virtual_switch" "dvs" {
 	)
 }
 
+func testAccResourceVSphereDistributedVirtualSwitchConfigLacpGroup(uplinks int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "lag_uplinks" {
+  default = "%d"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name             = "terraform-test-dvs"
+  datacenter_id    = "${data.vsphere_datacenter.dc.id}"
+  lacp_api_version = "multipleLag"
+
+  lacp_group {
+    name                = "lag1"
+    uplink_count        = "${var.lag_uplinks}"
+    mode                = "passive"
+    load_balancing_mode = "srcDestIp"
+  }
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+
+  active_uplinks = ["lag1"]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		uplinks,
+	)
+}
+
+func testAccResourceVSphereDistributedVirtualSwitchConfigPvlanMapping(secondaryType string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "secondary_type" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_distributed_virtual_switch" "dvs" {
+  name          = "terraform-test-dvs"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1000
+    pvlan_type        = "promiscuous"
+  }
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1001
+    pvlan_type        = "community"
+  }
+
+  pvlan_mapping {
+    primary_vlan_id   = 1000
+    secondary_vlan_id = 1002
+    pvlan_type        = "${var.secondary_type}"
+  }
+}
+
+resource "vsphere_distributed_port_group" "pg" {
+  name                            = "terraform-test-pg"
+  distributed_virtual_switch_uuid = "${vsphere_distributed_virtual_switch.dvs.id}"
+
+  port_private_secondary_vlan_id = 1001
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		secondaryType,
+	)
+}
+
 func testAccResourceVSphereDistributedVirtualSwitchConfigSingleCustomAttribute() string {
 	return fmt.Sprintf(`
 variable "datacenter" {
@@ -0,0 +1,230 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/authorization"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereEntityPermissionName = "vsphere_entity_permission"
+
+func resourceVSphereEntityPermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereEntityPermissionCreate,
+		Read:   resourceVSphereEntityPermissionRead,
+		Update: resourceVSphereEntityPermissionUpdate,
+		Delete: resourceVSphereEntityPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereEntityPermissionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"entity_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the entity to assign the permission to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"entity_type": {
+				Type:         schema.TypeString,
+				Description:  "The managed object type of the entity to assign the permission to, such as Folder or Datacenter.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(managedEntityTypeAllowedValues, false),
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Description: "The user or group to assign the permission to, such as VSPHERE.LOCAL\\devops.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"is_group": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not principal is a group.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the role to assign to the principal on the entity.",
+				Required:    true,
+			},
+			"propagate": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not the permission propagates to child entities.",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceVSphereEntityPermissionCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereEntityPermissionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, err := managedEntityReferenceFromID(client, d.Get("entity_type").(string), d.Get("entity_id").(string))
+	if err != nil {
+		return err
+	}
+	principal := d.Get("principal").(string)
+	group := d.Get("is_group").(bool)
+
+	existing, err := authorization.Permission(client, entity, principal, group)
+	if err != nil {
+		return fmt.Errorf("error checking for existing permission: %s", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("a permission for %q already exists on %s %q", principal, entity.Type, entity.Value)
+	}
+
+	perm, err := expandEntityPermission(d)
+	if err != nil {
+		return err
+	}
+	if err := authorization.SetPermission(client, entity, perm); err != nil {
+		return fmt.Errorf("could not set permission: %s", err)
+	}
+
+	d.SetId(resourceVSphereEntityPermissionID(entity, principal))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereEntityPermissionIDString(d))
+	return resourceVSphereEntityPermissionRead(d, meta)
+}
+
+func resourceVSphereEntityPermissionRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereEntityPermissionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, principal, err := resourceVSphereEntityPermissionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	perm, err := authorization.Permission(client, entity, principal, d.Get("is_group").(bool))
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Entity is missing, marking permission as deleted", resourceVSphereEntityPermissionIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching permission: %s", err)
+	}
+	if perm == nil {
+		log.Printf("[DEBUG] %s: Permission is missing, marking as deleted", resourceVSphereEntityPermissionIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("entity_id", entity.Value)
+	d.Set("entity_type", entity.Type)
+	d.Set("principal", principal)
+	if err := flattenEntityPermission(d, perm); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereEntityPermissionIDString(d))
+	return nil
+}
+
+func resourceVSphereEntityPermissionUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereEntityPermissionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, _, err := resourceVSphereEntityPermissionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	perm, err := expandEntityPermission(d)
+	if err != nil {
+		return err
+	}
+	if err := authorization.SetPermission(client, entity, perm); err != nil {
+		return fmt.Errorf("could not update permission: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereEntityPermissionIDString(d))
+	return resourceVSphereEntityPermissionRead(d, meta)
+}
+
+func resourceVSphereEntityPermissionDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereEntityPermissionIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	entity, principal, err := resourceVSphereEntityPermissionParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := authorization.RemovePermission(client, entity, principal, d.Get("is_group").(bool)); err != nil {
+		return fmt.Errorf("could not remove permission: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereEntityPermissionIDString(d))
+	return nil
+}
+
+func resourceVSphereEntityPermissionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	entity, principal, err := resourceVSphereEntityPermissionParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	entity, err = managedEntityReferenceFromID(client, entity.Type, entity.Value)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range []bool{false, true} {
+		perm, err := authorization.Permission(client, entity, principal, group)
+		if err != nil {
+			return nil, err
+		}
+		if perm != nil {
+			d.Set("is_group", perm.Group)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no permission for %q found on %s %q", principal, entity.Type, entity.Value)
+}
+
+// expandEntityPermission reads the permission configuration from
+// ResourceData into a Permission.
+func expandEntityPermission(d *schema.ResourceData) (types.Permission, error) {
+	roleID, err := resourceVSphereRoleParseID(d.Get("role_id").(string))
+	if err != nil {
+		return types.Permission{}, err
+	}
+	return types.Permission{
+		Principal: d.Get("principal").(string),
+		Group:     d.Get("is_group").(bool),
+		RoleId:    roleID,
+		Propagate: d.Get("propagate").(bool),
+	}, nil
+}
+
+// flattenEntityPermission saves a Permission into ResourceData.
+func flattenEntityPermission(d *schema.ResourceData, obj *types.Permission) error {
+	d.Set("is_group", obj.Group)
+	d.Set("role_id", fmt.Sprint(obj.RoleId))
+	d.Set("propagate", obj.Propagate)
+	return nil
+}
+
+// resourceVSphereEntityPermissionID builds the resource ID for a permission,
+// in the form entity_type:entity_id:principal.
+func resourceVSphereEntityPermissionID(entity types.ManagedObjectReference, principal string) string {
+	return strings.Join([]string{entity.Type, entity.Value, principal}, ":")
+}
+
+// resourceVSphereEntityPermissionParseID parses the entity reference and
+// principal out of a resource ID.
+func resourceVSphereEntityPermissionParseID(id string) (types.ManagedObjectReference, string, error) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return types.ManagedObjectReference{}, "", fmt.Errorf("invalid permission ID %q, expected entity_type:entity_id:principal", id)
+	}
+	return types.ManagedObjectReference{Type: parts[0], Value: parts[1]}, parts[2], nil
+}
+
+// resourceVSphereEntityPermissionIDString prints a friendly string for the
+// vsphere_entity_permission resource.
+func resourceVSphereEntityPermissionIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereEntityPermissionName)
+}
@@ -0,0 +1,177 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+func TestAccResourceVSphereEntityPermission_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionConfig("role1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionExists(true),
+					testAccResourceVSphereEntityPermissionMatches("role1", true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereEntityPermission_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionConfig("role1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereEntityPermissionConfig("role2", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionExists(true),
+					testAccResourceVSphereEntityPermissionMatches("role2", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereEntityPermission_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereEntityPermissionPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereEntityPermissionExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereEntityPermissionConfig("role1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereEntityPermissionExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_entity_permission.permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+				Config:            testAccResourceVSphereEntityPermissionConfig("role1", true),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereEntityPermissionPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_entity_permission acceptance tests")
+	}
+	if os.Getenv("VSPHERE_PERMISSION_PRINCIPAL") == "" {
+		t.Skip("set VSPHERE_PERMISSION_PRINCIPAL to run vsphere_entity_permission acceptance tests")
+	}
+}
+
+func testAccResourceVSphereEntityPermissionExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		perm, err := testGetEntityPermission(s, "permission")
+		if err != nil {
+			if viapi.IsAnyNotFoundError(err) && !expected {
+				// The folder the permission was on is gone, and the permission
+				// along with it.
+				return nil
+			}
+			return err
+		}
+		if perm == nil && expected {
+			return errors.New("expected permission to exist")
+		} else if perm != nil && !expected {
+			return errors.New("expected permission to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereEntityPermissionMatches(role string, propagate bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		perm, err := testGetEntityPermission(s, "permission")
+		if err != nil {
+			return err
+		}
+		r, err := testGetRole(s, role)
+		if err != nil {
+			return err
+		}
+		if perm.RoleId != r.RoleId {
+			return fmt.Errorf("expected role ID to be %d, got %d", r.RoleId, perm.RoleId)
+		}
+		if perm.Propagate != propagate {
+			return fmt.Errorf("expected propagate to be %t, got %t", propagate, perm.Propagate)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereEntityPermissionConfig(role string, propagate bool) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "principal" {
+  default = %q
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+resource "vsphere_folder" "folder" {
+  path          = "terraform-test-folder"
+  type          = "vm"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_role" "role1" {
+  name       = "terraform-test-role1"
+  privileges = ["VirtualMachine.Interact.PowerOn"]
+}
+
+resource "vsphere_role" "role2" {
+  name       = "terraform-test-role2"
+  privileges = ["VirtualMachine.Interact.PowerOff"]
+}
+
+resource "vsphere_entity_permission" "permission" {
+  entity_id   = "${vsphere_folder.folder.id}"
+  entity_type = "Folder"
+  principal   = "${var.principal}"
+  role_id     = "${vsphere_role.%s.id}"
+  propagate   = %t
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_PERMISSION_PRINCIPAL"),
+		role,
+		propagate,
+	)
+}
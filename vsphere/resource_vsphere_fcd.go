@@ -0,0 +1,247 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/firstclassdisk"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+var fcdProvisioningTypeAllowedValues = []string{
+	string(types.BaseConfigInfoDiskFileBackingInfoProvisioningTypeThin),
+	string(types.BaseConfigInfoDiskFileBackingInfoProvisioningTypeLazyZeroedThick),
+	string(types.BaseConfigInfoDiskFileBackingInfoProvisioningTypeEagerZeroedThick),
+}
+
+func resourceVSphereFCD() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereFCDCreate,
+		Read:          resourceVSphereFCDRead,
+		Update:        resourceVSphereFCDUpdate,
+		Delete:        resourceVSphereFCDDelete,
+		CustomizeDiff: resourceVSphereFCDCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereFCDImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the first class disk.",
+				Required:    true,
+			},
+			"datastore_id": {
+				Type:        schema.TypeString,
+				Description: "The managed object ID of the datastore to create the first class disk in.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"capacity": {
+				Type:         schema.TypeInt,
+				Description:  "The capacity of the first class disk, in GB. Disks can only be grown in place, shrinking a disk forces a new resource.",
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"provisioning_type": {
+				Type:         schema.TypeString,
+				Description:  "The provisioning type of the first class disk. Can be one of thin, lazyZeroedThick, or eagerZeroedThick.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      string(types.BaseConfigInfoDiskFileBackingInfoProvisioningTypeThin),
+				ValidateFunc: validation.StringInSlice(fcdProvisioningTypeAllowedValues, false),
+			},
+			"storage_policy_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the storage policy to assign to the first class disk. This is not read back from vSphere, so changes made outside of Terraform are not detected.",
+				Optional:    true,
+			},
+			"keep_after_delete_vm": {
+				Type:        schema.TypeBool,
+				Description: "Keep the first class disk when a virtual machine it is attached to is deleted.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Description: "The datastore path of the virtual disk file backing the first class disk.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereFCDCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := firstclassdisk.VerifySupport(client); err != nil {
+		return err
+	}
+	ds, err := datastore.FromID(client, d.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore: %s", err)
+	}
+	spec := types.VslmCreateSpec{
+		Name:              d.Get("name").(string),
+		KeepAfterDeleteVm: structure.BoolPtr(d.Get("keep_after_delete_vm").(bool)),
+		CapacityInMB:      int64(d.Get("capacity").(int)) * 1024,
+		BackingSpec: &types.VslmCreateSpecDiskFileBackingSpec{
+			VslmCreateSpecBackingSpec: types.VslmCreateSpecBackingSpec{
+				Datastore: ds.Reference(),
+			},
+			ProvisioningType: d.Get("provisioning_type").(string),
+		},
+		Profile: firstclassdisk.ProfileSpec(d.Get("storage_policy_id").(string)),
+	}
+	obj, err := firstclassdisk.Create(client, spec)
+	if err != nil {
+		return fmt.Errorf("error creating first class disk: %s", err)
+	}
+
+	d.SetId(obj.Config.Id.Id)
+	return resourceVSphereFCDRead(d, meta)
+}
+
+func resourceVSphereFCDRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := firstclassdisk.VerifySupport(client); err != nil {
+		return err
+	}
+	ds, err := datastore.FromID(client, d.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore: %s", err)
+	}
+	obj, err := firstclassdisk.FromID(client, d.Id(), ds)
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			log.Printf("[DEBUG] First class disk %q not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching first class disk: %s", err)
+	}
+	return flattenVStorageObject(d, obj)
+}
+
+func resourceVSphereFCDUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := firstclassdisk.VerifySupport(client); err != nil {
+		return err
+	}
+	ds, err := datastore.FromID(client, d.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore: %s", err)
+	}
+
+	d.Partial(true)
+	if d.HasChange("name") {
+		if err := firstclassdisk.Rename(client, d.Id(), ds, d.Get("name").(string)); err != nil {
+			return fmt.Errorf("error renaming first class disk: %s", err)
+		}
+		d.SetPartial("name")
+	}
+	if d.HasChange("storage_policy_id") {
+		if err := firstclassdisk.UpdatePolicy(client, d.Id(), ds, d.Get("storage_policy_id").(string)); err != nil {
+			return fmt.Errorf("error updating storage policy of first class disk: %s", err)
+		}
+		d.SetPartial("storage_policy_id")
+	}
+	// Shrinking is handled in resourceVSphereFCDCustomizeDiff, so only growing
+	// is left here.
+	if d.HasChange("capacity") {
+		if err := firstclassdisk.Extend(client, d.Id(), ds, int64(d.Get("capacity").(int))*1024); err != nil {
+			return fmt.Errorf("error extending first class disk: %s", err)
+		}
+	}
+	d.Partial(false)
+
+	return resourceVSphereFCDRead(d, meta)
+}
+
+func resourceVSphereFCDDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := firstclassdisk.VerifySupport(client); err != nil {
+		return err
+	}
+	ds, err := datastore.FromID(client, d.Get("datastore_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate datastore: %s", err)
+	}
+	if err := firstclassdisk.Delete(client, d.Id(), ds); err != nil {
+		return fmt.Errorf("error deleting first class disk: %s", err)
+	}
+	return nil
+}
+
+func resourceVSphereFCDCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	// First class disks cannot be shrunk, so the only way to do this is to
+	// create a new disk.
+	if o, n := d.GetChange("capacity"); n.(int) < o.(int) {
+		if err := d.ForceNew("capacity"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceVSphereFCDImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := firstclassdisk.VerifySupport(client); err != nil {
+		return nil, err
+	}
+	var data map[string]string
+	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+		return nil, err
+	}
+	id, ok := data["id"]
+	if !ok {
+		return nil, errors.New("missing id in input data")
+	}
+	dsID, ok := data["datastore_id"]
+	if !ok {
+		return nil, errors.New("missing datastore_id in input data")
+	}
+	ds, err := datastore.FromID(client, dsID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate datastore %q: %s", dsID, err)
+	}
+	obj, err := firstclassdisk.FromID(client, id, ds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate first class disk %q: %s", id, err)
+	}
+
+	d.SetId(id)
+	if err := flattenVStorageObject(d, obj); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// flattenVStorageObject reads a VStorageObject into the supplied
+// ResourceData. The storage policy is not read back, as the policy of a first
+// class disk can only be queried through the storage policy service.
+func flattenVStorageObject(d *schema.ResourceData, obj *types.VStorageObject) error {
+	d.Set("name", obj.Config.Name)
+	d.Set("capacity", obj.Config.CapacityInMB/1024)
+	if obj.Config.KeepAfterDeleteVm != nil {
+		d.Set("keep_after_delete_vm", *obj.Config.KeepAfterDeleteVm)
+	}
+	backing, ok := obj.Config.Backing.(*types.BaseConfigInfoDiskFileBackingInfo)
+	if !ok {
+		return fmt.Errorf("first class disk %q has an unsupported backing (type %T)", obj.Config.Id.Id, obj.Config.Backing)
+	}
+	d.Set("datastore_id", backing.Datastore.Value)
+	d.Set("path", backing.FilePath)
+	d.Set("provisioning_type", backing.ProvisioningType)
+	return nil
+}
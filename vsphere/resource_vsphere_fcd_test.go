@@ -0,0 +1,200 @@
+package vsphere
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccResourceVSphereFCD_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereFCDPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereFCDExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereFCDConfig("terraform-test-fcd", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereFCDExists(true),
+					testAccResourceVSphereFCDHasNameAndCapacity("terraform-test-fcd", 1),
+					resource.TestCheckResourceAttr("vsphere_fcd.disk", "provisioning_type", "thin"),
+					resource.TestCheckResourceAttrSet("vsphere_fcd.disk", "path"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereFCD_update(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereFCDPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereFCDExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereFCDConfig("terraform-test-fcd", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereFCDExists(true),
+					testAccResourceVSphereFCDSaveID(&id),
+				),
+			},
+			{
+				Config: testAccResourceVSphereFCDConfig("terraform-test-fcd-renamed", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereFCDExists(true),
+					testAccResourceVSphereFCDHasNameAndCapacity("terraform-test-fcd-renamed", 2),
+					testAccResourceVSphereFCDHasID(&id),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereFCD_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereFCDPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereFCDExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereFCDConfig("terraform-test-fcd", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereFCDExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_fcd.disk",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["vsphere_fcd.disk"]
+					if !ok {
+						return "", errors.New("vsphere_fcd.disk not found in state")
+					}
+					b, err := json.Marshal(map[string]string{
+						"id":           rs.Primary.ID,
+						"datastore_id": rs.Primary.Attributes["datastore_id"],
+					})
+					if err != nil {
+						return "", err
+					}
+					return string(b), nil
+				},
+				Config: testAccResourceVSphereFCDConfig("terraform-test-fcd", 1),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereFCDPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_fcd acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DATASTORE") == "" {
+		t.Skip("set VSPHERE_DATASTORE to run vsphere_fcd acceptance tests")
+	}
+}
+
+func testAccResourceVSphereFCDExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := testGetFCD(s, "disk")
+		if err != nil {
+			return err
+		}
+		if obj == nil && expected {
+			return errors.New("expected first class disk to exist")
+		} else if obj != nil && !expected {
+			return errors.New("expected first class disk to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereFCDHasNameAndCapacity(name string, capacity int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := testGetFCD(s, "disk")
+		if err != nil {
+			return err
+		}
+		if name != obj.Config.Name {
+			return fmt.Errorf("expected name to be %q, got %q", name, obj.Config.Name)
+		}
+		if capacity*1024 != obj.Config.CapacityInMB {
+			return fmt.Errorf("expected capacity to be %d MB, got %d MB", capacity*1024, obj.Config.CapacityInMB)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereFCDSaveID(id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := testGetFCD(s, "disk")
+		if err != nil {
+			return err
+		}
+		*id = obj.Config.Id.Id
+		return nil
+	}
+}
+
+// testAccResourceVSphereFCDHasID checks to make sure the first class disk was
+// changed in place.
+func testAccResourceVSphereFCDHasID(id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj, err := testGetFCD(s, "disk")
+		if err != nil {
+			return err
+		}
+		if *id != obj.Config.Id.Id {
+			return fmt.Errorf("expected ID to be %q, got %q", *id, obj.Config.Id.Id)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereFCDConfig(name string, capacity int) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_fcd" "disk" {
+  name         = "%s"
+  datastore_id = "${data.vsphere_datastore.datastore.id}"
+  capacity     = %d
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		name,
+		capacity,
+	)
+}
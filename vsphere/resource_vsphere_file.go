@@ -2,8 +2,12 @@ package vsphere
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -19,6 +23,7 @@ type file struct {
 	sourceDatastore   string
 	datastore         string
 	sourceFile        string
+	content           string
 	destinationFile   string
 	createDirectories bool
 	copyFile          bool
@@ -31,6 +36,8 @@ func resourceVSphereFile() *schema.Resource {
 		Update: resourceVSphereFileUpdate,
 		Delete: resourceVSphereFileDelete,
 
+		CustomizeDiff: resourceVSphereFileCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"datacenter": {
 				Type:     schema.TypeString,
@@ -55,9 +62,21 @@ func resourceVSphereFile() *schema.Resource {
 			},
 
 			"source_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"content"},
+			},
+
+			"content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source_file", "source_datacenter", "source_datastore"},
+			},
+
+			"source_sha256": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Computed: true,
 			},
 
 			"destination_file": {
@@ -102,8 +121,10 @@ func resourceVSphereFileCreate(d *schema.ResourceData, meta interface{}) error {
 
 	if v, ok := d.GetOk("source_file"); ok {
 		f.sourceFile = v.(string)
+	} else if v, ok := d.GetOk("content"); ok {
+		f.content = v.(string)
 	} else {
-		return fmt.Errorf("source_file argument is required")
+		return fmt.Errorf("one of source_file or content is required")
 	}
 
 	if v, ok := d.GetOk("destination_file"); ok {
@@ -121,6 +142,14 @@ func resourceVSphereFileCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if !f.copyFile {
+		sum, err := f.sha256()
+		if err != nil {
+			return err
+		}
+		d.Set("source_sha256", sum)
+	}
+
 	d.SetId(fmt.Sprintf("[%v] %v/%v", f.datastore, f.datacenter, f.destinationFile))
 	log.Printf("[INFO] Created file: %s", f.destinationFile)
 
@@ -148,7 +177,7 @@ func createFile(client *govmomi.Client, f *file) error {
 		if err != nil {
 			return fmt.Errorf("error %s", err)
 		}
-		finder = finder.SetDatacenter(dc)
+		finder = finder.SetDatacenter(source_dc)
 
 		source_ds, err := getDatastore(finder, f.sourceDatastore)
 		if err != nil {
@@ -183,7 +212,12 @@ func createFile(client *govmomi.Client, f *file) error {
 		}
 
 		p := soap.DefaultUpload
-		err = client.Client.UploadFile(context.TODO(), f.sourceFile, dsurl, &p)
+		if f.sourceFile != "" {
+			err = client.Client.UploadFile(context.TODO(), f.sourceFile, dsurl, &p)
+		} else {
+			p.ContentLength = int64(len(f.content))
+			err = client.Client.Upload(context.TODO(), strings.NewReader(f.content), dsurl, &p)
+		}
 		if err != nil {
 			return fmt.Errorf("error %s", err)
 		}
@@ -217,8 +251,6 @@ func resourceVSphereFileRead(d *schema.ResourceData, meta interface{}) error {
 
 	if v, ok := d.GetOk("source_file"); ok {
 		f.sourceFile = v.(string)
-	} else {
-		return fmt.Errorf("source_file argument is required")
 	}
 
 	if v, ok := d.GetOk("destination_file"); ok {
@@ -259,6 +291,10 @@ func resourceVSphereFileUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] updating file: %#v", d)
 
+	// Use partial mode so that a checksum of contents that failed to upload is
+	// not saved, and the upload is retried on the next apply.
+	d.Partial(true)
+
 	if d.HasChange("destination_file") || d.HasChange("datacenter") || d.HasChange("datastore") {
 		// File needs to be moved, get old and new destination changes
 		var oldDataceneter, newDatacenter, oldDatastore, newDatastore, oldDestinationFile, newDestinationFile string
@@ -321,11 +357,96 @@ func resourceVSphereFileUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return err
 		}
+		d.SetPartial("datacenter")
+		d.SetPartial("datastore")
+		d.SetPartial("destination_file")
+	}
+
+	if d.HasChange("source_sha256") || d.HasChange("content") {
+		// The contents of the source have changed, so upload it again over the
+		// file at its current location.
+		f := file{
+			datastore:         d.Get("datastore").(string),
+			datacenter:        d.Get("datacenter").(string),
+			sourceFile:        d.Get("source_file").(string),
+			content:           d.Get("content").(string),
+			destinationFile:   d.Get("destination_file").(string),
+			createDirectories: d.Get("create_directories").(bool),
+		}
+		client := meta.(*VSphereClient).vimClient
+		if err := createFile(client, &f); err != nil {
+			return err
+		}
+		sum, err := f.sha256()
+		if err != nil {
+			return err
+		}
+		d.Set("source_sha256", sum)
+		d.SetPartial("source_sha256")
+		d.SetPartial("source_file")
+		d.SetPartial("content")
 	}
 
+	d.Partial(false)
 	return nil
 }
 
+// resourceVSphereFileCustomizeDiff computes the SHA-256 of the contents of
+// source_file or content, so that a change in the contents of the source
+// triggers a new upload. Copies from within vSphere are not tracked.
+func resourceVSphereFileCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("source_datastore"); ok {
+		return nil
+	}
+	if _, ok := d.GetOk("source_datacenter"); ok {
+		return nil
+	}
+	if !d.NewValueKnown("source_file") || !d.NewValueKnown("content") {
+		return nil
+	}
+	f := file{
+		sourceFile: d.Get("source_file").(string),
+		content:    d.Get("content").(string),
+	}
+	if f.sourceFile == "" && f.content == "" {
+		return nil
+	}
+	sum, err := f.sha256()
+	if err != nil {
+		// The source file may not exist yet if it is created in the same run.
+		// The checksum is recorded on upload in this case.
+		log.Printf("[DEBUG] Could not compute checksum of %q: %s", f.sourceFile, err)
+		return nil
+	}
+	if sum != d.Get("source_sha256").(string) {
+		return d.SetNew("source_sha256", sum)
+	}
+	return nil
+}
+
+// sha256 returns the hex-encoded SHA-256 of the contents of the local source
+// file, or the inline content if no source file is set.
+func (f *file) sha256() (string, error) {
+	if f.sourceFile == "" {
+		return sha256Hex(strings.NewReader(f.content))
+	}
+	src, err := os.Open(f.sourceFile)
+	if err != nil {
+		return "", fmt.Errorf("error opening source file: %s", err)
+	}
+	defer src.Close()
+	return sha256Hex(src)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of everything read from r.
+func sha256Hex(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func resourceVSphereFileDelete(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] deleting file: %#v", d)
@@ -343,8 +464,6 @@ func resourceVSphereFileDelete(d *schema.ResourceData, meta interface{}) error {
 
 	if v, ok := d.GetOk("source_file"); ok {
 		f.sourceFile = v.(string)
-	} else {
-		return fmt.Errorf("source_file argument is required")
 	}
 
 	if v, ok := d.GetOk("destination_file"); ok {
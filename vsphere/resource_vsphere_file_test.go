@@ -2,6 +2,8 @@ package vsphere
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -174,6 +176,112 @@ func TestAccResourceVSphereFile_renamePostCreation(t *testing.T) {
 	os.Remove(testVmdkFile)
 }
 
+// file upload, followed by a change of the source file contents (update)
+func TestAccResourceVSphereFile_sourceContentsChanged(t *testing.T) {
+	testVmdkFileData := []byte("# Disk DescriptorFile\n")
+	testVmdkFileDataChanged := []byte("# Disk DescriptorFile\n# Changed\n")
+	testVmdkFile := "/tmp/tf_test.vmdk"
+	err := ioutil.WriteFile(testVmdkFile, testVmdkFileData, 0644)
+	if err != nil {
+		t.Errorf("error %s", err)
+		return
+	}
+
+	datacenter := os.Getenv("VSPHERE_DATACENTER")
+	datastore := os.Getenv("VSPHERE_DATASTORE")
+	testMethod := "contents_changed"
+	resourceName := "vsphere_file." + testMethod
+	destinationFile := "tf_test_file.vmdk"
+	sourceFile := testVmdkFile
+	config := fmt.Sprintf(
+		testAccCheckVSphereFileConfig,
+		testMethod,
+		datacenter,
+		datastore,
+		sourceFile,
+		destinationFile,
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVSphereFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVSphereFileExists(resourceName, destinationFile, true),
+					resource.TestCheckResourceAttr(resourceName, "source_sha256", testAccCheckVSphereFileSHA256(testVmdkFileData)),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := ioutil.WriteFile(testVmdkFile, testVmdkFileDataChanged, 0644); err != nil {
+						panic(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVSphereFileExists(resourceName, destinationFile, true),
+					resource.TestCheckResourceAttr(resourceName, "source_sha256", testAccCheckVSphereFileSHA256(testVmdkFileDataChanged)),
+				),
+			},
+		},
+	})
+	os.Remove(testVmdkFile)
+}
+
+// file creation from inline content, followed by a change of content (update)
+func TestAccResourceVSphereFile_content(t *testing.T) {
+	datacenter := os.Getenv("VSPHERE_DATACENTER")
+	datastore := os.Getenv("VSPHERE_DATASTORE")
+	testMethod := "content"
+	resourceName := "vsphere_file." + testMethod
+	destinationFile := "tf_test_file.cfg"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccCheckEnvVariables(t, []string{"VSPHERE_DATACENTER", "VSPHERE_DATASTORE"})
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVSphereFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(
+					testAccCheckVSphereFileContentConfig,
+					testMethod,
+					datacenter,
+					datastore,
+					"first",
+					destinationFile,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVSphereFileExists(resourceName, destinationFile, true),
+					resource.TestCheckResourceAttr(resourceName, "source_sha256", testAccCheckVSphereFileSHA256([]byte("first"))),
+				),
+			},
+			{
+				Config: fmt.Sprintf(
+					testAccCheckVSphereFileContentConfig,
+					testMethod,
+					datacenter,
+					datastore,
+					"second",
+					destinationFile,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVSphereFileExists(resourceName, destinationFile, true),
+					resource.TestCheckResourceAttr(resourceName, "source_sha256", testAccCheckVSphereFileSHA256([]byte("second"))),
+				),
+			},
+		},
+	})
+}
+
 // file upload, then copy, finally the copy is renamed (moved) (update)
 func TestAccResourceVSphereFile_uploadAndCopyAndUpdate(t *testing.T) {
 	testVmdkFileData := []byte("# Disk DescriptorFile\n")
@@ -357,3 +465,17 @@ resource "vsphere_file" "%s" {
 	destination_file = "%s"
 }
 `
+const testAccCheckVSphereFileContentConfig = `
+resource "vsphere_file" "%s" {
+	datacenter = "%s"
+	datastore = "%s"
+	content = "%s"
+	destination_file = "%s"
+}
+`
+
+// testAccCheckVSphereFileSHA256 returns the hex-encoded SHA-256 of b.
+func testAccCheckVSphereFileSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,201 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/customizationspec"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/vmworkflow"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	guestOSCustomizationTypeLinux   = "Linux"
+	guestOSCustomizationTypeWindows = "Windows"
+)
+
+func resourceVSphereGuestOSCustomization() *schema.Resource {
+	// The saved spec uses the same options as the customize block in
+	// vsphere_virtual_machine, minus the ones that only apply when customizing
+	// a virtual machine directly.
+	customize := vmworkflow.VirtualMachineCustomizeSchema()
+	for _, k := range []string{"timeout", "spec_name", "reapply_on_change", "cohesity_windows_customization_options"} {
+		delete(customize, k)
+	}
+
+	return &schema.Resource{
+		Create: resourceVSphereGuestOSCustomizationCreate,
+		Read:   resourceVSphereGuestOSCustomizationRead,
+		Update: resourceVSphereGuestOSCustomizationUpdate,
+		Delete: resourceVSphereGuestOSCustomizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereGuestOSCustomizationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the customization specification.",
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the customization specification.",
+				Optional:    true,
+			},
+			"customize": {
+				Type:        schema.TypeList,
+				Description: "The customization options of the specification. One of linux_options, windows_options or windows_sysprep_text must be set.",
+				Required:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: customize},
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "The type of the customization specification, either Linux or Windows.",
+				Computed:    true,
+			},
+			"change_version": {
+				Type:        schema.TypeString,
+				Description: "The current version of the customization specification, which changes each time it is modified.",
+				Computed:    true,
+			},
+			"last_update_time": {
+				Type:        schema.TypeString,
+				Description: "The time the customization specification was last modified, in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceVSphereGuestOSCustomizationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := customizationspec.VerifySupport(client); err != nil {
+		return err
+	}
+	item, err := expandGuestOSCustomizationSpecItem(d)
+	if err != nil {
+		return err
+	}
+	if err := customizationspec.Create(client, item); err != nil {
+		return fmt.Errorf("could not create customization specification: %s", err)
+	}
+
+	d.SetId(item.Info.Name)
+	return resourceVSphereGuestOSCustomizationRead(d, meta)
+}
+
+func resourceVSphereGuestOSCustomizationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := customizationspec.VerifySupport(client); err != nil {
+		return err
+	}
+	item, err := customizationspec.FromName(client, d.Id())
+	if err != nil {
+		if viapi.IsAnyNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching customization specification: %s", err)
+	}
+
+	flattenGuestOSCustomizationSpecInfo(d, item.Info)
+	if err := vmworkflow.FlattenCustomizationSpec(d, item.Spec); err != nil {
+		return fmt.Errorf("error setting customize: %s", err)
+	}
+	return nil
+}
+
+func resourceVSphereGuestOSCustomizationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := customizationspec.VerifySupport(client); err != nil {
+		return err
+	}
+	if d.HasChange("name") {
+		if err := customizationspec.Rename(client, d.Id(), d.Get("name").(string)); err != nil {
+			return fmt.Errorf("could not rename customization specification: %s", err)
+		}
+		d.SetId(d.Get("name").(string))
+	}
+
+	if d.HasChange("description") || d.HasChange("customize") {
+		// Overwriting a spec requires the current change version, to guard
+		// against concurrent modification.
+		current, err := customizationspec.FromName(client, d.Id())
+		if err != nil {
+			return fmt.Errorf("error fetching customization specification: %s", err)
+		}
+		item, err := expandGuestOSCustomizationSpecItem(d)
+		if err != nil {
+			return err
+		}
+		item.Info.ChangeVersion = current.Info.ChangeVersion
+		if err := customizationspec.Overwrite(client, item); err != nil {
+			return fmt.Errorf("could not update customization specification: %s", err)
+		}
+	}
+	return resourceVSphereGuestOSCustomizationRead(d, meta)
+}
+
+func resourceVSphereGuestOSCustomizationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*VSphereClient).vimClient
+	if err := customizationspec.VerifySupport(client); err != nil {
+		return err
+	}
+	if err := customizationspec.Delete(client, d.Id()); err != nil {
+		return fmt.Errorf("could not delete customization specification: %s", err)
+	}
+	return nil
+}
+
+func resourceVSphereGuestOSCustomizationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := customizationspec.VerifySupport(client); err != nil {
+		return nil, err
+	}
+	if _, err := customizationspec.FromName(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("cannot locate customization specification %q: %s", d.Id(), err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandGuestOSCustomizationSpecItem reads certain ResourceData keys and
+// returns a CustomizationSpecItem. The type of the specification is derived
+// from the identity settings in the customize block.
+func expandGuestOSCustomizationSpecItem(d *schema.ResourceData) (types.CustomizationSpecItem, error) {
+	family := vmworkflow.CustomizationSpecFamily(d, "")
+	var specType string
+	switch family {
+	case string(types.VirtualMachineGuestOsFamilyLinuxGuest):
+		specType = guestOSCustomizationTypeLinux
+	case string(types.VirtualMachineGuestOsFamilyWindowsGuest):
+		specType = guestOSCustomizationTypeWindows
+	default:
+		return types.CustomizationSpecItem{}, errors.New("one of linux_options, windows_options or windows_sysprep_text must be set in customize")
+	}
+
+	return types.CustomizationSpecItem{
+		Info: types.CustomizationSpecInfo{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+			Type:        specType,
+		},
+		Spec: vmworkflow.ExpandCustomizationSpec(d, family, ""),
+	}, nil
+}
+
+// flattenGuestOSCustomizationSpecInfo reads a CustomizationSpecInfo into the
+// supplied ResourceData.
+func flattenGuestOSCustomizationSpecInfo(d *schema.ResourceData, obj types.CustomizationSpecInfo) {
+	d.Set("name", obj.Name)
+	d.Set("description", obj.Description)
+	d.Set("type", obj.Type)
+	d.Set("change_version", obj.ChangeVersion)
+	if obj.LastUpdateTime != nil {
+		d.Set("last_update_time", obj.LastUpdateTime.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,196 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereGuestOSCustomization_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereGuestOSCustomizationExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereGuestOSCustomizationConfigLinux("terraform-test-spec", "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereGuestOSCustomizationExists(true),
+					testAccResourceVSphereGuestOSCustomizationHasName("terraform-test-spec"),
+					testAccResourceVSphereGuestOSCustomizationHasLinuxDomain("example.com"),
+					resource.TestCheckResourceAttr("vsphere_guest_os_customization.spec", "type", "Linux"),
+					resource.TestCheckResourceAttrSet("vsphere_guest_os_customization.spec", "change_version"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereGuestOSCustomization_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereGuestOSCustomizationExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereGuestOSCustomizationConfigLinux("terraform-test-spec", "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereGuestOSCustomizationExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereGuestOSCustomizationConfigLinux("terraform-test-spec-renamed", "example.org"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereGuestOSCustomizationExists(true),
+					testAccResourceVSphereGuestOSCustomizationHasName("terraform-test-spec-renamed"),
+					testAccResourceVSphereGuestOSCustomizationHasLinuxDomain("example.org"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereGuestOSCustomization_windows(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereGuestOSCustomizationExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereGuestOSCustomizationConfigWindows(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereGuestOSCustomizationExists(true),
+					resource.TestCheckResourceAttr("vsphere_guest_os_customization.spec", "type", "Windows"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereGuestOSCustomization_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereGuestOSCustomizationExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereGuestOSCustomizationConfigLinux("terraform-test-spec", "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereGuestOSCustomizationExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_guest_os_customization.spec",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					item, err := testGetGuestOSCustomization(s, "spec")
+					if err != nil {
+						return "", err
+					}
+					if item == nil {
+						return "", errors.New("customization specification does not exist")
+					}
+					return item.Info.Name, nil
+				},
+				Config: testAccResourceVSphereGuestOSCustomizationConfigLinux("terraform-test-spec", "example.com"),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereGuestOSCustomizationExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		item, err := testGetGuestOSCustomization(s, "spec")
+		if err != nil {
+			return err
+		}
+		if item == nil && expected {
+			return errors.New("expected customization specification to exist")
+		} else if item != nil && !expected {
+			return errors.New("expected customization specification to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereGuestOSCustomizationHasName(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		item, err := testGetGuestOSCustomization(s, "spec")
+		if err != nil {
+			return err
+		}
+		if expected != item.Info.Name {
+			return fmt.Errorf("expected name to be %q, got %q", expected, item.Info.Name)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereGuestOSCustomizationHasLinuxDomain(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		item, err := testGetGuestOSCustomization(s, "spec")
+		if err != nil {
+			return err
+		}
+		prep, ok := item.Spec.Identity.(*types.CustomizationLinuxPrep)
+		if !ok {
+			return fmt.Errorf("expected Linux identity, got %T", item.Spec.Identity)
+		}
+		if expected != prep.Domain {
+			return fmt.Errorf("expected domain to be %q, got %q", expected, prep.Domain)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereGuestOSCustomizationConfigLinux(name, domain string) string {
+	return fmt.Sprintf(`
+resource "vsphere_guest_os_customization" "spec" {
+  name        = "%s"
+  description = "terraform-test"
+
+  customize {
+    linux_options {
+      host_name = "terraform-test"
+      domain    = "%s"
+    }
+
+    network_interface {}
+  }
+}
+`,
+		name,
+		domain,
+	)
+}
+
+func testAccResourceVSphereGuestOSCustomizationConfigWindows() string {
+	return `
+resource "vsphere_guest_os_customization" "spec" {
+  name = "terraform-test-spec-windows"
+
+  customize {
+    windows_options {
+      computer_name  = "terraform-test"
+      workgroup      = "test"
+      admin_password = "VMw4re"
+    }
+
+    network_interface {}
+  }
+}
+`
+}
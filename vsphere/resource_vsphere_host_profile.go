@@ -0,0 +1,358 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/clustercomputeresource"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostprofile"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereHostProfileName = "vsphere_host_profile"
+
+func resourceVSphereHostProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereHostProfileCreate,
+		Read:   resourceVSphereHostProfileRead,
+		Update: resourceVSphereHostProfileUpdate,
+		Delete: resourceVSphereHostProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereHostProfileImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the host profile.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the host profile.",
+			},
+			"reference_host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The managed object ID of the host to extract the profile from. Changing this re-extracts the profile from the new host.",
+			},
+			"cluster_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The managed object IDs of the clusters to attach the host profile to.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"host_system_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The managed object IDs of the hosts to attach the host profile to.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"remediate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Apply the host profile to non-compliant attached hosts, including the hosts in attached clusters, after compliance has been checked.",
+			},
+			"compliance_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The overall compliance status of the entities attached to the host profile.",
+			},
+			"compliance": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The compliance status of each entity attached to the host profile.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"entity_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The managed object ID of the attached entity.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The compliance status of the entity. One of compliant, nonCompliant, unknown, or running.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVSphereHostProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereHostProfileIDString(d))
+	client, err := resourceVSphereHostProfileClient(meta)
+	if err != nil {
+		return err
+	}
+
+	host, err := hostsystem.FromID(client, d.Get("reference_host_system_id").(string))
+	if err != nil {
+		return fmt.Errorf("cannot locate reference host: %s", err)
+	}
+	id, err := hostprofile.Create(client, d.Get("name").(string), d.Get("description").(string), host)
+	if err != nil {
+		return fmt.Errorf("error creating host profile: %s", err)
+	}
+	d.SetId(id)
+
+	if err := hostprofile.Associate(client, id, resourceVSphereHostProfileEntities(d)); err != nil {
+		return fmt.Errorf("error attaching host profile: %s", err)
+	}
+	if err := resourceVSphereHostProfileCheckAndRemediate(d, client); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereHostProfileIDString(d))
+	return resourceVSphereHostProfileRead(d, meta)
+}
+
+func resourceVSphereHostProfileRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereHostProfileIDString(d))
+	client, err := resourceVSphereHostProfileClient(meta)
+	if err != nil {
+		return err
+	}
+
+	props, err := hostprofile.Properties(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Resource has been deleted", resourceVSphereHostProfileIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	var refHostID string
+	if props.ReferenceHost != nil {
+		refHostID = props.ReferenceHost.Value
+	}
+	var description string
+	if props.Config != nil {
+		description = props.Config.GetProfileConfigInfo().Annotation
+	}
+	var clusterIDs, hostIDs []string
+	for _, ref := range props.Entity {
+		switch ref.Type {
+		case "ClusterComputeResource":
+			clusterIDs = append(clusterIDs, ref.Value)
+		case "HostSystem":
+			hostIDs = append(hostIDs, ref.Value)
+		}
+	}
+
+	results, err := hostprofile.QueryComplianceStatus(client, d.Id(), props.Entity)
+	if err != nil {
+		return fmt.Errorf("error querying compliance status: %s", err)
+	}
+
+	err = structure.SetBatch(d, map[string]interface{}{
+		"name":                     props.Name,
+		"description":              description,
+		"reference_host_system_id": refHostID,
+		"cluster_ids":              clusterIDs,
+		"host_system_ids":          hostIDs,
+		"compliance_status":        props.ComplianceStatus,
+		"compliance":               flattenComplianceResults(results),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereHostProfileIDString(d))
+	return nil
+}
+
+func resourceVSphereHostProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereHostProfileIDString(d))
+	client, err := resourceVSphereHostProfileClient(meta)
+	if err != nil {
+		return err
+	}
+
+	// The profile is only re-extracted when the reference host changes, as this
+	// overwrites any changes made to the profile since it was extracted.
+	switch {
+	case d.HasChange("reference_host_system_id"):
+		host, err := hostsystem.FromID(client, d.Get("reference_host_system_id").(string))
+		if err != nil {
+			return fmt.Errorf("cannot locate reference host: %s", err)
+		}
+		if err := hostprofile.Update(client, d.Id(), d.Get("name").(string), d.Get("description").(string), host); err != nil {
+			return fmt.Errorf("error updating host profile: %s", err)
+		}
+	case d.HasChange("name") || d.HasChange("description"):
+		if err := hostprofile.UpdateNameAndDescription(client, d.Id(), d.Get("name").(string), d.Get("description").(string)); err != nil {
+			return fmt.Errorf("error updating host profile: %s", err)
+		}
+	}
+
+	var added, removed []types.ManagedObjectReference
+	for k, t := range map[string]string{"cluster_ids": "ClusterComputeResource", "host_system_ids": "HostSystem"} {
+		o, n := d.GetChange(k)
+		added = append(added, structure.SliceInterfacesToManagedObjectReferences(n.(*schema.Set).Difference(o.(*schema.Set)).List(), t)...)
+		removed = append(removed, structure.SliceInterfacesToManagedObjectReferences(o.(*schema.Set).Difference(n.(*schema.Set)).List(), t)...)
+	}
+	if err := hostprofile.Dissociate(client, d.Id(), removed); err != nil {
+		return fmt.Errorf("error detaching host profile: %s", err)
+	}
+	if err := hostprofile.Associate(client, d.Id(), added); err != nil {
+		return fmt.Errorf("error attaching host profile: %s", err)
+	}
+
+	if err := resourceVSphereHostProfileCheckAndRemediate(d, client); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereHostProfileIDString(d))
+	return resourceVSphereHostProfileRead(d, meta)
+}
+
+func resourceVSphereHostProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereHostProfileIDString(d))
+	client, err := resourceVSphereHostProfileClient(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := hostprofile.Dissociate(client, d.Id(), resourceVSphereHostProfileEntities(d)); err != nil {
+		return fmt.Errorf("error detaching host profile: %s", err)
+	}
+	if err := hostprofile.Delete(client, d.Id()); err != nil {
+		return fmt.Errorf("error deleting host profile: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereHostProfileIDString(d))
+	return nil
+}
+
+func resourceVSphereHostProfileImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, err := resourceVSphereHostProfileClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hostprofile.Properties(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error loading host profile: %s", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereHostProfileCheckAndRemediate runs a compliance check against
+// all entities attached to the host profile. If remediate is set, any
+// non-compliant hosts, including hosts that are members of non-compliant
+// clusters, are remediated and compliance is checked again.
+func resourceVSphereHostProfileCheckAndRemediate(d *schema.ResourceData, client *govmomi.Client) error {
+	entities := resourceVSphereHostProfileEntities(d)
+	results, err := hostprofile.CheckCompliance(client, d.Id(), entities)
+	if err != nil {
+		return fmt.Errorf("error checking compliance: %s", err)
+	}
+	if !d.Get("remediate").(bool) {
+		return nil
+	}
+
+	hosts, err := resourceVSphereHostProfileNonCompliantHosts(client, results)
+	if err != nil {
+		return err
+	}
+	if len(hosts) < 1 {
+		log.Printf("[DEBUG] %s: All attached entities are compliant", resourceVSphereHostProfileIDString(d))
+		return nil
+	}
+	for _, host := range hosts {
+		if err := hostprofile.Remediate(client, d.Id(), host); err != nil {
+			return fmt.Errorf("error remediating host %q: %s", host.Name(), err)
+		}
+	}
+
+	if _, err := hostprofile.CheckCompliance(client, d.Id(), entities); err != nil {
+		return fmt.Errorf("error checking compliance after remediation: %s", err)
+	}
+	return nil
+}
+
+// resourceVSphereHostProfileNonCompliantHosts returns the hosts that need to
+// be remediated for the supplied compliance results. Non-compliant clusters
+// are expanded to their member hosts.
+func resourceVSphereHostProfileNonCompliantHosts(client *govmomi.Client, results []types.ComplianceResult) ([]*object.HostSystem, error) {
+	var hosts []*object.HostSystem
+	for _, result := range results {
+		if result.Entity == nil || result.ComplianceStatus != string(types.ComplianceResultStatusNonCompliant) {
+			continue
+		}
+		switch result.Entity.Type {
+		case "HostSystem":
+			host, err := hostsystem.FromID(client, result.Entity.Value)
+			if err != nil {
+				return nil, err
+			}
+			hosts = append(hosts, host)
+		case "ClusterComputeResource":
+			cluster, err := clustercomputeresource.FromID(client, result.Entity.Value)
+			if err != nil {
+				return nil, err
+			}
+			props, err := clustercomputeresource.Properties(cluster)
+			if err != nil {
+				return nil, err
+			}
+			for _, ref := range props.Host {
+				hosts = append(hosts, object.NewHostSystem(client.Client, ref))
+			}
+		}
+	}
+	return hosts, nil
+}
+
+// resourceVSphereHostProfileEntities returns the managed object references
+// of all clusters and hosts configured to be attached to the host profile.
+func resourceVSphereHostProfileEntities(d *schema.ResourceData) []types.ManagedObjectReference {
+	var entities []types.ManagedObjectReference
+	entities = append(entities, structure.SliceInterfacesToManagedObjectReferences(d.Get("cluster_ids").(*schema.Set).List(), "ClusterComputeResource")...)
+	entities = append(entities, structure.SliceInterfacesToManagedObjectReferences(d.Get("host_system_ids").(*schema.Set).List(), "HostSystem")...)
+	return entities
+}
+
+// flattenComplianceResults converts a list of ComplianceResult into a list
+// of compliance entries for state, sorted by entity ID.
+func flattenComplianceResults(results []types.ComplianceResult) []interface{} {
+	var out []interface{}
+	for _, result := range results {
+		if result.Entity == nil {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"entity_id": result.Entity.Value,
+			"status":    result.ComplianceStatus,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].(map[string]interface{})["entity_id"].(string) < out[j].(map[string]interface{})["entity_id"].(string)
+	})
+	return out
+}
+
+// resourceVSphereHostProfileIDString prints a friendly string for the
+// vsphere_host_profile resource.
+func resourceVSphereHostProfileIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereHostProfileName)
+}
+
+func resourceVSphereHostProfileClient(meta interface{}) (*govmomi.Client, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := viapi.ValidateVirtualCenter(client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
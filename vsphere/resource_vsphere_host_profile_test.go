@@ -0,0 +1,188 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostprofile"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+func TestAccResourceVSphereHostProfile_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostProfilePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostProfileCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostProfileConfig("terraform-test-host-profile", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostProfileCheckExists(true),
+					testAccResourceVSphereHostProfileCheckName("terraform-test-host-profile"),
+					testAccResourceVSphereHostProfileCheckEntityCount(0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostProfile_attachAndRename(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostProfilePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostProfileCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostProfileConfig("terraform-test-host-profile", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostProfileCheckExists(true),
+					testAccResourceVSphereHostProfileCheckEntityCount(0),
+				),
+			},
+			{
+				Config: testAccResourceVSphereHostProfileConfig("terraform-test-host-profile-renamed", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostProfileCheckExists(true),
+					testAccResourceVSphereHostProfileCheckName("terraform-test-host-profile-renamed"),
+					testAccResourceVSphereHostProfileCheckEntityCount(1),
+					resource.TestCheckResourceAttr("vsphere_host_profile.profile", "compliance.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereHostProfile_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereHostProfilePreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereHostProfileCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereHostProfileConfig("terraform-test-host-profile", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereHostProfileCheckExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_host_profile.profile",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereHostProfilePreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_host_profile acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_host_profile acceptance tests")
+	}
+}
+
+func testAccResourceVSphereHostProfileCheckExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := testGetHostProfileProperties(s, "profile")
+		if err != nil {
+			if viapi.IsManagedObjectNotFoundError(err) && expected == false {
+				// Expected missing
+				return nil
+			}
+			return err
+		}
+		if !expected {
+			return errors.New("expected host profile to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostProfileCheckName(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetHostProfileProperties(s, "profile")
+		if err != nil {
+			return err
+		}
+		if expected != props.Name {
+			return fmt.Errorf("expected name to be %q, got %q", expected, props.Name)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereHostProfileCheckEntityCount(expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetHostProfileProperties(s, "profile")
+		if err != nil {
+			return err
+		}
+		actual := len(props.Entity)
+		if expected != actual {
+			return fmt.Errorf("expected %d attached entities, got %d", expected, actual)
+		}
+		return nil
+	}
+}
+
+// testGetHostProfileProperties is a convenience method to fetch the
+// properties of a host profile managed by a vsphere_host_profile resource.
+func testGetHostProfileProperties(s *terraform.State, resourceName string) (*mo.HostProfile, error) {
+	vars, err := testClientVariablesForResource(s, fmt.Sprintf("%s.%s", resourceVSphereHostProfileName, resourceName))
+	if err != nil {
+		return nil, err
+	}
+	return hostprofile.Properties(vars.client, vars.resourceID)
+}
+
+func testAccResourceVSphereHostProfileConfig(name string, attach bool) string {
+	var hostIDs string
+	if attach {
+		hostIDs = `host_system_ids          = ["${data.vsphere_host.esxi_host.id}"]`
+	}
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "esxi_host" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "${var.esxi_host}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_host_profile" "profile" {
+  name                     = "%s"
+  description              = "Managed by Terraform"
+  reference_host_system_id = "${data.vsphere_host.esxi_host.id}"
+  %s
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		name,
+		hostIDs,
+	)
+}
@@ -0,0 +1,158 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/authorization"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+)
+
+const resourceVSphereRoleName = "vsphere_role"
+
+func resourceVSphereRole() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceVSphereRoleCreate,
+		Read:          resourceVSphereRoleRead,
+		Update:        resourceVSphereRoleUpdate,
+		Delete:        resourceVSphereRoleDelete,
+		CustomizeDiff: resourceVSphereRoleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereRoleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the role.",
+				Required:    true,
+			},
+			"privileges": {
+				Type:        schema.TypeSet,
+				Description: "The IDs of the privileges granted by the role, such as VirtualMachine.Interact.PowerOn. The System.Anonymous, System.Read, and System.View privileges are always granted and do not need to be specified.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceVSphereRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	privileges := structure.SliceInterfacesToStrings(d.Get("privileges").(*schema.Set).List())
+
+	id, err := authorization.CreateRole(client, d.Get("name").(string), privileges)
+	if err != nil {
+		return fmt.Errorf("could not create role: %s", err)
+	}
+
+	d.SetId(fmt.Sprint(id))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereRoleIDString(d))
+	return resourceVSphereRoleRead(d, meta)
+}
+
+func resourceVSphereRoleRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	id, err := resourceVSphereRoleParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	role, err := authorization.RoleFromID(client, id)
+	if err != nil {
+		return fmt.Errorf("error fetching role: %s", err)
+	}
+	if role == nil {
+		log.Printf("[DEBUG] %s: Role is missing, marking as deleted", resourceVSphereRoleIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", role.Name)
+	if err := d.Set("privileges", authorization.UserPrivileges(role)); err != nil {
+		return fmt.Errorf("error setting privileges: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereRoleIDString(d))
+	return nil
+}
+
+func resourceVSphereRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	id, err := resourceVSphereRoleParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	privileges := structure.SliceInterfacesToStrings(d.Get("privileges").(*schema.Set).List())
+
+	if err := authorization.UpdateRole(client, id, d.Get("name").(string), privileges); err != nil {
+		return fmt.Errorf("could not update role: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereRoleIDString(d))
+	return resourceVSphereRoleRead(d, meta)
+}
+
+func resourceVSphereRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereRoleIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	id, err := resourceVSphereRoleParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := authorization.RemoveRole(client, id); err != nil {
+		return fmt.Errorf("could not remove role: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereRoleIDString(d))
+	return nil
+}
+
+// resourceVSphereRoleCustomizeDiff checks the privileges in the configuration
+// against the privileges defined on the connected endpoint, so that unknown
+// privileges are reported at plan time. Validation is skipped if privileges
+// is not known yet.
+func resourceVSphereRoleCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning diff customization and validation", resourceVSphereRoleIDString(d))
+	if !d.NewValueKnown("privileges") {
+		log.Printf("[DEBUG] %s: privileges not known yet, skipping validation", resourceVSphereRoleIDString(d))
+		return nil
+	}
+	client := meta.(*VSphereClient).vimClient
+	privileges := structure.SliceInterfacesToStrings(d.Get("privileges").(*schema.Set).List())
+	if err := authorization.ValidatePrivileges(client, privileges); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] %s: Diff customization and validation complete", resourceVSphereRoleIDString(d))
+	return nil
+}
+
+func resourceVSphereRoleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	role, err := authorization.RoleFromName(client, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if role.System {
+		return nil, fmt.Errorf("role %q is a system role and cannot be managed", role.Name)
+	}
+
+	d.SetId(fmt.Sprint(role.RoleId))
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereRoleParseID parses the numeric role ID out of a resource ID.
+func resourceVSphereRoleParseID(id string) (int32, error) {
+	i, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid role ID %q: %s", id, err)
+	}
+	return int32(i), nil
+}
+
+// resourceVSphereRoleIDString prints a friendly string for the vsphere_role
+// resource.
+func resourceVSphereRoleIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereRoleName)
+}
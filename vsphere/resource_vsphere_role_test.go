@@ -0,0 +1,191 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/authorization"
+)
+
+func TestAccResourceVSphereRole_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereRoleConfig("terraform-test-role", testAccResourceVSphereRolePrivileges),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereRoleExists(true),
+					testAccResourceVSphereRoleHasName("terraform-test-role"),
+					testAccResourceVSphereRoleHasPrivileges(testAccResourceVSphereRolePrivileges),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereRole_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereRoleConfig("terraform-test-role", testAccResourceVSphereRolePrivileges),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereRoleExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereRoleConfig("terraform-test-role-renamed", testAccResourceVSphereRolePrivilegesAlt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereRoleExists(true),
+					testAccResourceVSphereRoleHasName("terraform-test-role-renamed"),
+					testAccResourceVSphereRoleHasPrivileges(testAccResourceVSphereRolePrivilegesAlt),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereRole_badPrivilege(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereRoleConfig("terraform-test-role", []string{"Terraform.Bad.Privilege"}),
+				ExpectError: regexp.MustCompile("unknown privileges: Terraform.Bad.Privilege"),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereRole_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereRoleExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereRoleConfig("terraform-test-role", testAccResourceVSphereRolePrivileges),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereRoleExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_role.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					role, err := testGetRole(s, "role")
+					if err != nil {
+						return "", err
+					}
+					if role == nil {
+						return "", errors.New("role does not exist")
+					}
+					return role.Name, nil
+				},
+				Config: testAccResourceVSphereRoleConfig("terraform-test-role", testAccResourceVSphereRolePrivileges),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereRoleExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		role, err := testGetRole(s, "role")
+		if err != nil {
+			return err
+		}
+		if role == nil && expected {
+			return errors.New("expected role to exist")
+		} else if role != nil && !expected {
+			return errors.New("expected role to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereRoleHasName(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		role, err := testGetRole(s, "role")
+		if err != nil {
+			return err
+		}
+		if expected != role.Name {
+			return fmt.Errorf("expected name to be %q, got %q", expected, role.Name)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereRoleHasPrivileges(expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		role, err := testGetRole(s, "role")
+		if err != nil {
+			return err
+		}
+		actual := authorization.UserPrivileges(role)
+		sort.Strings(actual)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected privileges to be %q, got %q", expected, actual)
+		}
+		return nil
+	}
+}
+
+var testAccResourceVSphereRolePrivileges = []string{
+	"VirtualMachine.Interact.PowerOff",
+	"VirtualMachine.Interact.PowerOn",
+}
+
+var testAccResourceVSphereRolePrivilegesAlt = []string{
+	"Datastore.Browse",
+	"VirtualMachine.Interact.ConsoleInteract",
+	"VirtualMachine.Interact.PowerOn",
+}
+
+func testAccResourceVSphereRoleConfig(name string, privileges []string) string {
+	return fmt.Sprintf(`
+variable "privileges" {
+  default = [%s]
+}
+
+resource "vsphere_role" "role" {
+  name       = "%s"
+  privileges = "${var.privileges}"
+}
+`,
+		testAccResourceVSphereRoleQuotedList(privileges),
+		name,
+	)
+}
+
+// testAccResourceVSphereRoleQuotedList renders a list of strings as the
+// contents of an HCL list.
+func testAccResourceVSphereRoleQuotedList(l []string) string {
+	var quoted []string
+	for _, v := range l {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(quoted, ", ")
+}
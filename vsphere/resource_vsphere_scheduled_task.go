@@ -0,0 +1,152 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/scheduledtask"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+)
+
+const resourceVSphereScheduledTaskName = "vsphere_scheduled_task"
+
+func resourceVSphereScheduledTask() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"entity_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The managed object ID of the virtual machine, host, or cluster to run the task against.",
+		},
+		"entity_type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The managed object type of the entity to run the task against. Can be one of VirtualMachine, HostSystem, or ClusterComputeResource.",
+			ValidateFunc: validation.StringInSlice(scheduledTaskEntityTypeAllowedValues, false),
+		},
+	}
+	structure.MergeSchema(s, schemaScheduledTaskSpec())
+
+	return &schema.Resource{
+		Create:        resourceVSphereScheduledTaskCreate,
+		Read:          resourceVSphereScheduledTaskRead,
+		Update:        resourceVSphereScheduledTaskUpdate,
+		Delete:        resourceVSphereScheduledTaskDelete,
+		CustomizeDiff: resourceVSphereScheduledTaskCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereScheduledTaskImport,
+		},
+		Schema: s,
+	}
+}
+
+func resourceVSphereScheduledTaskCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := scheduledtask.VerifySupport(client); err != nil {
+		return err
+	}
+	entity, err := managedEntityReferenceFromID(client, d.Get("entity_type").(string), d.Get("entity_id").(string))
+	if err != nil {
+		return err
+	}
+	spec, err := expandScheduledTaskSpec(d)
+	if err != nil {
+		return err
+	}
+
+	id, err := scheduledtask.Create(client, entity, spec)
+	if err != nil {
+		return fmt.Errorf("error creating scheduled task: %s", err)
+	}
+	d.SetId(id)
+
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereScheduledTaskIDString(d))
+	return resourceVSphereScheduledTaskRead(d, meta)
+}
+
+func resourceVSphereScheduledTaskRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := scheduledtask.VerifySupport(client); err != nil {
+		return err
+	}
+	props, err := scheduledtask.Properties(client, d.Id())
+	if err != nil {
+		if viapi.IsManagedObjectNotFoundError(err) {
+			log.Printf("[DEBUG] %s: Scheduled task is missing, marking as deleted", resourceVSphereScheduledTaskIDString(d))
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error fetching scheduled task properties: %s", err)
+	}
+
+	d.Set("entity_id", props.Info.Entity.Value)
+	d.Set("entity_type", props.Info.Entity.Type)
+	if err := flattenScheduledTaskInfo(d, &props.Info); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereScheduledTaskIDString(d))
+	return nil
+}
+
+func resourceVSphereScheduledTaskUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := scheduledtask.VerifySupport(client); err != nil {
+		return err
+	}
+	spec, err := expandScheduledTaskSpec(d)
+	if err != nil {
+		return err
+	}
+	if err := scheduledtask.Reconfigure(client, d.Id(), spec); err != nil {
+		return fmt.Errorf("error reconfiguring scheduled task: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereScheduledTaskIDString(d))
+	return resourceVSphereScheduledTaskRead(d, meta)
+}
+
+func resourceVSphereScheduledTaskDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereScheduledTaskIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	if err := scheduledtask.VerifySupport(client); err != nil {
+		return err
+	}
+	if err := scheduledtask.Remove(client, d.Id()); err != nil {
+		return fmt.Errorf("error removing scheduled task: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereScheduledTaskIDString(d))
+	return nil
+}
+
+func resourceVSphereScheduledTaskCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("entity_type") || !d.NewValueKnown("action") {
+		return nil
+	}
+	return validateScheduledTaskAction(d.Get("entity_type").(string), d.Get("action").(string))
+}
+
+func resourceVSphereScheduledTaskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	if err := scheduledtask.VerifySupport(client); err != nil {
+		return nil, err
+	}
+	if _, err := scheduledtask.Properties(client, d.Id()); err != nil {
+		return nil, fmt.Errorf("error locating scheduled task %q: %s", d.Id(), err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVSphereScheduledTaskIDString prints a friendly string for the
+// vsphere_scheduled_task resource.
+func resourceVSphereScheduledTaskIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereScheduledTaskName)
+}
@@ -0,0 +1,343 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/viapi"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualmachine"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereScheduledTask_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigDailyPowerOff(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+					testAccResourceVSphereScheduledTaskHasMethod("PowerOffVM_Task"),
+					testAccResourceVSphereScheduledTaskHasScheduler(&types.DailyTaskScheduler{}),
+					resource.TestCheckResourceAttrSet("vsphere_scheduled_task.task", "next_run_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereScheduledTask_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigDailyPowerOff(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+				),
+			},
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigWeeklySnapshot(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+					testAccResourceVSphereScheduledTaskHasMethod("CreateSnapshot_Task"),
+					testAccResourceVSphereScheduledTaskHasScheduler(&types.WeeklyTaskScheduler{}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereScheduledTask_cluster(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigClusterDRS(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+					testAccResourceVSphereScheduledTaskHasMethod("ReconfigureComputeResource_Task"),
+					resource.TestCheckResourceAttr("vsphere_scheduled_task.task", "drs_automation_level", "fullyAutomated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereScheduledTask_badAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceVSphereScheduledTaskConfigBadAction,
+				ExpectError: regexp.MustCompile(`action "create_snapshot" is not supported for entity type "HostSystem"`),
+				PlanOnly:    true,
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereScheduledTask_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereScheduledTaskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereScheduledTaskExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereScheduledTaskConfigWeeklySnapshot(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereScheduledTaskExists(true),
+				),
+			},
+			{
+				ResourceName:            "vsphere_scheduled_task.task",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"state", "last_run_time", "last_run_error", "next_run_time"},
+				Config:                  testAccResourceVSphereScheduledTaskConfigWeeklySnapshot(),
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereScheduledTaskPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_scheduled_task acceptance tests")
+	}
+	if os.Getenv("VSPHERE_DATASTORE") == "" {
+		t.Skip("set VSPHERE_DATASTORE to run vsphere_scheduled_task acceptance tests")
+	}
+	if os.Getenv("VSPHERE_CLUSTER") == "" {
+		t.Skip("set VSPHERE_CLUSTER to run vsphere_scheduled_task acceptance tests")
+	}
+	if os.Getenv("VSPHERE_NETWORK_LABEL_PXE") == "" {
+		t.Skip("set VSPHERE_NETWORK_LABEL_PXE to run vsphere_scheduled_task acceptance tests")
+	}
+}
+
+func testAccResourceVSphereScheduledTaskExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := testGetScheduledTask(s, "task")
+		if err != nil {
+			if !expected {
+				switch {
+				case viapi.IsManagedObjectNotFoundError(err):
+					fallthrough
+				case virtualmachine.IsUUIDNotFoundError(err):
+					return nil
+				}
+			}
+			return err
+		}
+		if !expected {
+			return errors.New("expected scheduled task to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereScheduledTaskHasMethod(expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetScheduledTask(s, "task")
+		if err != nil {
+			return err
+		}
+		action, ok := props.Info.Action.(*types.MethodAction)
+		if !ok {
+			return fmt.Errorf("unexpected action type %T", props.Info.Action)
+		}
+		if action.Name != expected {
+			return fmt.Errorf("expected method to be %q, got %q", expected, action.Name)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereScheduledTaskHasScheduler(expected types.BaseTaskScheduler) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		props, err := testGetScheduledTask(s, "task")
+		if err != nil {
+			return err
+		}
+		if fmt.Sprintf("%T", props.Info.Scheduler) != fmt.Sprintf("%T", expected) {
+			return fmt.Errorf("expected scheduler to be %T, got %T", expected, props.Info.Scheduler)
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereScheduledTaskConfigBase() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+variable "cluster" {
+  default = "%s"
+}
+
+variable "network_label" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "datastore" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "${var.cluster}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+data "vsphere_network" "network" {
+  name          = "${var.network_label}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_virtual_machine" "vm" {
+  name             = "terraform-test"
+  resource_pool_id = "${data.vsphere_compute_cluster.cluster.resource_pool_id}"
+  datastore_id     = "${data.vsphere_datastore.datastore.id}"
+
+  num_cpus = 2
+  memory   = 2048
+  guest_id = "other3xLinux64Guest"
+
+  wait_for_guest_net_timeout = -1
+
+  network_interface {
+    network_id = "${data.vsphere_network.network.id}"
+  }
+
+  disk {
+    label = "disk0"
+    size  = 20
+  }
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		os.Getenv("VSPHERE_CLUSTER"),
+		os.Getenv("VSPHERE_NETWORK_LABEL_PXE"),
+	)
+}
+
+func testAccResourceVSphereScheduledTaskConfigDailyPowerOff() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_scheduled_task" "task" {
+  name        = "terraform-test-task"
+  entity_id   = "${vsphere_virtual_machine.vm.moid}"
+  entity_type = "VirtualMachine"
+  frequency   = "daily"
+  hour        = 23
+  minute      = 30
+  action      = "power_off"
+}
+`,
+		testAccResourceVSphereScheduledTaskConfigBase(),
+	)
+}
+
+func testAccResourceVSphereScheduledTaskConfigWeeklySnapshot() string {
+	return fmt.Sprintf(`
+%s
+
+resource "vsphere_scheduled_task" "task" {
+  name          = "terraform-test-task"
+  description   = "Managed by Terraform"
+  entity_id     = "${vsphere_virtual_machine.vm.moid}"
+  entity_type   = "VirtualMachine"
+  frequency     = "weekly"
+  days_of_week  = ["saturday", "sunday"]
+  hour          = 2
+  action        = "create_snapshot"
+  snapshot_name = "terraform-test-snapshot"
+  notification  = "ops@example.com"
+}
+`,
+		testAccResourceVSphereScheduledTaskConfigBase(),
+	)
+}
+
+func testAccResourceVSphereScheduledTaskConfigClusterDRS() string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "cluster" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_compute_cluster" "cluster" {
+  name          = "${var.cluster}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_scheduled_task" "task" {
+  name                 = "terraform-test-task"
+  entity_id            = "${data.vsphere_compute_cluster.cluster.id}"
+  entity_type          = "ClusterComputeResource"
+  frequency            = "daily"
+  hour                 = 22
+  action               = "configure_drs"
+  drs_automation_level = "fullyAutomated"
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_CLUSTER"),
+	)
+}
+
+const testAccResourceVSphereScheduledTaskConfigBadAction = `
+resource "vsphere_scheduled_task" "task" {
+  name          = "terraform-test-task"
+  entity_id     = "host-1"
+  entity_type   = "HostSystem"
+  frequency     = "daily"
+  action        = "create_snapshot"
+  snapshot_name = "terraform-test-snapshot"
+}
+`
@@ -1,6 +1,7 @@
 package vsphere
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/virtualdisk"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
@@ -30,39 +32,41 @@ type virtualDisk struct {
 // Define VirtualDisk args
 func resourceVSphereVirtualDisk() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVSphereVirtualDiskCreate,
-		Read:   resourceVSphereVirtualDiskRead,
-		Delete: resourceVSphereVirtualDiskDelete,
+		Create:        resourceVSphereVirtualDiskCreate,
+		Read:          resourceVSphereVirtualDiskRead,
+		Update:        resourceVSphereVirtualDiskUpdate,
+		Delete:        resourceVSphereVirtualDiskDelete,
+		CustomizeDiff: resourceVSphereVirtualDiskCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereVirtualDiskImport,
+		},
 
 		Schema: map[string]*schema.Schema{
-			// Size in GB
+			// Size in GB. Disks can only be grown in place, shrinking a disk forces
+			// a new resource.
 			"size": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true, //TODO Can this be optional (resize)?
 			},
 
 			// TODO:
 			//
-			// * Add extra lifecycles (move, rename, etc). May not be possible
-			// without breaking other resources though.
 			// * Add validation (make sure it ends in .vmdk)
 			"vmdk_path": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"datastore": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
+			// Only thin and lazy disks can be changed in place, and only to
+			// eagerZeroedThick. All other changes force a new resource.
 			"type": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Default:  "eagerZeroedThick",
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
 					value := v.(string)
@@ -100,7 +104,12 @@ func resourceVSphereVirtualDisk() *schema.Resource {
 			"create_directories": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
+			},
+
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the virtual disk, in the same format as the uuid of a disk in vsphere_virtual_machine.",
 			},
 		},
 	}
@@ -293,10 +302,83 @@ func resourceVSphereVirtualDiskRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("datastore", d.Get("datastore"))
 	// Todo collect and write type info
 
+	uuid, err := virtualdisk.QueryUUID(client, ds.Path(vDisk.vmdkPath), dc)
+	if err != nil {
+		return fmt.Errorf("error querying UUID of virtual disk %q: %s", ds.Path(vDisk.vmdkPath), err)
+	}
+	d.Set("uuid", uuid)
+
 	return nil
 
 }
 
+func resourceVSphereVirtualDiskUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] Updating virtual disk %q", d.Id())
+	client := meta.(*VSphereClient).vimClient
+
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return fmt.Errorf("Error finding Datacenter: %s: %s", d.Get("datacenter").(string), err)
+	}
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	// Moves are done first, so that the remaining operations happen on the new
+	// path. Save the new location in state right away, so that we don't lose
+	// track of the disk if a later operation fails.
+	d.Partial(true)
+	ods, nds := d.GetChange("datastore")
+	ovp, nvp := d.GetChange("vmdk_path")
+	ds, err := getDatastore(finder, nds.(string))
+	if err != nil {
+		return fmt.Errorf("Error finding Datastore: %s: %s", nds.(string), err)
+	}
+	diskPath := ds.Path(nvp.(string))
+	if d.HasChange("datastore") || d.HasChange("vmdk_path") {
+		srcDS, err := getDatastore(finder, ods.(string))
+		if err != nil {
+			return fmt.Errorf("Error finding Datastore: %s: %s", ods.(string), err)
+		}
+		if d.Get("create_directories").(bool) {
+			if err := virtualDiskMakeParentDirectory(client, ds, dc, nvp.(string)); err != nil {
+				return err
+			}
+		}
+		if _, err := virtualdisk.Move(client, srcDS.Path(ovp.(string)), dc, diskPath, dc); err != nil {
+			return fmt.Errorf("error moving virtual disk: %s", err)
+		}
+		d.SetPartial("datastore")
+		d.SetPartial("vmdk_path")
+	}
+
+	if d.HasChange("type") {
+		// Anything other than these two changes is a ForceNew, see
+		// resourceVSphereVirtualDiskCustomizeDiff.
+		ot, _ := d.GetChange("type")
+		switch ot.(string) {
+		case "thin":
+			err = virtualdisk.Inflate(client, diskPath, dc)
+		case "lazy":
+			err = virtualdisk.EagerZero(client, diskPath, dc)
+		}
+		if err != nil {
+			return fmt.Errorf("error changing type of virtual disk: %s", err)
+		}
+		d.SetPartial("type")
+	}
+
+	if d.HasChange("size") {
+		size := int64(1024 * 1024 * d.Get("size").(int))
+		eagerZero := d.Get("type").(string) == "eagerZeroedThick"
+		if err := virtualdisk.Extend(client, diskPath, dc, size, eagerZero); err != nil {
+			return fmt.Errorf("error extending virtual disk: %s", err)
+		}
+	}
+	d.Partial(false)
+
+	return resourceVSphereVirtualDiskRead(d, meta)
+}
+
 func resourceVSphereVirtualDiskDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*VSphereClient).vimClient
 
@@ -342,6 +424,99 @@ func resourceVSphereVirtualDiskDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+func resourceVSphereVirtualDiskCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	// Virtual disks cannot be shrunk, so the only way to do this is to create a
+	// new disk.
+	if o, n := d.GetChange("size"); n.(int) < o.(int) {
+		if err := d.ForceNew("size"); err != nil {
+			return err
+		}
+	}
+	// Thin and lazily-zeroed disks can be converted to eagerly-zeroed disks in
+	// place. Any other conversion requires a new disk.
+	if d.HasChange("type") {
+		o, n := d.GetChange("type")
+		if n.(string) != "eagerZeroedThick" || (o.(string) != "thin" && o.(string) != "lazy") {
+			if err := d.ForceNew("type"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resourceVSphereVirtualDiskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*VSphereClient).vimClient
+	var data map[string]string
+	if err := json.Unmarshal([]byte(d.Id()), &data); err != nil {
+		return nil, err
+	}
+	vmdkPath, ok := data["vmdk_path"]
+	if !ok {
+		return nil, errors.New("missing vmdk_path in input data")
+	}
+	dsName, ok := data["datastore"]
+	if !ok {
+		return nil, errors.New("missing datastore in input data")
+	}
+	dcName := data["datacenter"]
+
+	dc, err := getDatacenter(client, dcName)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding Datacenter: %s: %s", dcName, err)
+	}
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+	ds, err := getDatastore(finder, dsName)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding Datastore: %s: %s", dsName, err)
+	}
+
+	t, err := virtualdisk.QueryDiskType(client, ds.Path(vmdkPath), dc)
+	if err != nil {
+		return nil, fmt.Errorf("error querying virtual disk %q: %s", ds.Path(vmdkPath), err)
+	}
+	var diskType string
+	switch t {
+	case types.VirtualDiskTypeThin:
+		diskType = "thin"
+	case types.VirtualDiskTypePreallocated, types.VirtualDiskTypeThick:
+		diskType = "lazy"
+	case types.VirtualDiskTypeEagerZeroedThick:
+		diskType = "eagerZeroedThick"
+	default:
+		return nil, fmt.Errorf("virtual disk %q is of unsupported type %q", ds.Path(vmdkPath), t)
+	}
+
+	d.SetId(vmdkPath)
+	d.Set("vmdk_path", vmdkPath)
+	d.Set("datastore", dsName)
+	d.Set("datacenter", dcName)
+	d.Set("type", diskType)
+	rs := resourceVSphereVirtualDisk().Schema
+	d.Set("adapter_type", rs["adapter_type"].Default)
+	d.Set("create_directories", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// virtualDiskMakeParentDirectory creates the parent directories of the
+// virtual disk at vmdkPath on the supplied datastore.
+func virtualDiskMakeParentDirectory(client *govmomi.Client, ds *object.Datastore, dc *object.Datacenter, vmdkPath string) error {
+	dir := path.Dir(vmdkPath)
+	if dir == "." {
+		return nil
+	}
+	log.Printf("[DEBUG] Creating parent directories: %v", ds.Path(dir))
+	fm := object.NewFileManager(client.Client)
+	if err := fm.MakeDirectory(context.TODO(), ds.Path(dir), dc, true); err != nil && !isAlreadyExists(err) {
+		return fmt.Errorf("error creating parent directories of %q: %s", ds.Path(vmdkPath), err)
+	}
+	return nil
+}
+
 func isAlreadyExists(err error) bool {
 	return strings.HasPrefix(err.Error(), "Cannot complete the operation because the file or folder") &&
 		strings.HasSuffix(err.Error(), "already exists")
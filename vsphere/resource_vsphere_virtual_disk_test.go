@@ -1,6 +1,8 @@
 package vsphere
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -100,6 +102,113 @@ func TestAccResourceVSphereVirtualDisk_withParent(t *testing.T) {
 	})
 }
 
+func TestAccResourceVSphereVirtualDisk_extendAndInflate(t *testing.T) {
+	rString := acctest.RandString(5)
+	var state *terraform.State
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereVirtualDiskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVSphereVirtuaDiskConfig_update(rString, "tfTestDisk-"+rString+".vmdk", 1, "thin"),
+				Check: resource.ComposeTestCheckFunc(
+					copyState(&state),
+					testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", true),
+					resource.TestCheckResourceAttrSet("vsphere_virtual_disk.foo", "uuid"),
+				),
+			},
+			{
+				Config: testAccCheckVSphereVirtuaDiskConfig_update(rString, "tfTestDisk-"+rString+".vmdk", 2, "eagerZeroedThick"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", true),
+					resource.TestCheckResourceAttr("vsphere_virtual_disk.foo", "size", "2"),
+					testAccVSphereVirtualDiskSameUUID("vsphere_virtual_disk.foo", &state),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereVirtualDisk_move(t *testing.T) {
+	rString := acctest.RandString(5)
+	var state *terraform.State
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereVirtualDiskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVSphereVirtuaDiskConfig_update(rString, "tfTestDisk-"+rString+".vmdk", 1, "thin"),
+				Check: resource.ComposeTestCheckFunc(
+					copyState(&state),
+					testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", true),
+				),
+			},
+			{
+				Config: testAccCheckVSphereVirtuaDiskConfig_update(rString, "tfTestParent-"+rString+"/tfTestDisk-"+rString+"-renamed.vmdk", 1, "thin"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", true),
+					resource.TestCheckResourceAttr("vsphere_virtual_disk.foo", "vmdk_path", "tfTestParent-"+rString+"/tfTestDisk-"+rString+"-renamed.vmdk"),
+					testAccVSphereVirtualDiskSameUUID("vsphere_virtual_disk.foo", &state),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereVirtualDisk_import(t *testing.T) {
+	rString := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereVirtualDiskPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVSphereVirtuaDiskConfig_update(rString, "tfTestDisk-"+rString+".vmdk", 1, "thin"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVSphereVirtualDiskExists("vsphere_virtual_disk.foo", true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_virtual_disk.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"create_directories",
+				},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["vsphere_virtual_disk.foo"]
+					if !ok {
+						return "", errors.New("vsphere_virtual_disk.foo not found in state")
+					}
+					b, err := json.Marshal(map[string]string{
+						"datacenter": rs.Primary.Attributes["datacenter"],
+						"datastore":  rs.Primary.Attributes["datastore"],
+						"vmdk_path":  rs.Primary.Attributes["vmdk_path"],
+					})
+					if err != nil {
+						return "", err
+					}
+					return string(b), nil
+				},
+			},
+		},
+	})
+}
+
 func testAccResourceVSphereVirtualDiskPreCheck(t *testing.T) {
 	if os.Getenv("VSPHERE_DATACENTER") == "" {
 		t.Skip("set VSPHERE_DATACENTER to run vsphere_virtual_disk acceptance tests")
@@ -148,6 +257,19 @@ func testAccVSphereVirtualDiskExists(name string, expected bool) resource.TestCh
 	}
 }
 
+// testAccVSphereVirtualDiskSameUUID checks that the UUID of a virtual disk has
+// not changed from the one in a previously copied state, ie: that the disk was
+// updated in place.
+func testAccVSphereVirtualDiskSameUUID(name string, state **terraform.State) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		old, ok := (*state).RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in previous state", name)
+		}
+		return resource.TestCheckResourceAttr(name, "uuid", old.Primary.Attributes["uuid"])(s)
+	}
+}
+
 func testAccCheckVSphereVirtualDiskIsFileNotFoundError(err error) bool {
 	if strings.HasPrefix(err.Error(), "cannot stat") && strings.HasSuffix(err.Error(), "No such file") {
 		return true
@@ -310,3 +432,44 @@ resource "vsphere_virtual_disk" "foo" {
 		rName,
 	)
 }
+
+func testAccCheckVSphereVirtuaDiskConfig_update(rName, vmdkPath string, size int, diskType string) string {
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "datastore" {
+  default = "%s"
+}
+
+variable "rstring" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_datastore" "ds" {
+  name          = "${var.datastore}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_virtual_disk" "foo" {
+  size               = %d
+  vmdk_path          = "%s"
+  type               = "%s"
+  datacenter         = "${data.vsphere_datacenter.dc.name}"
+  datastore          = "${data.vsphere_datastore.ds.name}"
+  create_directories = true
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_DATASTORE"),
+		rName,
+		size,
+		vmdkPath,
+		diskType,
+	)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/customattribute"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/customizationspec"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/datastore"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/folder"
 	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
@@ -42,6 +43,19 @@ https://www.terraform.io/docs/commands/taint.html
 If the virtual machine does not exist in state, manually delete it to try again.
 `
 
+// formatVirtualMachinePostRegisterRollbackError defines the verbose error when
+// rollback fails on a post-registration virtual machine operation.
+const formatVirtualMachinePostRegisterRollbackError = `
+WARNING:
+There was an error performing post-registration changes to virtual machine %q:
+%s
+Additionally, there was an error removing the virtual machine from inventory:
+%s
+
+The virtual machine has not been saved to Terraform state. Manually remove it
+from inventory, without deleting it from disk, to try again.
+`
+
 // formatVirtualMachineCustomizationWaitError defines the verbose error that is
 // sent when the customization waiter returns an error. This can either be due
 // to timeout waiting for respective events or a guest-specific customization
@@ -208,8 +222,22 @@ func resourceVSphereVirtualMachine() *schema.Resource {
 		},
 		"vmx_path": {
 			Type:        schema.TypeString,
+			Optional:    true,
 			Computed:    true,
-			Description: "The path of the virtual machine's configuration file in the VM's datastore.",
+			Description: "The path of the virtual machine's configuration file in the VM's datastore. Required when register is enabled.",
+		},
+		"register": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Register the existing virtual machine configuration file at vmx_path in datastore_id, instead of creating a new virtual machine. Only used when the virtual machine is created.",
+		},
+		"register_answer": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      virtualmachine.UUIDQuestionAnswerMoved,
+			Description:  "The answer to give if asked whether a registered virtual machine was moved or copied when it is powered on. Can be one of moved or copied.",
+			ValidateFunc: validation.StringInSlice([]string{virtualmachine.UUIDQuestionAnswerMoved, virtualmachine.UUIDQuestionAnswerCopied}, false),
 		},
 		"imported": {
 			Type:        schema.TypeBool,
@@ -265,6 +293,8 @@ func resourceVSphereVirtualMachineCreate(d *schema.ResourceData, meta interface{
 	case len(d.Get("clone").([]interface{})) > 0:
 
 		vm, err = resourceVSphereVirtualMachineCreateClone(d, meta)
+	case d.Get("register").(bool):
+		vm, err = resourceVSphereVirtualMachineCreateRegister(d, meta)
 	default:
 		vm, err = resourceVSphereVirtualMachineCreateBare(d, meta)
 	}
@@ -567,6 +597,15 @@ func resourceVSphereVirtualMachineUpdate(d *schema.ResourceData, meta interface{
 	// operation finishes successfully, need to be done in partial mode.
 	d.Partial(true)
 
+	// First class disks that have been removed need to be detached before
+	// the device changes are calculated, as their removal is not part of the
+	// config spec.
+	if d.HasChange("disk") {
+		if err := resourceVSphereVirtualMachineDetachFirstClassDisks(d, vm); err != nil {
+			return setErrorInResource(d, err)
+		}
+	}
+
 	vprops, err := virtualmachine.Properties(vm)
 	if err != nil {
 		return setErrorInResource(d, fmt.Errorf("error fetching VM properties: %s", err))
@@ -636,6 +675,18 @@ func resourceVSphereVirtualMachineUpdate(d *schema.ResourceData, meta interface{
 			}
 		}
 	}
+	if err := resourceVSphereVirtualMachineAttachFirstClassDisks(d, client, vm); err != nil {
+		return setErrorInResource(d, err)
+	}
+	// Re-apply customization if it has changed and the customize block has
+	// opted in to it.
+	for _, prefix := range []string{"clone.0.", ""} {
+		if vmworkflow.CustomizationNeedsReapply(d, prefix) {
+			if err := resourceVSphereVirtualMachineReapplyCustomization(d, meta, vm, prefix); err != nil {
+				return setErrorInResource(d, err)
+			}
+		}
+	}
 	// Now safe to turn off partial mode.
 	d.Partial(false)
 	d.Set("reboot_required", false)
@@ -718,6 +769,9 @@ func resourceVSphereVirtualMachineDelete(d *schema.ResourceData, meta interface{
 	}
 	// Now attempt to detach any virtual disks that may need to be preserved.
 	devices := object.VirtualDeviceList(vprops.Config.Hardware.Device)
+	if err := virtualdevice.DiskFCDDestroyOperation(d, vm, devices); err != nil {
+		return err
+	}
 	spec := types.VirtualMachineConfigSpec{}
 	if spec.DeviceChange, err = virtualdevice.DiskDestroyOperation(d, client, devices); err != nil {
 		return err
@@ -750,6 +804,34 @@ func resourceVSphereVirtualMachineCustomizeDiff(d *schema.ResourceDiff, meta int
 		}
 	}
 
+	// Validate that extra_config does not manage any cloud_init keys
+	if err := validateCloudInitExtraConfig(d); err != nil {
+		return err
+	}
+
+	// Cohesity windows customization is carried out by an agent in the guest
+	// and cannot be re-applied.
+	for _, prefix := range []string{"clone.0.", ""} {
+		if d.Get(prefix + "customize.0.reapply_on_change").(bool) {
+			if _, ok := d.GetOk(prefix + "customize.0.cohesity_windows_customization_options"); ok {
+				return errors.New("reapply_on_change cannot be used with cohesity_windows_customization_options")
+			}
+		}
+	}
+
+	// Validate the customization specification referenced by spec_name, if any.
+	// Clones are checked in ValidateVirtualMachineClone.
+	if name, ok := d.GetOk("customize.0.spec_name"); ok {
+		if err := vmworkflow.ValidateCustomizationSpecName(d, client, name.(string), ""); err != nil {
+			return err
+		}
+	}
+
+	// Validate the options for registering an existing virtual machine
+	if err := resourceVSphereVirtualMachineCustomizeDiffRegisterOperation(d); err != nil {
+		return err
+	}
+
 	// Validate cdrom sub-resources
 	if err := virtualdevice.CdromDiffOperation(d, client); err != nil {
 		return err
@@ -801,6 +883,7 @@ func resourceVSphereVirtualMachineCustomizeDiff(d *schema.ResourceDiff, meta int
 		default:
 			// For most cases (all non-imported workflows), any changed attribute in
 			// the clone configuration namespace is a ForceNew. Flag those now.
+			reapply := d.Get("clone.0.customize.0.reapply_on_change").(bool)
 			for _, k := range d.GetChangedKeysPrefix("clone.0") {
 				if strings.HasSuffix(k, ".#") {
 					k = strings.TrimSuffix(k, ".#")
@@ -810,6 +893,11 @@ func resourceVSphereVirtualMachineCustomizeDiff(d *schema.ResourceDiff, meta int
 				if k == "clone.0.timeout" {
 					continue
 				}
+				// Customization changes are re-applied to the existing virtual
+				// machine when reapply_on_change is enabled.
+				if reapply && strings.HasPrefix(k, "clone.0.customize") {
+					continue
+				}
 				d.ForceNew(k)
 			}
 		}
@@ -838,6 +926,42 @@ func resourceVSphereVirtualMachineCustomizeDiffResourcePoolOperation(d *schema.R
 	return nil
 }
 
+// resourceVSphereVirtualMachineCustomizeDiffRegisterOperation validates the
+// options used to register an existing virtual machine. vmx_path is computed
+// in all other workflows, so it is only allowed to be set when register is
+// enabled.
+func resourceVSphereVirtualMachineCustomizeDiffRegisterOperation(d *schema.ResourceDiff) error {
+	vmxPathSet := d.HasChange("vmx_path") && d.NewValueKnown("vmx_path") && d.Get("vmx_path").(string) != ""
+	if !d.Get("register").(bool) {
+		if vmxPathSet {
+			return errors.New("vmx_path can only be set when register is enabled")
+		}
+		return nil
+	}
+	switch {
+	case len(d.Get("clone").([]interface{})) > 0:
+		return errors.New("register cannot be used with clone")
+	case d.Get("datastore_cluster_id").(string) != "":
+		return errors.New("register cannot be used with datastore_cluster_id")
+	}
+	if _, ok := d.GetOk("customize.0.cohesity_windows_customization_options"); ok {
+		return errors.New("register cannot be used with cohesity_windows_customization_options")
+	}
+	if d.Id() == "" {
+		if d.NewValueKnown("vmx_path") && d.Get("vmx_path").(string) == "" {
+			return errors.New("vmx_path is required when register is enabled")
+		}
+		return nil
+	}
+	// Pointing an existing resource at a different configuration file registers
+	// a new virtual machine.
+	if vmxPathSet {
+		log.Printf("[DEBUG] %s: vmx_path changed with register enabled, forcing new resource", resourceVSphereVirtualMachineIDString(d))
+		return d.ForceNew("vmx_path")
+	}
+	return nil
+}
+
 func datastoreClusterDiffOperation(d *schema.ResourceDiff, client *govmomi.Client) error {
 	if !structure.ValuesAvailable("", []string{"datastore_cluster_id", "datastore_id"}, d) {
 		log.Printf("[DEBUG] DatastoreClusterDiffOperation: datastore_id or datastore_cluster_id value depends on a computed value from another resource. Skipping validation.")
@@ -971,6 +1095,7 @@ func resourceVSphereVirtualMachineImport(d *schema.ResourceData, meta interface{
 	d.Set("wait_for_guest_ip_timeout", rs["wait_for_guest_ip_timeout"].Default)
 	d.Set("wait_for_guest_net_timeout", rs["wait_for_guest_net_timeout"].Default)
 	d.Set("wait_for_guest_net_routable", rs["wait_for_guest_net_routable"].Default)
+	d.Set("register_answer", rs["register_answer"].Default)
 
 	log.Printf("[DEBUG] %s: Import complete, resource is ready for read", resourceVSphereVirtualMachineIDString(d))
 	return []*schema.ResourceData{d}, nil
@@ -1050,63 +1175,30 @@ func resourceVSphereVirtualMachineCreateBare(d *schema.ResourceData, meta interf
 	log.Printf("[DEBUG] VM %q - UUID is %q", vm.InventoryPath, vprops.Config.Uuid)
 	d.SetId(vprops.Config.Uuid)
 
+	if err := resourceVSphereVirtualMachineAttachFirstClassDisks(d, client, vm); err != nil {
+		return nil, err
+	}
+
 	var cw *virtualMachineCustomizationWaiter
+	var cab *cohesityWindowsCustomizationCabinet
 	// Send customization spec if any has been defined.
 	if len(d.Get("customize").([]interface{})) > 0 {
 		if _, ok := d.GetOk("customize.0.cohesity_windows_customization_options"); ok {
-			if _, ok := d.GetOk("extra_config"); !ok {
-				return nil, fmt.Errorf("extra_config not set")
-			}
-
-			f := file{}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.source_datacenter"); ok {
-				f.sourceDatacenter = v.(string)
-				f.copyFile = true
-			}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.datacenter"); ok {
-				f.datacenter = v.(string)
-			}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.source_datastore"); ok {
-				f.sourceDatastore = v.(string)
-				f.copyFile = true
-			}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.datastore"); ok {
-				f.datastore = v.(string)
-			} else {
-				return nil, fmt.Errorf("datastore argument is required")
-			}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.source_file"); ok {
-				f.sourceFile = v.(string)
-			} else {
-				return nil, fmt.Errorf("source_file argument is required")
-			}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.destination_file"); ok {
-				f.destinationFile = v.(string)
+			if _, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.source_file"); ok {
+				if err := copyCohesityWindowsCustomization(d, client); err != nil {
+					return nil, err
+				}
+				log.Printf("[DEBUG] Cabinet file copied successfully.")
 			} else {
-				return nil, fmt.Errorf("destination_file argument is required")
-			}
-
-			if v, ok := d.GetOk("customize.0.cohesity_windows_customization_options.0.create_directories"); ok {
-				f.createDirectories = v.(bool)
-			}
-
-			err := createFile(client, &f)
-			if err != nil {
-				return nil, err
+				if cab, err = stageCohesityWindowsCustomization(d, client, vm); err != nil {
+					return nil, fmt.Errorf("error staging cohesity windows customization: %s", err)
+				}
 			}
-			log.Printf("[DEBUG] Cabinet file copied successfully.")
 		} else {
-			family, err := resourcepool.OSFamily(client, pool, d.Get("guest_id").(string))
+			custSpec, err := resourceVSphereVirtualMachineExpandCustomizationSpec(d, client, pool, "")
 			if err != nil {
-				return nil, fmt.Errorf("cannot find OS family for guest ID %q: %s", d.Get("guest_id").(string), err)
+				return nil, err
 			}
-			custSpec := vmworkflow.ExpandCustomizationSpec(d, family, "")
 			cw = newVirtualMachineCustomizationWaiter(client, vm, d.Get("customize.0.timeout").(int))
 			if err := virtualmachine.Customize(vm, custSpec); err != nil {
 				// Roll back the VMs as per the error handling in reconfigure.
@@ -1133,6 +1225,29 @@ func resourceVSphereVirtualMachineCreateBare(d *schema.ResourceData, meta interf
 		}
 	}
 
+	// If we staged a cohesity windows customization cabinet, wait on the guest
+	// to report its result, and clean up the cabinet. If the waiter is disabled,
+	// or there is no administrator password to read the result with, the
+	// cabinet is left in place, as the guest may not have read it yet.
+	if cab != nil {
+		timeout := d.Get("customize.0.timeout").(int)
+		if timeout < 1 || !cab.CanWait() {
+			log.Printf("[DEBUG] %s: Not waiting for cohesity windows customization, leaving cabinet file %q in place", resourceVSphereVirtualMachineIDString(d), cab)
+			return vm, nil
+		}
+		log.Printf("[DEBUG] %s: Waiting for cohesity windows customization to complete", resourceVSphereVirtualMachineIDString(d))
+		werr := cab.Wait(vm, timeout)
+		if err := cab.Remove(vm); err != nil {
+			if werr != nil {
+				return nil, fmt.Errorf(formatVirtualMachineCustomizationWaitError, vm.InventoryPath, werr)
+			}
+			return nil, fmt.Errorf("error cleaning up cohesity windows customization: %s", err)
+		}
+		if werr != nil {
+			return nil, fmt.Errorf(formatVirtualMachineCustomizationWaitError, vm.InventoryPath, werr)
+		}
+	}
+
 	return vm, nil
 }
 
@@ -1334,15 +1449,17 @@ func resourceVSphereVirtualMachineCreateClone(d *schema.ResourceData, meta inter
 			fmt.Errorf("error reconfiguring virtual machine: %s", err),
 		)
 	}
+	if err := resourceVSphereVirtualMachineAttachFirstClassDisks(d, client, vm); err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackCreate(d, meta, vm, err)
+	}
 
 	var cw *virtualMachineCustomizationWaiter
 	// Send customization spec if any has been defined.
 	if len(d.Get("clone.0.customize").([]interface{})) > 0 {
-		family, err := resourcepool.OSFamily(client, pool, d.Get("guest_id").(string))
+		custSpec, err := resourceVSphereVirtualMachineExpandCustomizationSpec(d, client, pool, "clone.0.")
 		if err != nil {
-			return nil, fmt.Errorf("cannot find OS family for guest ID %q: %s", d.Get("guest_id").(string), err)
+			return nil, err
 		}
-		custSpec := vmworkflow.ExpandCustomizationSpec(d, family, "clone.0.")
 		cw = newVirtualMachineCustomizationWaiter(client, vm, d.Get("clone.0.customize.0.timeout").(int))
 		if err := virtualmachine.Customize(vm, custSpec); err != nil {
 			// Roll back the VMs as per the error handling in reconfigure.
@@ -1369,6 +1486,124 @@ func resourceVSphereVirtualMachineCreateClone(d *schema.ResourceData, meta inter
 	return vm, nil
 }
 
+// resourceVSphereVirtualMachineExpandCustomizationSpec returns the
+// customization spec for the customize block under prefix. This is either the
+// saved specification referenced by spec_name with the per-VM settings applied,
+// or one built from the identity settings for the OS family of the guest.
+func resourceVSphereVirtualMachineExpandCustomizationSpec(
+	d *schema.ResourceData,
+	client *govmomi.Client,
+	pool *object.ResourcePool,
+	prefix string,
+) (types.CustomizationSpec, error) {
+	return resourceVSphereVirtualMachineExpandCustomizationSpecForGuest(d, client, pool, d.Get("guest_id").(string), prefix)
+}
+
+// resourceVSphereVirtualMachineExpandCustomizationSpecForGuest works like
+// resourceVSphereVirtualMachineExpandCustomizationSpec, but looks up the OS
+// family for the supplied guest ID instead of the one in guest_id.
+func resourceVSphereVirtualMachineExpandCustomizationSpecForGuest(
+	d *schema.ResourceData,
+	client *govmomi.Client,
+	pool *object.ResourcePool,
+	guestID string,
+	prefix string,
+) (types.CustomizationSpec, error) {
+	if name, ok := d.GetOk(prefix + "customize.0.spec_name"); ok {
+		item, err := customizationspec.FromName(client, name.(string))
+		if err != nil {
+			return types.CustomizationSpec{}, fmt.Errorf("cannot locate customization specification %q: %s", name.(string), err)
+		}
+		return vmworkflow.OverlayCustomizationSpec(d, item.Spec, prefix)
+	}
+	family, err := resourcepool.OSFamily(client, pool, guestID)
+	if err != nil {
+		return types.CustomizationSpec{}, fmt.Errorf("cannot find OS family for guest ID %q: %s", guestID, err)
+	}
+	return vmworkflow.ExpandCustomizationSpec(d, family, prefix), nil
+}
+
+// resourceVSphereVirtualMachineReapplyCustomization re-applies the
+// customization in the customize block under prefix to an existing virtual
+// machine. The virtual machine is powered off to send the spec. If it was
+// powered on beforehand, it is powered back on and the customization is waited
+// on. Otherwise it is left powered off, and the customization runs the next
+// time it is powered on.
+func resourceVSphereVirtualMachineReapplyCustomization(
+	d *schema.ResourceData,
+	meta interface{},
+	vm *object.VirtualMachine,
+	prefix string,
+) error {
+	log.Printf("[DEBUG] %s: Re-applying customization", resourceVSphereVirtualMachineIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	pool, err := resourcepool.FromID(client, d.Get("resource_pool_id").(string))
+	if err != nil {
+		return fmt.Errorf("could not find resource pool ID %q: %s", d.Get("resource_pool_id").(string), err)
+	}
+	custSpec, err := resourceVSphereVirtualMachineExpandCustomizationSpec(d, client, pool, prefix)
+	if err != nil {
+		return err
+	}
+
+	vprops, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return fmt.Errorf("error fetching VM properties: %s", err)
+	}
+	poweredOn := vprops.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff
+	if poweredOn {
+		timeout := d.Get("shutdown_wait_timeout").(int)
+		force := d.Get("force_power_off").(bool)
+		if err := virtualmachine.GracefulPowerOff(client, vm, timeout, force); err != nil {
+			return fmt.Errorf("error shutting down virtual machine: %s", err)
+		}
+	}
+
+	if !poweredOn {
+		if err := virtualmachine.Customize(vm, custSpec); err != nil {
+			return fmt.Errorf("error sending customization spec: %s", err)
+		}
+		log.Printf("[DEBUG] %s: Virtual machine was powered off, customization will run on next power on", resourceVSphereVirtualMachineIDString(d))
+		return nil
+	}
+
+	cw := newVirtualMachineCustomizationWaiter(client, vm, d.Get(prefix+"customize.0.timeout").(int))
+	if err := virtualmachine.Customize(vm, custSpec); err != nil {
+		// Leave the virtual machine running, as it was before the attempt.
+		if perr := virtualmachine.PowerOn(vm); perr != nil {
+			log.Printf("[DEBUG] %s: Error powering on virtual machine: %s", resourceVSphereVirtualMachineIDString(d), perr)
+		}
+		return fmt.Errorf("error sending customization spec: %s", err)
+	}
+	if err := virtualmachine.PowerOn(vm); err != nil {
+		return fmt.Errorf("error powering on virtual machine: %s", err)
+	}
+	log.Printf("[DEBUG] %s: Waiting for VM customization to complete", resourceVSphereVirtualMachineIDString(d))
+	<-cw.Done()
+	if err := cw.Err(); err != nil {
+		return fmt.Errorf(formatVirtualMachineCustomizationWaitError, vm.InventoryPath, err)
+	}
+
+	err = virtualmachine.WaitForGuestIP(
+		client,
+		vm,
+		d.Get("wait_for_guest_ip_timeout").(int),
+		d.Get("ignored_guest_ips").([]interface{}),
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	return virtualmachine.WaitForGuestNet(
+		client,
+		vm,
+		d.Get("wait_for_guest_net_routable").(bool),
+		d.Get("wait_for_guest_net_timeout").(int),
+		d.Get("ignored_guest_ips").([]interface{}),
+		"",
+	)
+}
+
 // resourceVSphereVirtualMachineCreateCloneWithSDRS runs the clone part of
 // resourceVSphereVirtualMachineCreateClone through storage DRS. It's designed
 // to be run when a storage cluster is specified, versus simply specifying
@@ -1426,6 +1661,181 @@ func resourceVSphereVirtualMachineRollbackCreate(
 	return fmt.Errorf("error reconfiguring virtual machine: %s", origErr)
 }
 
+// resourceVSphereVirtualMachineCreateRegister contains the workflow for
+// registering an existing virtual machine configuration file. The VM is
+// returned.
+//
+// The configuration of the registered virtual machine is brought in line with
+// the resource configuration in the same fashion as a clone. The ID of the
+// resource is only set once the virtual machine has been powered on, as the
+// UUID changes if the virtual machine is registered as a copy.
+func resourceVSphereVirtualMachineCreateRegister(d *schema.ResourceData, meta interface{}) (*object.VirtualMachine, error) {
+	log.Printf("[DEBUG] %s: VM being registered from existing configuration", resourceVSphereVirtualMachineIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	poolID := d.Get("resource_pool_id").(string)
+	pool, err := resourcepool.FromID(client, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find resource pool ID %q: %s", poolID, err)
+	}
+	fo, err := folder.VirtualMachineFolderFromObject(client, pool, d.Get("folder").(string))
+	if err != nil {
+		return nil, err
+	}
+	var hs *object.HostSystem
+	if v, ok := d.GetOk("host_system_id"); ok {
+		hsID := v.(string)
+		var err error
+		if hs, err = hostsystem.FromID(client, hsID); err != nil {
+			return nil, fmt.Errorf("error locating host system at ID %q: %s", hsID, err)
+		}
+	}
+	if err := resourcepool.ValidateHost(client, pool, hs); err != nil {
+		return nil, err
+	}
+	dsID := d.Get("datastore_id").(string)
+	ds, err := datastore.FromID(client, dsID)
+	if err != nil {
+		return nil, fmt.Errorf("error locating datastore at ID %q: %s", dsID, err)
+	}
+
+	vm, err := virtualmachine.Register(client, fo, ds.Path(d.Get("vmx_path").(string)), d.Get("name").(string), pool, hs)
+	if err != nil {
+		return nil, fmt.Errorf("error registering virtual machine: %s", err)
+	}
+	vprops, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("cannot fetch properties of registered virtual machine: %s", err),
+		)
+	}
+
+	// Normalize the configuration of the registered VM. This is the same
+	// process as post-clone, as we have configuration, but no state.
+	cfgSpec, err := expandVirtualMachineConfigSpec(d, client)
+	if err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error in virtual machine configuration: %s", err),
+		)
+	}
+	devices := object.VirtualDeviceList(vprops.Config.Hardware.Device)
+	var delta []types.BaseVirtualDeviceConfigSpec
+	devices, delta, err = virtualdevice.NormalizeSCSIBus(devices, d.Get("scsi_type").(string), d.Get("scsi_controller_count").(int), d.Get("scsi_bus_sharing").(string))
+	if err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error normalizing SCSI bus post-registration: %s", err),
+		)
+	}
+	cfgSpec.DeviceChange = virtualdevice.AppendDeviceChangeSpec(cfgSpec.DeviceChange, delta...)
+	devices, delta, err = virtualdevice.DiskPostCloneOperation(d, client, devices)
+	if err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error processing disk changes post-registration: %s", err),
+		)
+	}
+	cfgSpec.DeviceChange = virtualdevice.AppendDeviceChangeSpec(cfgSpec.DeviceChange, delta...)
+	devices, delta, err = virtualdevice.NetworkInterfacePostCloneOperation(d, client, devices)
+	if err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error processing network device changes post-registration: %s", err),
+		)
+	}
+	cfgSpec.DeviceChange = virtualdevice.AppendDeviceChangeSpec(cfgSpec.DeviceChange, delta...)
+	devices, delta, err = virtualdevice.CdromPostCloneOperation(d, client, devices)
+	if err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error processing CDROM device changes post-registration: %s", err),
+		)
+	}
+	cfgSpec.DeviceChange = virtualdevice.AppendDeviceChangeSpec(cfgSpec.DeviceChange, delta...)
+	log.Printf("[DEBUG] %s: Final device list: %s", resourceVSphereVirtualMachineIDString(d), virtualdevice.DeviceListString(devices))
+	log.Printf("[DEBUG] %s: Final device change cfgSpec: %s", resourceVSphereVirtualMachineIDString(d), virtualdevice.DeviceChangeString(cfgSpec.DeviceChange))
+	if err := virtualmachine.Reconfigure(vm, cfgSpec); err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error reconfiguring virtual machine: %s", err),
+		)
+	}
+	if err := resourceVSphereVirtualMachineAttachFirstClassDisks(d, client, vm); err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(d, vm, err)
+	}
+
+	var cw *virtualMachineCustomizationWaiter
+	if len(d.Get("customize").([]interface{})) > 0 {
+		custSpec, err := resourceVSphereVirtualMachineExpandCustomizationSpec(d, client, pool, "")
+		if err != nil {
+			return nil, resourceVSphereVirtualMachineRollbackRegister(d, vm, err)
+		}
+		cw = newVirtualMachineCustomizationWaiter(client, vm, d.Get("customize.0.timeout").(int))
+		if err := virtualmachine.Customize(vm, custSpec); err != nil {
+			return nil, resourceVSphereVirtualMachineRollbackRegister(
+				d,
+				vm,
+				fmt.Errorf("error sending customization spec: %s", err),
+			)
+		}
+	}
+
+	// Power on, answering the moved or copied question if it's asked, and then
+	// set the ID from the UUID the virtual machine ended up with.
+	if err := virtualmachine.PowerOnAnswerUUIDQuestion(vm, d.Get("register_answer").(string)); err != nil {
+		return nil, resourceVSphereVirtualMachineRollbackRegister(
+			d,
+			vm,
+			fmt.Errorf("error powering on virtual machine: %s", err),
+		)
+	}
+	vprops, err = virtualmachine.Properties(vm)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch properties of registered virtual machine: %s", err)
+	}
+	log.Printf("[DEBUG] VM %q - UUID is %q", vm.InventoryPath, vprops.Config.Uuid)
+	d.SetId(vprops.Config.Uuid)
+
+	if cw != nil {
+		log.Printf("[DEBUG] %s: Waiting for VM customization to complete", resourceVSphereVirtualMachineIDString(d))
+		<-cw.Done()
+		if err := cw.Err(); err != nil {
+			return nil, fmt.Errorf(formatVirtualMachineCustomizationWaitError, vm.InventoryPath, err)
+		}
+	}
+	return vm, nil
+}
+
+// resourceVSphereVirtualMachineRollbackRegister attempts to "roll back" a
+// resource due to an error that happened after registration but before the
+// virtual machine was powered on. The virtual machine is powered off if
+// necessary and removed from inventory. Its files are not deleted, as they
+// were not created by Terraform.
+func resourceVSphereVirtualMachineRollbackRegister(
+	d *schema.ResourceData,
+	vm *object.VirtualMachine,
+	origErr error,
+) error {
+	defer d.SetId("")
+	if vprops, err := virtualmachine.Properties(vm); err == nil && vprops.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff {
+		if err := virtualmachine.PowerOff(vm); err != nil {
+			return fmt.Errorf(formatVirtualMachinePostRegisterRollbackError, vm.InventoryPath, origErr, err)
+		}
+	}
+	if err := virtualmachine.Unregister(vm); err != nil {
+		return fmt.Errorf(formatVirtualMachinePostRegisterRollbackError, vm.InventoryPath, origErr, err)
+	}
+	return origErr
+}
+
 // resourceVSphereVirtualMachineUpdateLocation manages vMotion. This includes
 // the migration of a VM from one host to another, or from one datastore to
 // another (storage vMotion).
@@ -1539,6 +1949,33 @@ func resourceVSphereVirtualMachineUpdateLocationRelocateWithSDRS(
 	return nil
 }
 
+// resourceVSphereVirtualMachineAttachFirstClassDisks attaches any first class
+// disks in the disk sub-resource that are not yet attached to the virtual
+// machine. This is run after the device changes from applyVirtualDevices or
+// the post-clone operations have been applied.
+func resourceVSphereVirtualMachineAttachFirstClassDisks(d *schema.ResourceData, c *govmomi.Client, vm *object.VirtualMachine) error {
+	vprops, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return fmt.Errorf("error fetching VM properties: %s", err)
+	}
+	devices := object.VirtualDeviceList(vprops.Config.Hardware.Device)
+	if err := virtualdevice.DiskFCDAttachOperation(d, c, vm, devices); err != nil {
+		return fmt.Errorf("error attaching first class disks: %s", err)
+	}
+	return nil
+}
+
+// resourceVSphereVirtualMachineDetachFirstClassDisks detaches any first class
+// disks that have been removed from the disk sub-resource.
+func resourceVSphereVirtualMachineDetachFirstClassDisks(d *schema.ResourceData, vm *object.VirtualMachine) error {
+	vprops, err := virtualmachine.Properties(vm)
+	if err != nil {
+		return fmt.Errorf("error fetching VM properties: %s", err)
+	}
+	devices := object.VirtualDeviceList(vprops.Config.Hardware.Device)
+	return virtualdevice.DiskFCDDetachOperation(d, vm, devices)
+}
+
 // applyVirtualDevices is used by Create and Update to build a list of virtual
 // device changes.
 func applyVirtualDevices(d *schema.ResourceData, c *govmomi.Client, l object.VirtualDeviceList) ([]types.BaseVirtualDeviceConfigSpec, error) {
@@ -0,0 +1,260 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const resourceVSphereVsanDiskGroupName = "vsphere_vsan_disk_group"
+
+var vsanHostDecommissionModeObjectActionAllowedValues = []string{
+	string(types.VsanHostDecommissionModeObjectActionNoAction),
+	string(types.VsanHostDecommissionModeObjectActionEnsureObjectAccessibility),
+	string(types.VsanHostDecommissionModeObjectActionEvacuateAllData),
+}
+
+func resourceVSphereVsanDiskGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereVsanDiskGroupCreate,
+		Read:   resourceVSphereVsanDiskGroupRead,
+		Update: resourceVSphereVsanDiskGroupUpdate,
+		Delete: resourceVSphereVsanDiskGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereVsanDiskGroupImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_system_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The managed object ID of the host to create the disk group on.",
+			},
+			"cache_disk": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The canonical name of the flash disk to use as the cache tier of the disk group.",
+			},
+			"capacity_disks": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    7,
+				Description: "The canonical names of the disks to use as the capacity tier of the disk group.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"decommission_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.VsanHostDecommissionModeObjectActionEnsureObjectAccessibility),
+				Description:  "How vSAN handles data on disks that are removed from the disk group, or when the disk group is destroyed. Can be one of noAction, ensureObjectAccessibility, or evacuateAllData.",
+				ValidateFunc: validation.StringInSlice(vsanHostDecommissionModeObjectActionAllowedValues, false),
+			},
+			"decommission_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3600,
+				Description: "The timeout, in seconds, for data migration when removing disks from the disk group.",
+			},
+		},
+	}
+}
+
+func resourceVSphereVsanDiskGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning create", resourceVSphereVsanDiskGroupIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID := d.Get("host_system_id").(string)
+	vs, err := hostVsanSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host vSAN system: %s", err)
+	}
+
+	cacheName := d.Get("cache_disk").(string)
+	capacityNames := structure.SliceInterfacesToStrings(d.Get("capacity_disks").(*schema.Set).List())
+	mapping, err := expandVsanHostDiskMapping(vs, cacheName, capacityNames)
+	if err != nil {
+		return err
+	}
+	if err := hostVsanInitializeDisks(vs, *mapping); err != nil {
+		return fmt.Errorf("error creating disk group: %s", err)
+	}
+
+	d.SetId(resourceVSphereVsanDiskGroupFlattenID(hsID, cacheName))
+	log.Printf("[DEBUG] %s: Create finished successfully", resourceVSphereVsanDiskGroupIDString(d))
+	return resourceVSphereVsanDiskGroupRead(d, meta)
+}
+
+func resourceVSphereVsanDiskGroupRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning read", resourceVSphereVsanDiskGroupIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, cacheName, err := resourceVSphereVsanDiskGroupParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	vs, err := hostVsanSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host vSAN system: %s", err)
+	}
+
+	mapping, err := hostVsanDiskMapping(vs, cacheName)
+	if err != nil {
+		return err
+	}
+	if mapping == nil {
+		log.Printf("[DEBUG] %s: Disk group not found, marking resource as gone", resourceVSphereVsanDiskGroupIDString(d))
+		d.SetId("")
+		return nil
+	}
+
+	var capacityNames []string
+	for _, disk := range mapping.NonSsd {
+		capacityNames = append(capacityNames, disk.CanonicalName)
+	}
+	err = structure.SetBatch(d, map[string]interface{}{
+		"host_system_id": hsID,
+		"cache_disk":     mapping.Ssd.CanonicalName,
+		"capacity_disks": capacityNames,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] %s: Read completed successfully", resourceVSphereVsanDiskGroupIDString(d))
+	return nil
+}
+
+func resourceVSphereVsanDiskGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning update", resourceVSphereVsanDiskGroupIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, cacheName, err := resourceVSphereVsanDiskGroupParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	vs, err := hostVsanSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host vSAN system: %s", err)
+	}
+
+	o, n := d.GetChange("capacity_disks")
+	added := structure.SliceInterfacesToStrings(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+	removed := structure.SliceInterfacesToStrings(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+	// Add new disks first so that there is capacity to evacuate data to from
+	// the disks being removed.
+	if len(added) > 0 {
+		log.Printf("[DEBUG] %s: Adding capacity disks: %s", resourceVSphereVsanDiskGroupIDString(d), strings.Join(added, ", "))
+		mapping, err := expandVsanHostDiskMapping(vs, cacheName, added)
+		if err != nil {
+			return err
+		}
+		if err := hostVsanInitializeDisks(vs, *mapping); err != nil {
+			return fmt.Errorf("error adding capacity disks: %s", err)
+		}
+	}
+	if len(removed) > 0 {
+		log.Printf("[DEBUG] %s: Removing capacity disks: %s", resourceVSphereVsanDiskGroupIDString(d), strings.Join(removed, ", "))
+		disks, err := hostVsanDisksByName(vs, removed)
+		if err != nil {
+			return err
+		}
+		if err := hostVsanRemoveDisks(vs, disks, d.Get("decommission_mode").(string), d.Get("decommission_timeout").(int)); err != nil {
+			return fmt.Errorf("error removing capacity disks: %s", err)
+		}
+	}
+
+	log.Printf("[DEBUG] %s: Update finished successfully", resourceVSphereVsanDiskGroupIDString(d))
+	return resourceVSphereVsanDiskGroupRead(d, meta)
+}
+
+func resourceVSphereVsanDiskGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[DEBUG] %s: Beginning delete", resourceVSphereVsanDiskGroupIDString(d))
+	client := meta.(*VSphereClient).vimClient
+	hsID, cacheName, err := resourceVSphereVsanDiskGroupParseID(d.Id())
+	if err != nil {
+		return err
+	}
+	vs, err := hostVsanSystemFromHostSystemID(client, hsID)
+	if err != nil {
+		return fmt.Errorf("error loading host vSAN system: %s", err)
+	}
+
+	mapping, err := hostVsanDiskMapping(vs, cacheName)
+	if err != nil {
+		return err
+	}
+	if mapping == nil {
+		log.Printf("[DEBUG] %s: Disk group already gone", resourceVSphereVsanDiskGroupIDString(d))
+		return nil
+	}
+	if err := hostVsanRemoveDiskMapping(vs, *mapping, d.Get("decommission_mode").(string), d.Get("decommission_timeout").(int)); err != nil {
+		return fmt.Errorf("error removing disk group: %s", err)
+	}
+
+	log.Printf("[DEBUG] %s: Deleted successfully", resourceVSphereVsanDiskGroupIDString(d))
+	return nil
+}
+
+func resourceVSphereVsanDiskGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := resourceVSphereVsanDiskGroupParseID(d.Id()); err != nil {
+		return nil, errors.New("please supply the ID in the following format: HOSTID:CACHEDISK")
+	}
+
+	// Set the defaults for attributes not managed by read.
+	s := resourceVSphereVsanDiskGroup().Schema
+	err := structure.SetBatch(d, map[string]interface{}{
+		"decommission_mode":    s["decommission_mode"].Default,
+		"decommission_timeout": s["decommission_timeout"].Default,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandVsanHostDiskMapping looks up the supplied cache and capacity disks on
+// the host's vSAN system and returns a VsanHostDiskMapping for them.
+func expandVsanHostDiskMapping(vs *object.HostVsanSystem, cacheName string, capacityNames []string) (*types.VsanHostDiskMapping, error) {
+	cache, err := hostVsanDisksByName(vs, []string{cacheName})
+	if err != nil {
+		return nil, fmt.Errorf("error locating cache disk: %s", err)
+	}
+	capacity, err := hostVsanDisksByName(vs, capacityNames)
+	if err != nil {
+		return nil, fmt.Errorf("error locating capacity disks: %s", err)
+	}
+	return &types.VsanHostDiskMapping{
+		Ssd:    cache[0],
+		NonSsd: capacity,
+	}, nil
+}
+
+// resourceVSphereVsanDiskGroupFlattenID makes an ID for the
+// vsphere_vsan_disk_group resource.
+func resourceVSphereVsanDiskGroupFlattenID(hsID, cacheName string) string {
+	return strings.Join([]string{hsID, cacheName}, ":")
+}
+
+// resourceVSphereVsanDiskGroupParseID parses an ID for the
+// vsphere_vsan_disk_group resource and outputs its parts.
+func resourceVSphereVsanDiskGroupParseID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("bad ID %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resourceVSphereVsanDiskGroupIDString prints a friendly string for the
+// vsphere_vsan_disk_group resource.
+func resourceVSphereVsanDiskGroupIDString(d structure.ResourceIDStringer) string {
+	return structure.ResourceIDString(d, resourceVSphereVsanDiskGroupName)
+}
@@ -0,0 +1,196 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestAccResourceVSphereVsanDiskGroup_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereVsanDiskGroupPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereVsanDiskGroupCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereVsanDiskGroupConfig(os.Getenv("VSPHERE_VSAN_CAPACITY_DISK1")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereVsanDiskGroupCheckExists(true),
+					testAccResourceVSphereVsanDiskGroupCheckCapacityDiskCount(1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereVsanDiskGroup_addCapacityDisk(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereVsanDiskGroupPreCheck(t)
+			if os.Getenv("VSPHERE_VSAN_CAPACITY_DISK2") == "" {
+				t.Skip("set VSPHERE_VSAN_CAPACITY_DISK2 to run this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereVsanDiskGroupCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereVsanDiskGroupConfig(os.Getenv("VSPHERE_VSAN_CAPACITY_DISK1")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereVsanDiskGroupCheckExists(true),
+					testAccResourceVSphereVsanDiskGroupCheckCapacityDiskCount(1),
+				),
+			},
+			{
+				Config: testAccResourceVSphereVsanDiskGroupConfig(
+					os.Getenv("VSPHERE_VSAN_CAPACITY_DISK1"),
+					os.Getenv("VSPHERE_VSAN_CAPACITY_DISK2"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereVsanDiskGroupCheckExists(true),
+					testAccResourceVSphereVsanDiskGroupCheckCapacityDiskCount(2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVSphereVsanDiskGroup_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccResourceVSphereVsanDiskGroupPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceVSphereVsanDiskGroupCheckExists(false),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceVSphereVsanDiskGroupConfig(os.Getenv("VSPHERE_VSAN_CAPACITY_DISK1")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceVSphereVsanDiskGroupCheckExists(true),
+				),
+			},
+			{
+				ResourceName:      "vsphere_vsan_disk_group.disk_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceVSphereVsanDiskGroupPreCheck(t *testing.T) {
+	if os.Getenv("VSPHERE_DATACENTER") == "" {
+		t.Skip("set VSPHERE_DATACENTER to run vsphere_vsan_disk_group acceptance tests")
+	}
+	if os.Getenv("VSPHERE_ESXI_HOST") == "" {
+		t.Skip("set VSPHERE_ESXI_HOST to run vsphere_vsan_disk_group acceptance tests")
+	}
+	if os.Getenv("VSPHERE_VSAN_CACHE_DISK") == "" {
+		t.Skip("set VSPHERE_VSAN_CACHE_DISK to run vsphere_vsan_disk_group acceptance tests")
+	}
+	if os.Getenv("VSPHERE_VSAN_CAPACITY_DISK1") == "" {
+		t.Skip("set VSPHERE_VSAN_CAPACITY_DISK1 to run vsphere_vsan_disk_group acceptance tests")
+	}
+}
+
+func testAccResourceVSphereVsanDiskGroupCheckExists(expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mapping, err := testGetVsanDiskGroup(s, "disk_group")
+		if err != nil {
+			if expected == false && err.Error() == "vsphere_vsan_disk_group.disk_group not found in state" {
+				return nil
+			}
+			return err
+		}
+		switch {
+		case mapping == nil && expected:
+			return errors.New("expected disk group to exist")
+		case mapping != nil && !expected:
+			return errors.New("expected disk group to be missing")
+		}
+		return nil
+	}
+}
+
+func testAccResourceVSphereVsanDiskGroupCheckCapacityDiskCount(expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		mapping, err := testGetVsanDiskGroup(s, "disk_group")
+		if err != nil {
+			return err
+		}
+		if mapping == nil {
+			return errors.New("disk group not found")
+		}
+		actual := len(mapping.NonSsd)
+		if expected != actual {
+			return fmt.Errorf("expected %d capacity disks, got %d", expected, actual)
+		}
+		return nil
+	}
+}
+
+// testGetVsanDiskGroup is a convenience method to fetch the disk mapping for
+// a vsphere_vsan_disk_group resource.
+func testGetVsanDiskGroup(s *terraform.State, resourceName string) (*types.VsanHostDiskMapping, error) {
+	vars, err := testClientVariablesForResource(s, fmt.Sprintf("%s.%s", resourceVSphereVsanDiskGroupName, resourceName))
+	if err != nil {
+		return nil, err
+	}
+	hsID, cacheName, err := resourceVSphereVsanDiskGroupParseID(vars.resourceID)
+	if err != nil {
+		return nil, err
+	}
+	vs, err := hostVsanSystemFromHostSystemID(vars.client, hsID)
+	if err != nil {
+		return nil, err
+	}
+	return hostVsanDiskMapping(vs, cacheName)
+}
+
+func testAccResourceVSphereVsanDiskGroupConfig(capacityDisks ...string) string {
+	var disks string
+	for _, disk := range capacityDisks {
+		disks += fmt.Sprintf("    %q,\n", disk)
+	}
+	return fmt.Sprintf(`
+variable "datacenter" {
+  default = "%s"
+}
+
+variable "esxi_host" {
+  default = "%s"
+}
+
+data "vsphere_datacenter" "dc" {
+  name = "${var.datacenter}"
+}
+
+data "vsphere_host" "esxi_host" {
+  name          = "${var.esxi_host}"
+  datacenter_id = "${data.vsphere_datacenter.dc.id}"
+}
+
+resource "vsphere_vsan_disk_group" "disk_group" {
+  host_system_id = "${data.vsphere_host.esxi_host.id}"
+  cache_disk     = "%s"
+
+  capacity_disks = [
+%s  ]
+}
+`,
+		os.Getenv("VSPHERE_DATACENTER"),
+		os.Getenv("VSPHERE_ESXI_HOST"),
+		os.Getenv("VSPHERE_VSAN_CACHE_DISK"),
+		disks,
+	)
+}
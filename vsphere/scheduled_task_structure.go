@@ -0,0 +1,694 @@
+package vsphere
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/structure"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	scheduledTaskFrequencyOnce    = "once"
+	scheduledTaskFrequencyHourly  = "hourly"
+	scheduledTaskFrequencyDaily   = "daily"
+	scheduledTaskFrequencyWeekly  = "weekly"
+	scheduledTaskFrequencyMonthly = "monthly"
+)
+
+var scheduledTaskFrequencyAllowedValues = []string{
+	scheduledTaskFrequencyOnce,
+	scheduledTaskFrequencyHourly,
+	scheduledTaskFrequencyDaily,
+	scheduledTaskFrequencyWeekly,
+	scheduledTaskFrequencyMonthly,
+}
+
+const (
+	scheduledTaskActionPowerOn        = "power_on"
+	scheduledTaskActionPowerOff       = "power_off"
+	scheduledTaskActionSuspend        = "suspend"
+	scheduledTaskActionCreateSnapshot = "create_snapshot"
+	scheduledTaskActionMigrate        = "migrate"
+	scheduledTaskActionConfigureDRS   = "configure_drs"
+)
+
+var scheduledTaskActionAllowedValues = []string{
+	scheduledTaskActionPowerOn,
+	scheduledTaskActionPowerOff,
+	scheduledTaskActionSuspend,
+	scheduledTaskActionCreateSnapshot,
+	scheduledTaskActionMigrate,
+	scheduledTaskActionConfigureDRS,
+}
+
+var scheduledTaskEntityTypeAllowedValues = []string{
+	"ClusterComputeResource",
+	"HostSystem",
+	"VirtualMachine",
+}
+
+// scheduledTaskMethods maps the actions supported for each entity type to
+// the vSphere API method that the scheduled task runs.
+var scheduledTaskMethods = map[string]map[string]string{
+	"VirtualMachine": {
+		scheduledTaskActionPowerOn:        "PowerOnVM_Task",
+		scheduledTaskActionPowerOff:       "PowerOffVM_Task",
+		scheduledTaskActionSuspend:        "SuspendVM_Task",
+		scheduledTaskActionCreateSnapshot: "CreateSnapshot_Task",
+		scheduledTaskActionMigrate:        "MigrateVM_Task",
+	},
+	"HostSystem": {
+		scheduledTaskActionPowerOn:  "PowerUpHostFromStandBy_Task",
+		scheduledTaskActionPowerOff: "ShutdownHost_Task",
+		scheduledTaskActionSuspend:  "PowerDownHostToStandBy_Task",
+	},
+	"ClusterComputeResource": {
+		scheduledTaskActionConfigureDRS: "ReconfigureComputeResource_Task",
+	},
+}
+
+var scheduledTaskDayOfWeekAllowedValues = []string{
+	string(types.DayOfWeekSunday),
+	string(types.DayOfWeekMonday),
+	string(types.DayOfWeekTuesday),
+	string(types.DayOfWeekWednesday),
+	string(types.DayOfWeekThursday),
+	string(types.DayOfWeekFriday),
+	string(types.DayOfWeekSaturday),
+}
+
+var scheduledTaskWeekOfMonthAllowedValues = []string{
+	string(types.WeekOfMonthFirst),
+	string(types.WeekOfMonthSecond),
+	string(types.WeekOfMonthThird),
+	string(types.WeekOfMonthFourth),
+	string(types.WeekOfMonthLast),
+}
+
+var scheduledTaskMigratePriorityAllowedValues = []string{
+	string(types.VirtualMachineMovePriorityLowPriority),
+	string(types.VirtualMachineMovePriorityHighPriority),
+	string(types.VirtualMachineMovePriorityDefaultPriority),
+}
+
+// schemaScheduledTaskSpec returns schema items for resources that need to
+// work with a ScheduledTaskSpec.
+func schemaScheduledTaskSpec() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the scheduled task.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The description of the scheduled task.",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether or not the scheduled task is enabled.",
+		},
+		"notification": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "An email address to notify when the task completes.",
+		},
+
+		// TaskScheduler
+		"frequency": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "How often the task runs. Can be one of once, hourly, daily, weekly, or monthly.",
+			ValidateFunc: validation.StringInSlice(scheduledTaskFrequencyAllowedValues, false),
+		},
+		"run_at": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The time to run a one-time task, in RFC3339 format.",
+			ValidateFunc:     validation.ValidateRFC3339TimeString,
+			DiffSuppressFunc: suppressEquivalentScheduledTaskTime,
+		},
+		"interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			Description:  "The number of hours, days, weeks, or months between runs of a recurring task.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"minute": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The minute of the hour to run a recurring task.",
+			ValidateFunc: validation.IntBetween(0, 59),
+		},
+		"hour": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The hour of the day to run a daily, weekly, or monthly task.",
+			ValidateFunc: validation.IntBetween(0, 23),
+		},
+		"days_of_week": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "The days of the week to run a weekly task.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(scheduledTaskDayOfWeekAllowedValues, false),
+			},
+		},
+		"day_of_month": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "The day of the month to run a monthly task.",
+			ValidateFunc:  validation.IntBetween(1, 31),
+			ConflictsWith: []string{"week_of_month", "day_of_week"},
+		},
+		"week_of_month": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The week of the month to run a monthly task. Used with day_of_week.",
+			ValidateFunc: validation.StringInSlice(scheduledTaskWeekOfMonthAllowedValues, false),
+		},
+		"day_of_week": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The day of the week to run a monthly task. Used with week_of_month.",
+			ValidateFunc: validation.StringInSlice(scheduledTaskDayOfWeekAllowedValues, false),
+		},
+		"start_time": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The time that a recurring task becomes active, in RFC3339 format.",
+			ValidateFunc:     validation.ValidateRFC3339TimeString,
+			DiffSuppressFunc: suppressEquivalentScheduledTaskTime,
+		},
+		"end_time": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The time that a recurring task expires, in RFC3339 format.",
+			ValidateFunc:     validation.ValidateRFC3339TimeString,
+			DiffSuppressFunc: suppressEquivalentScheduledTaskTime,
+		},
+
+		// MethodAction
+		"action": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The action to run. Can be one of power_on, power_off, suspend, create_snapshot, migrate, or configure_drs.",
+			ValidateFunc: validation.StringInSlice(scheduledTaskActionAllowedValues, false),
+		},
+		"snapshot_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the snapshot to create. Used with the create_snapshot action.",
+		},
+		"snapshot_description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The description of the snapshot to create. Used with the create_snapshot action.",
+		},
+		"snapshot_memory": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether or not to include the memory of the virtual machine in the snapshot. Used with the create_snapshot action.",
+		},
+		"snapshot_quiesce": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether or not to quiesce the file system of the virtual machine before taking the snapshot. Used with the create_snapshot action.",
+		},
+		"migrate_resource_pool_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The managed object ID of the resource pool to migrate the virtual machine to. Used with the migrate action.",
+		},
+		"migrate_host_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The managed object ID of the host to migrate the virtual machine to. Used with the migrate action.",
+		},
+		"migrate_priority": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(types.VirtualMachineMovePriorityDefaultPriority),
+			Description:  "The priority of the migration. Used with the migrate action.",
+			ValidateFunc: validation.StringInSlice(scheduledTaskMigratePriorityAllowedValues, false),
+		},
+		"host_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The time, in seconds, to wait for a host to enter or exit standby mode. Used with the power_on and suspend actions on hosts. 0 means no timeout.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"drs_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether or not to enable DRS on the cluster. Used with the configure_drs action.",
+		},
+		"drs_automation_level": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      string(types.DrsBehaviorManual),
+			Description:  "The default DRS automation level to set on the cluster. Can be one of manual, partiallyAutomated, or fullyAutomated. Used with the configure_drs action.",
+			ValidateFunc: validation.StringInSlice(drsBehaviorAllowedValues, false),
+		},
+
+		// Computed
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The state of the last run of the task.",
+		},
+		"last_run_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time the task last ran, in RFC3339 format.",
+		},
+		"last_run_error": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The error from the last run of the task, if it failed.",
+		},
+		"next_run_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time the task will next run, in RFC3339 format.",
+		},
+	}
+}
+
+// suppressEquivalentScheduledTaskTime suppresses diffs between two RFC3339
+// timestamps that represent the same instant in different time zones.
+func suppressEquivalentScheduledTaskTime(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+// expandScheduledTaskTime parses the RFC3339 time at key. nil is returned if
+// the key is empty.
+func expandScheduledTaskTime(d *schema.ResourceData, key string) (*time.Time, error) {
+	v := d.Get(key).(string)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", key, err)
+	}
+	return &t, nil
+}
+
+// flattenScheduledTaskTime formats a time in RFC3339 format. An empty string
+// is returned for a nil time.
+func flattenScheduledTaskTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// expandDailyTaskScheduler reads the fields shared by the daily, weekly, and
+// monthly schedulers into a DailyTaskScheduler.
+func expandDailyTaskScheduler(d *schema.ResourceData, base types.TaskScheduler) types.DailyTaskScheduler {
+	return types.DailyTaskScheduler{
+		HourlyTaskScheduler: types.HourlyTaskScheduler{
+			RecurrentTaskScheduler: types.RecurrentTaskScheduler{
+				TaskScheduler: base,
+				Interval:      int32(d.Get("interval").(int)),
+			},
+			Minute: int32(d.Get("minute").(int)),
+		},
+		Hour: int32(d.Get("hour").(int)),
+	}
+}
+
+// expandTaskScheduler reads certain ResourceData keys and returns a
+// BaseTaskScheduler of the type selected by frequency.
+func expandTaskScheduler(d *schema.ResourceData) (types.BaseTaskScheduler, error) {
+	var base types.TaskScheduler
+	var err error
+	if base.ActiveTime, err = expandScheduledTaskTime(d, "start_time"); err != nil {
+		return nil, err
+	}
+	if base.ExpireTime, err = expandScheduledTaskTime(d, "end_time"); err != nil {
+		return nil, err
+	}
+
+	switch d.Get("frequency").(string) {
+	case scheduledTaskFrequencyOnce:
+		runAt, err := expandScheduledTaskTime(d, "run_at")
+		if err != nil {
+			return nil, err
+		}
+		if runAt == nil {
+			return nil, fmt.Errorf("run_at is required when frequency is %q", scheduledTaskFrequencyOnce)
+		}
+		return &types.OnceTaskScheduler{
+			TaskScheduler: base,
+			RunAt:         runAt,
+		}, nil
+	case scheduledTaskFrequencyHourly:
+		return &types.HourlyTaskScheduler{
+			RecurrentTaskScheduler: types.RecurrentTaskScheduler{
+				TaskScheduler: base,
+				Interval:      int32(d.Get("interval").(int)),
+			},
+			Minute: int32(d.Get("minute").(int)),
+		}, nil
+	case scheduledTaskFrequencyDaily:
+		obj := expandDailyTaskScheduler(d, base)
+		return &obj, nil
+	case scheduledTaskFrequencyWeekly:
+		obj := &types.WeeklyTaskScheduler{
+			DailyTaskScheduler: expandDailyTaskScheduler(d, base),
+		}
+		days := d.Get("days_of_week").(*schema.Set)
+		if days.Len() < 1 {
+			return nil, fmt.Errorf("days_of_week is required when frequency is %q", scheduledTaskFrequencyWeekly)
+		}
+		obj.Sunday = days.Contains(string(types.DayOfWeekSunday))
+		obj.Monday = days.Contains(string(types.DayOfWeekMonday))
+		obj.Tuesday = days.Contains(string(types.DayOfWeekTuesday))
+		obj.Wednesday = days.Contains(string(types.DayOfWeekWednesday))
+		obj.Thursday = days.Contains(string(types.DayOfWeekThursday))
+		obj.Friday = days.Contains(string(types.DayOfWeekFriday))
+		obj.Saturday = days.Contains(string(types.DayOfWeekSaturday))
+		return obj, nil
+	case scheduledTaskFrequencyMonthly:
+		monthly := types.MonthlyTaskScheduler{
+			DailyTaskScheduler: expandDailyTaskScheduler(d, base),
+		}
+		if v, ok := d.GetOk("day_of_month"); ok {
+			return &types.MonthlyByDayTaskScheduler{
+				MonthlyTaskScheduler: monthly,
+				Day:                  int32(v.(int)),
+			}, nil
+		}
+		week := d.Get("week_of_month").(string)
+		day := d.Get("day_of_week").(string)
+		if week == "" || day == "" {
+			return nil, fmt.Errorf("either day_of_month, or week_of_month and day_of_week, are required when frequency is %q", scheduledTaskFrequencyMonthly)
+		}
+		return &types.MonthlyByWeekdayTaskScheduler{
+			MonthlyTaskScheduler: monthly,
+			Offset:               types.WeekOfMonth(week),
+			Weekday:              types.DayOfWeek(day),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported frequency %q", d.Get("frequency").(string))
+}
+
+// flattenDailyTaskScheduler reads the fields shared by the daily, weekly, and
+// monthly schedulers into the supplied map.
+func flattenDailyTaskScheduler(m map[string]interface{}, obj *types.DailyTaskScheduler) {
+	m["interval"] = obj.Interval
+	m["minute"] = obj.Minute
+	m["hour"] = obj.Hour
+}
+
+// flattenTaskScheduler saves a BaseTaskScheduler into ResourceData.
+func flattenTaskScheduler(d *schema.ResourceData, obj types.BaseTaskScheduler) error {
+	base := obj.GetTaskScheduler()
+	m := map[string]interface{}{
+		"run_at":        "",
+		"interval":      1,
+		"minute":        0,
+		"hour":          0,
+		"days_of_week":  []interface{}{},
+		"day_of_month":  0,
+		"week_of_month": "",
+		"day_of_week":   "",
+		"start_time":    flattenScheduledTaskTime(base.ActiveTime),
+		"end_time":      flattenScheduledTaskTime(base.ExpireTime),
+	}
+
+	switch s := obj.(type) {
+	case *types.OnceTaskScheduler:
+		m["frequency"] = scheduledTaskFrequencyOnce
+		m["run_at"] = flattenScheduledTaskTime(s.RunAt)
+	case *types.HourlyTaskScheduler:
+		m["frequency"] = scheduledTaskFrequencyHourly
+		m["interval"] = s.Interval
+		m["minute"] = s.Minute
+	case *types.DailyTaskScheduler:
+		m["frequency"] = scheduledTaskFrequencyDaily
+		flattenDailyTaskScheduler(m, s)
+	case *types.WeeklyTaskScheduler:
+		m["frequency"] = scheduledTaskFrequencyWeekly
+		flattenDailyTaskScheduler(m, &s.DailyTaskScheduler)
+		var days []string
+		for day, set := range map[types.DayOfWeek]bool{
+			types.DayOfWeekSunday:    s.Sunday,
+			types.DayOfWeekMonday:    s.Monday,
+			types.DayOfWeekTuesday:   s.Tuesday,
+			types.DayOfWeekWednesday: s.Wednesday,
+			types.DayOfWeekThursday:  s.Thursday,
+			types.DayOfWeekFriday:    s.Friday,
+			types.DayOfWeekSaturday:  s.Saturday,
+		} {
+			if set {
+				days = append(days, string(day))
+			}
+		}
+		m["days_of_week"] = days
+	case *types.MonthlyByDayTaskScheduler:
+		m["frequency"] = scheduledTaskFrequencyMonthly
+		flattenDailyTaskScheduler(m, &s.DailyTaskScheduler)
+		m["day_of_month"] = s.Day
+	case *types.MonthlyByWeekdayTaskScheduler:
+		m["frequency"] = scheduledTaskFrequencyMonthly
+		flattenDailyTaskScheduler(m, &s.DailyTaskScheduler)
+		m["week_of_month"] = string(s.Offset)
+		m["day_of_week"] = string(s.Weekday)
+	default:
+		return fmt.Errorf("unsupported task scheduler type %T", obj)
+	}
+	return structure.SetBatch(d, m)
+}
+
+// validateScheduledTaskAction checks that the supplied action is supported
+// for the supplied entity type.
+func validateScheduledTaskAction(entityType, action string) error {
+	if _, ok := scheduledTaskMethods[entityType][action]; !ok {
+		return fmt.Errorf("action %q is not supported for entity type %q", action, entityType)
+	}
+	return nil
+}
+
+// expandScheduledTaskAction reads certain ResourceData keys and returns the
+// MethodAction that the scheduled task runs.
+func expandScheduledTaskAction(d *schema.ResourceData) (*types.MethodAction, error) {
+	entityType := d.Get("entity_type").(string)
+	action := d.Get("action").(string)
+	if err := validateScheduledTaskAction(entityType, action); err != nil {
+		return nil, err
+	}
+	obj := &types.MethodAction{
+		Name: scheduledTaskMethods[entityType][action],
+	}
+
+	var args []types.AnyType
+	switch obj.Name {
+	case "PowerOnVM_Task":
+		// host
+		args = []types.AnyType{nil}
+	case "CreateSnapshot_Task":
+		if d.Get("snapshot_name").(string) == "" {
+			return nil, fmt.Errorf("snapshot_name is required for the %q action", action)
+		}
+		args = []types.AnyType{
+			d.Get("snapshot_name").(string),
+			d.Get("snapshot_description").(string),
+			d.Get("snapshot_memory").(bool),
+			d.Get("snapshot_quiesce").(bool),
+		}
+	case "MigrateVM_Task":
+		var pool, host types.AnyType
+		if v := d.Get("migrate_resource_pool_id").(string); v != "" {
+			pool = types.ManagedObjectReference{Type: "ResourcePool", Value: v}
+		}
+		if v := d.Get("migrate_host_id").(string); v != "" {
+			host = types.ManagedObjectReference{Type: "HostSystem", Value: v}
+		}
+		if pool == nil && host == nil {
+			return nil, fmt.Errorf("one of migrate_resource_pool_id or migrate_host_id is required for the %q action", action)
+		}
+		args = []types.AnyType{
+			pool,
+			host,
+			types.VirtualMachineMovePriority(d.Get("migrate_priority").(string)),
+			nil,
+		}
+	case "PowerUpHostFromStandBy_Task":
+		args = []types.AnyType{int32(d.Get("host_timeout").(int))}
+	case "ShutdownHost_Task":
+		// force
+		args = []types.AnyType{false}
+	case "PowerDownHostToStandBy_Task":
+		// timeoutSec, evacuatePoweredOffVms
+		args = []types.AnyType{int32(d.Get("host_timeout").(int)), nil}
+	case "ReconfigureComputeResource_Task":
+		// spec, modify
+		args = []types.AnyType{
+			&types.ClusterConfigSpecEx{
+				DrsConfig: &types.ClusterDrsConfigInfo{
+					Enabled:           structure.GetBool(d, "drs_enabled"),
+					DefaultVmBehavior: types.DrsBehavior(d.Get("drs_automation_level").(string)),
+				},
+			},
+			true,
+		}
+	}
+	for _, arg := range args {
+		obj.Argument = append(obj.Argument, types.MethodActionArgument{Value: arg})
+	}
+	return obj, nil
+}
+
+// scheduledTaskActionArgument returns the value of the argument at index i,
+// or nil if it is not present.
+func scheduledTaskActionArgument(obj *types.MethodAction, i int) types.AnyType {
+	if i >= len(obj.Argument) {
+		return nil
+	}
+	return obj.Argument[i].Value
+}
+
+// scheduledTaskActionArgumentRef returns the managed object ID of the
+// reference argument at index i, or an empty string if it is not set.
+func scheduledTaskActionArgumentRef(obj *types.MethodAction, i int) string {
+	switch v := scheduledTaskActionArgument(obj, i).(type) {
+	case types.ManagedObjectReference:
+		return v.Value
+	case *types.ManagedObjectReference:
+		return v.Value
+	}
+	return ""
+}
+
+// flattenScheduledTaskAction saves the MethodAction that a scheduled task runs
+// into ResourceData.
+func flattenScheduledTaskAction(d *schema.ResourceData, entityType string, obj types.BaseAction) error {
+	ma, ok := obj.(*types.MethodAction)
+	if !ok {
+		return fmt.Errorf("unsupported scheduled task action type %T", obj)
+	}
+	var action string
+	for k, v := range scheduledTaskMethods[entityType] {
+		if v == ma.Name {
+			action = k
+		}
+	}
+	if action == "" {
+		return fmt.Errorf("unsupported scheduled task method %q for entity type %q", ma.Name, entityType)
+	}
+
+	m := map[string]interface{}{
+		"action":                   action,
+		"snapshot_name":            "",
+		"snapshot_description":     "",
+		"snapshot_memory":          false,
+		"snapshot_quiesce":         false,
+		"migrate_resource_pool_id": "",
+		"migrate_host_id":          "",
+		"migrate_priority":         string(types.VirtualMachineMovePriorityDefaultPriority),
+		"host_timeout":             0,
+		"drs_enabled":              true,
+		"drs_automation_level":     string(types.DrsBehaviorManual),
+	}
+	switch ma.Name {
+	case "CreateSnapshot_Task":
+		m["snapshot_name"], _ = scheduledTaskActionArgument(ma, 0).(string)
+		m["snapshot_description"], _ = scheduledTaskActionArgument(ma, 1).(string)
+		m["snapshot_memory"], _ = scheduledTaskActionArgument(ma, 2).(bool)
+		m["snapshot_quiesce"], _ = scheduledTaskActionArgument(ma, 3).(bool)
+	case "MigrateVM_Task":
+		m["migrate_resource_pool_id"] = scheduledTaskActionArgumentRef(ma, 0)
+		m["migrate_host_id"] = scheduledTaskActionArgumentRef(ma, 1)
+		if v, ok := scheduledTaskActionArgument(ma, 2).(types.VirtualMachineMovePriority); ok {
+			m["migrate_priority"] = string(v)
+		}
+	case "PowerUpHostFromStandBy_Task", "PowerDownHostToStandBy_Task":
+		if v, ok := scheduledTaskActionArgument(ma, 0).(int32); ok {
+			m["host_timeout"] = v
+		}
+	case "ReconfigureComputeResource_Task":
+		var spec *types.ClusterConfigSpecEx
+		switch v := scheduledTaskActionArgument(ma, 0).(type) {
+		case types.ClusterConfigSpecEx:
+			spec = &v
+		case *types.ClusterConfigSpecEx:
+			spec = v
+		}
+		if spec != nil && spec.DrsConfig != nil {
+			if spec.DrsConfig.Enabled != nil {
+				m["drs_enabled"] = *spec.DrsConfig.Enabled
+			}
+			if spec.DrsConfig.DefaultVmBehavior != "" {
+				m["drs_automation_level"] = string(spec.DrsConfig.DefaultVmBehavior)
+			}
+		}
+	}
+	return structure.SetBatch(d, m)
+}
+
+// expandScheduledTaskSpec reads certain ResourceData keys and returns a
+// ScheduledTaskSpec.
+func expandScheduledTaskSpec(d *schema.ResourceData) (*types.ScheduledTaskSpec, error) {
+	scheduler, err := expandTaskScheduler(d)
+	if err != nil {
+		return nil, err
+	}
+	action, err := expandScheduledTaskAction(d)
+	if err != nil {
+		return nil, err
+	}
+	obj := &types.ScheduledTaskSpec{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		Enabled:      d.Get("enabled").(bool),
+		Scheduler:    scheduler,
+		Action:       action,
+		Notification: d.Get("notification").(string),
+	}
+	return obj, nil
+}
+
+// flattenScheduledTaskInfo reads various fields from a ScheduledTaskInfo into
+// the passed in ResourceData.
+func flattenScheduledTaskInfo(d *schema.ResourceData, obj *types.ScheduledTaskInfo) error {
+	d.Set("name", obj.Name)
+	d.Set("description", obj.Description)
+	d.Set("enabled", obj.Enabled)
+	d.Set("notification", obj.Notification)
+	if err := flattenTaskScheduler(d, obj.Scheduler); err != nil {
+		return err
+	}
+	if err := flattenScheduledTaskAction(d, obj.Entity.Type, obj.Action); err != nil {
+		return err
+	}
+
+	d.Set("state", string(obj.State))
+	d.Set("last_run_time", flattenScheduledTaskTime(obj.PrevRunTime))
+	d.Set("next_run_time", flattenScheduledTaskTime(obj.NextRunTime))
+	var lastErr string
+	if obj.Error != nil {
+		lastErr = obj.Error.LocalizedMessage
+	}
+	d.Set("last_run_error", lastErr)
+	return nil
+}
@@ -0,0 +1,202 @@
+package vsphere
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const (
+	cloudInitEncodingGzipBase64 = "gzip+base64"
+	cloudInitEncodingBase64     = "base64"
+)
+
+var cloudInitEncodingAllowedValues = []string{
+	cloudInitEncodingGzipBase64,
+	cloudInitEncodingBase64,
+}
+
+// cloudInitGuestInfoKeys maps the attributes in the cloud_init block to the
+// guestinfo keys read by the cloud-init VMware guestinfo datasource. Each key
+// has a matching .encoding key that describes how the value is encoded.
+var cloudInitGuestInfoKeys = []struct {
+	attr string
+	key  string
+}{
+	{attr: "user_data", key: "guestinfo.userdata"},
+	{attr: "meta_data", key: "guestinfo.metadata"},
+	{attr: "vendor_data", key: "guestinfo.vendordata"},
+}
+
+// schemaVirtualMachineCloudInit returns the schema for the cloud_init block
+// of the vsphere_virtual_machine resource.
+func schemaVirtualMachineCloudInit() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"user_data": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The cloud-init user data, such as a #cloud-config document.",
+		},
+		"meta_data": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The cloud-init instance metadata, in JSON or YAML.",
+		},
+		"vendor_data": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The cloud-init vendor data.",
+		},
+		"encoding": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      cloudInitEncodingGzipBase64,
+			Description:  "The encoding used for the guestinfo values. Can be one of gzip+base64 or base64.",
+			ValidateFunc: validation.StringInSlice(cloudInitEncodingAllowedValues, false),
+		},
+	}
+}
+
+// cloudInitExtraConfigKeys returns all of the extraConfig keys managed by the
+// cloud_init block.
+func cloudInitExtraConfigKeys() []string {
+	var keys []string
+	for _, k := range cloudInitGuestInfoKeys {
+		keys = append(keys, k.key, k.key+".encoding")
+	}
+	return keys
+}
+
+// validateCloudInitExtraConfig checks that extra_config does not contain any
+// of the keys managed by cloud_init when the cloud_init block is in use.
+func validateCloudInitExtraConfig(d *schema.ResourceDiff) error {
+	if len(d.Get("cloud_init").([]interface{})) < 1 {
+		return nil
+	}
+	ec := d.Get("extra_config").(map[string]interface{})
+	for _, k := range cloudInitExtraConfigKeys() {
+		if _, ok := ec[k]; ok {
+			return fmt.Errorf("extra_config key %q cannot be set when using cloud_init", k)
+		}
+	}
+	return nil
+}
+
+// encodeCloudInitValue encodes a cloud-init value using the supplied encoding.
+func encodeCloudInitValue(v, encoding string) (string, error) {
+	if encoding == cloudInitEncodingBase64 {
+		return base64.StdEncoding.EncodeToString([]byte(v)), nil
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(v)); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeCloudInitValue decodes a cloud-init value using the supplied encoding.
+func decodeCloudInitValue(v, encoding string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return "", err
+	}
+	if encoding == cloudInitEncodingBase64 {
+		return string(b), nil
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+	out, err := ioutil.ReadAll(gz)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// expandCloudInitExtraConfig reads the cloud_init block and returns the
+// appropriate OptionValue slice, to be merged with the ones from
+// extra_config.
+//
+// Like expandExtraConfig, this returns nil when there is no change. Values
+// removed from configuration are added with an empty value to ensure they are
+// removed from extraConfig on the update.
+func expandCloudInitExtraConfig(d *schema.ResourceData) ([]types.BaseOptionValue, error) {
+	if !d.HasChange("cloud_init") {
+		return nil, nil
+	}
+	var opts []types.BaseOptionValue
+	encoding := d.Get("cloud_init.0.encoding").(string)
+	for _, k := range cloudInitGuestInfoKeys {
+		attr, key := k.attr, k.key
+		var value, encValue string
+		if v, ok := d.GetOk("cloud_init.0." + attr); ok {
+			var err error
+			if value, err = encodeCloudInitValue(v.(string), encoding); err != nil {
+				return nil, fmt.Errorf("error encoding cloud_init %s: %s", attr, err)
+			}
+			encValue = encoding
+		}
+		opts = append(
+			opts,
+			&types.OptionValue{Key: key, Value: value},
+			&types.OptionValue{Key: key + ".encoding", Value: encValue},
+		)
+	}
+	return opts, nil
+}
+
+// flattenCloudInit reads the cloud-init guestinfo keys from the extraConfig of
+// a virtual machine and decodes them into the cloud_init block. This is only
+// done if cloud_init is already in use, so that guestinfo values maintained
+// out-of-band are not taken over.
+func flattenCloudInit(d *schema.ResourceData, opts []types.BaseOptionValue) error {
+	if len(d.Get("cloud_init").([]interface{})) < 1 {
+		return nil
+	}
+	ec := make(map[string]string)
+	for _, v := range opts {
+		ov := v.GetOptionValue()
+		if s, ok := ov.Value.(string); ok {
+			ec[ov.Key] = s
+		}
+	}
+	m := map[string]interface{}{
+		"encoding": d.Get("cloud_init.0.encoding").(string),
+	}
+	for _, k := range cloudInitGuestInfoKeys {
+		attr, key := k.attr, k.key
+		raw, ok := ec[key]
+		if !ok || raw == "" {
+			continue
+		}
+		encoding := ec[key+".encoding"]
+		if encoding != cloudInitEncodingGzipBase64 && encoding != cloudInitEncodingBase64 {
+			// Unknown encoding, set the raw value so that it will be corrected
+			// on the next apply.
+			m[attr] = raw
+			continue
+		}
+		m["encoding"] = encoding
+		value, err := decodeCloudInitValue(raw, encoding)
+		if err != nil {
+			log.Printf("[DEBUG] %s: Error decoding %s: %s", resourceVSphereVirtualMachineIDString(d), key, err)
+			m[attr] = raw
+			continue
+		}
+		m[attr] = value
+	}
+	return d.Set("cloud_init", []interface{}{m})
+}
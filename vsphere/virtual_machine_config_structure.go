@@ -253,6 +253,13 @@ func schemaVirtualMachineConfigSpec() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Extra configuration data for this virtual machine. Can be used to supply advanced parameters not normally in configuration, such as instance metadata, or configuration data for OVF images.",
 		},
+		"cloud_init": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "cloud-init configuration data for this virtual machine, passed to the guest through the cloud-init VMware guestinfo datasource.",
+			MaxItems:    1,
+			Elem:        &schema.Resource{Schema: schemaVirtualMachineCloudInit()},
+		},
 		"vapp": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -775,6 +782,10 @@ func expandVirtualMachineConfigSpec(d *schema.ResourceData, client *govmomi.Clie
 	if err != nil {
 		return types.VirtualMachineConfigSpec{}, err
 	}
+	cloudInitOpts, err := expandCloudInitExtraConfig(d)
+	if err != nil {
+		return types.VirtualMachineConfigSpec{}, err
+	}
 
 	obj := types.VirtualMachineConfigSpec{
 		Name:                         d.Get("name").(string),
@@ -792,7 +803,7 @@ func expandVirtualMachineConfigSpec(d *schema.ResourceData, client *govmomi.Clie
 		CpuAllocation:                expandVirtualMachineResourceAllocation(d, "cpu"),
 		MemoryAllocation:             expandVirtualMachineResourceAllocation(d, "memory"),
 		MemoryReservationLockedToMax: getMemoryReservationLockedToMax(d),
-		ExtraConfig:                  expandExtraConfig(d),
+		ExtraConfig:                  append(expandExtraConfig(d), cloudInitOpts...),
 		SwapPlacement:                getWithRestart(d, "swap_placement_policy").(string),
 		BootOptions:                  expandVirtualMachineBootOptions(d, client),
 		VAppConfig:                   vappConfig,
@@ -845,6 +856,9 @@ func flattenVirtualMachineConfigInfo(d *schema.ResourceData, obj *types.VirtualM
 	if err := flattenExtraConfig(d, obj.ExtraConfig); err != nil {
 		return err
 	}
+	if err := flattenCloudInit(d, obj.ExtraConfig); err != nil {
+		return err
+	}
 	if err := flattenVAppConfig(d, obj.VAppConfig); err != nil {
 		return err
 	}
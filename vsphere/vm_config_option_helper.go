@@ -0,0 +1,72 @@
+package vsphere
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/computeresource"
+	"github.com/terraform-providers/terraform-provider-vsphere/vsphere/internal/helper/hostsystem"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// schemaVMConfigOptionTarget returns the attributes that select the cluster or
+// host to query virtual machine config options for. These are used by the
+// vsphere_guest_os and vsphere_vm_hardware_options data sources.
+func schemaVMConfigOptionTarget() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_id": {
+			Type:          schema.TypeString,
+			Description:   "The managed object ID of the cluster to query. Conflicts with host_system_id.",
+			Optional:      true,
+			ConflictsWith: []string{"host_system_id"},
+		},
+		"host_system_id": {
+			Type:          schema.TypeString,
+			Description:   "The managed object ID of the host to query. Conflicts with cluster_id.",
+			Optional:      true,
+			ConflictsWith: []string{"cluster_id"},
+		},
+	}
+}
+
+// vmConfigOptionTarget returns the compute resource whose environment browser
+// should be queried for the cluster_id or host_system_id in the supplied
+// ResourceData. If host_system_id is set, the host is returned as well, so
+// that results can be limited to it.
+func vmConfigOptionTarget(d *schema.ResourceData, client *govmomi.Client) (types.ManagedObjectReference, *object.HostSystem, error) {
+	if id, ok := d.GetOk("cluster_id"); ok {
+		cluster, err := computeresource.ClusterFromID(client, id.(string))
+		if err != nil {
+			return types.ManagedObjectReference{}, nil, fmt.Errorf("cannot locate cluster: %s", err)
+		}
+		return cluster.Reference(), nil, nil
+	}
+	id, ok := d.GetOk("host_system_id")
+	if !ok {
+		return types.ManagedObjectReference{}, nil, errors.New("one of cluster_id or host_system_id must be set")
+	}
+	host, err := hostsystem.FromID(client, id.(string))
+	if err != nil {
+		return types.ManagedObjectReference{}, nil, fmt.Errorf("cannot locate host: %s", err)
+	}
+	props, err := hostsystem.Properties(host)
+	if err != nil {
+		return types.ManagedObjectReference{}, nil, fmt.Errorf("error fetching host properties: %s", err)
+	}
+	if props.Parent == nil {
+		return types.ManagedObjectReference{}, nil, fmt.Errorf("host %q has no parent compute resource", id)
+	}
+	return *props.Parent, host, nil
+}
+
+// vmConfigOptionID returns the ID for a data source that queries the config
+// options of the supplied compute resource or host.
+func vmConfigOptionID(ref types.ManagedObjectReference, host *object.HostSystem, suffix string) string {
+	if host != nil {
+		ref = host.Reference()
+	}
+	return fmt.Sprintf("%s:%s", ref.Value, suffix)
+}